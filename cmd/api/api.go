@@ -4,14 +4,38 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 
 	"github.com/jayden1905/abundance/cmd/pkg/database"
 	"github.com/jayden1905/abundance/config"
+	"github.com/jayden1905/abundance/service/activity"
+	"github.com/jayden1905/abundance/service/alert"
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/service/cgmimport"
+	"github.com/jayden1905/abundance/service/dietaryrestriction"
 	"github.com/jayden1905/abundance/service/email"
+	"github.com/jayden1905/abundance/service/food"
+	"github.com/jayden1905/abundance/service/glucose"
+	"github.com/jayden1905/abundance/service/glycemic"
+	"github.com/jayden1905/abundance/service/goal"
+	"github.com/jayden1905/abundance/service/healthcondition"
+	"github.com/jayden1905/abundance/service/lab"
+	"github.com/jayden1905/abundance/service/meal"
+	"github.com/jayden1905/abundance/service/mealresponse"
+	"github.com/jayden1905/abundance/service/medication"
+	"github.com/jayden1905/abundance/service/mfa"
+	"github.com/jayden1905/abundance/service/oidc"
+	"github.com/jayden1905/abundance/service/profile"
+	"github.com/jayden1905/abundance/service/share"
+	"github.com/jayden1905/abundance/service/stats"
+	"github.com/jayden1905/abundance/service/timeline"
+	"github.com/jayden1905/abundance/service/token"
 	"github.com/jayden1905/abundance/service/user"
+	"github.com/jayden1905/abundance/service/webauthn"
+	"github.com/jayden1905/abundance/utils"
 )
 
 type apiConfig struct {
@@ -27,11 +51,21 @@ func NewAPIServer(addr string, db *sql.DB) *apiConfig {
 }
 
 func (s *apiConfig) Run() error {
-	app := fiber.New()
+	app := fiber.New(fiber.Config{
+		// Bodies over the default limit are streamed rather than rejected, so that CGM
+		// uploads can be larger. LimitBody keeps every other route at the default.
+		StreamRequestBody:            true,
+		DisablePreParseMultipartForm: true,
+	})
+
+	app.Use(utils.LimitBody(fiber.DefaultBodyLimit, func(c *fiber.Ctx) bool {
+		// CGM uploads are limited by their own route
+		return c.Method() == fiber.MethodPost && c.Path() == "/api/v1"+cgmimport.UploadPath
+	}))
 
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     fmt.Sprintf("%s, http://localhost:5173", config.Envs.PublicHost),
-		AllowMethods:     "GET,POST,PUT,DELETE,OPTIONS",
+		AllowMethods:     "GET,POST,PUT,PATCH,DELETE,OPTIONS",
 		AllowHeaders:     "Origin,Content-Type,Accept,Content-Length,Accept-Language,Accept-Encoding,Connection,Access-Control-Allow-Origin,Authorization",
 		AllowCredentials: true,
 	}))
@@ -43,11 +77,136 @@ func (s *apiConfig) Run() error {
 
 	// Define the user store and handler
 	userStore := user.NewStore(s.db)
+	tokenStore := token.NewStore(s.db)
 	mailer := email.NewEmailService()
-	userHandler := user.NewHandler(userStore, mailer)
+	mfaStore := mfa.NewStore(s.db)
+	webauthnStore := webauthn.NewStore(s.db)
+	relyingParty := webauthn.NewRelyingParty(config.Envs.WebAuthnRPID, config.Envs.WebAuthnRPName, config.Envs.WebAuthnOrigins)
+	oidcStore := oidc.NewStore(s.db)
+	oidcProviders := make(map[string]*oidc.Provider)
+	for _, p := range config.Envs.OIDCProviders {
+		oidcProviders[p.Name] = oidc.NewProvider(p.Name, p.Issuer, p.ClientID, p.ClientSecret, p.RedirectURL, nil)
+	}
+	userHandler := user.NewHandler(userStore, tokenStore, mfaStore, webauthnStore, relyingParty, oidcStore, oidcProviders, mailer)
+	mfaHandler := mfa.NewHandler(mfaStore, userStore)
+
+	// Load the keys tokens are signed with and rotate them on schedule
+	keySet := auth.NewKeySet(
+		config.Envs.JWTKeysDir,
+		config.Envs.JWTSigningAlgorithm,
+		time.Second*time.Duration(config.Envs.JWTKeyRotationInSeconds),
+		time.Second*time.Duration(config.Envs.JWTExpirationInSeconds)+time.Hour,
+	)
+	if err := auth.UseSigningKeys(keySet, time.Hour); err != nil {
+		return err
+	}
+
+	// Load revoked tokens so the auth middleware can reject them
+	if err := auth.UseRevocationStore(tokenStore, time.Minute); err != nil {
+		return err
+	}
+
+	// Define the glucose and profile stores
+	glucoseStore := glucose.NewStore(s.db)
+	profileStore := profile.NewStore(s.db)
+
+	// Define the share store and make the auth middleware honour caregiver shares
+	shareStore := share.NewStore(s.db)
+	auth.UseShareStore(shareStore)
+	shareHandler := share.NewHandler(shareStore, userStore, mailer)
+
+	// Define the glucose alert store, engine and handler. New readings are checked
+	// as they are logged and a monitor looks for missing data.
+	alertStore := alert.NewStore(s.db)
+	alertEngine := alert.NewEngine(alertStore, glucoseStore, profileStore, userStore, shareStore, alert.NewEmailChannel(mailer))
+	alertEngine.StartMonitor(5 * time.Minute)
+	alertHandler := alert.NewHandler(alertStore, alertEngine, profileStore, userStore)
+
+	// Define the glucose handler
+	glucoseHandler := glucose.NewHandler(glucoseStore, alertEngine, userStore)
+
+	// Define the goal, health condition and dietary restriction stores and handlers
+	goalStore := goal.NewStore(s.db)
+	goalHandler := goal.NewHandler(goalStore, userStore)
+	healthConditionStore := healthcondition.NewStore(s.db)
+	healthConditionHandler := healthcondition.NewHandler(healthConditionStore, userStore)
+	dietaryRestrictionStore := dietaryrestriction.NewStore(s.db)
+	dietaryRestrictionHandler := dietaryrestriction.NewHandler(dietaryRestrictionStore, userStore)
+
+	// Define the profile handler
+	profileHandler := profile.NewHandler(profileStore, userStore)
+
+	// Define the glucose statistics handler
+	statsHandler := stats.NewHandler(glucoseStore, profileStore, userStore)
+
+	// Define the CGM export import handler
+	importHandler := cgmimport.NewHandler(glucoseStore, profileStore, userStore)
+
+	// Define the meal store and handler
+	mealStore := meal.NewStore(s.db)
+	mealHandler := meal.NewHandler(mealStore, profileStore, userStore)
+
+	// Define the post-meal glucose response handler
+	mealResponseHandler := mealresponse.NewHandler(mealStore, glucoseStore, profileStore, userStore)
+
+	// Define the food store and handler
+	foodStore := food.NewStore(s.db)
+	foodHandler := food.NewHandler(foodStore, userStore)
+
+	// Define the glycemic index store and handler
+	glycemicHandler := glycemic.NewHandler(glycemic.NewStore(s.db), userStore)
+
+	// Define the medication store and handler
+	medicationStore := medication.NewStore(s.db)
+	medicationHandler := medication.NewHandler(medicationStore, userStore)
+
+	// Define the activity store and handler
+	activityStore := activity.NewStore(s.db)
+	activityHandler := activity.NewHandler(activityStore, glucoseStore, profileStore, userStore)
+
+	// Define the lab result store and handler
+	labStore := lab.NewStore(s.db)
+	labHandler := lab.NewHandler(labStore, profileStore, userStore)
+
+	// Define the timeline handler. Every store with per-user events registers as a source.
+	timelineHandler := timeline.NewHandler(userStore)
+	timelineHandler.Register(
+		userStore,
+		goalStore,
+		healthConditionStore,
+		dietaryRestrictionStore,
+		glucoseStore,
+		mealStore,
+		medicationStore,
+		activityStore,
+		labStore,
+		alertStore,
+		profileStore,
+	)
 
 	// Register the routes in v1 group
 	userHandler.RegisterRoutes(apiV1)
+	mfaHandler.RegisterRoutes(apiV1)
+	glucoseHandler.RegisterRoutes(apiV1)
+	goalHandler.RegisterRoutes(apiV1)
+	healthConditionHandler.RegisterRoutes(apiV1)
+	dietaryRestrictionHandler.RegisterRoutes(apiV1)
+	profileHandler.RegisterRoutes(apiV1)
+	statsHandler.RegisterRoutes(apiV1)
+	importHandler.RegisterRoutes(apiV1)
+	mealHandler.RegisterRoutes(apiV1)
+	mealResponseHandler.RegisterRoutes(apiV1)
+	foodHandler.RegisterRoutes(apiV1)
+	glycemicHandler.RegisterRoutes(apiV1)
+	medicationHandler.RegisterRoutes(apiV1)
+	activityHandler.RegisterRoutes(apiV1)
+	labHandler.RegisterRoutes(apiV1)
+	alertHandler.RegisterRoutes(apiV1)
+	shareHandler.RegisterRoutes(apiV1)
+	timelineHandler.RegisterRoutes(apiV1)
+
+	// Publish the token verification keys
+	app.Get("/.well-known/jwks.json", auth.HandleJWKS)
 
 	app.Use("/health", func(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "ok"})
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/config"
+	"github.com/jayden1905/abundance/db"
+	"github.com/jayden1905/abundance/service/food"
+)
+
+// Loads the food catalogue into the database. Without -file the USDA subset
+// bundled with the repository is loaded. Running it again updates existing foods.
+func main() {
+	file := flag.String("file", "", "catalogue CSV to load instead of the bundled one")
+	flag.Parse()
+
+	var r io.Reader = strings.NewReader(food.BundledCatalogue)
+	if *file != "" {
+		f, err := os.Open(*file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	foods, err := food.ParseCatalogue(r)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	conn, err := db.NewMySQLStorage(mysql.Config{
+		User:              config.Envs.DBUser,
+		Passwd:            config.Envs.DBPasswd,
+		Addr:              config.Envs.DBAddr,
+		DBName:            config.Envs.DBName,
+		Net:               "tcp",
+		AllowOldPasswords: true,
+		ParseTime:         true,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	created, updated, err := food.NewStore(database.New(conn)).SaveCatalogueFoods(context.Background(), foods)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("Loaded %d foods: %d created, %d updated", len(foods), created, updated)
+}
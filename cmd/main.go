@@ -3,6 +3,7 @@ package main
 import (
 	"database/sql"
 	"log"
+	_ "time/tzdata" // Embed the time zone database for per-user time zones
 
 	"github.com/go-sql-driver/mysql"
 
@@ -5,11 +5,686 @@
 package database
 
 import (
+	"database/sql"
 	"database/sql/driver"
 	"fmt"
 	"time"
 )
 
+type ActivitiesActivityType string
+
+const (
+	ActivitiesActivityTypeWalking  ActivitiesActivityType = "walking"
+	ActivitiesActivityTypeRunning  ActivitiesActivityType = "running"
+	ActivitiesActivityTypeCycling  ActivitiesActivityType = "cycling"
+	ActivitiesActivityTypeSwimming ActivitiesActivityType = "swimming"
+	ActivitiesActivityTypeStrength ActivitiesActivityType = "strength"
+	ActivitiesActivityTypeYoga     ActivitiesActivityType = "yoga"
+	ActivitiesActivityTypeHiit     ActivitiesActivityType = "hiit"
+	ActivitiesActivityTypeSports   ActivitiesActivityType = "sports"
+	ActivitiesActivityTypeOther    ActivitiesActivityType = "other"
+)
+
+func (e *ActivitiesActivityType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ActivitiesActivityType(s)
+	case string:
+		*e = ActivitiesActivityType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ActivitiesActivityType: %T", src)
+	}
+	return nil
+}
+
+type NullActivitiesActivityType struct {
+	ActivitiesActivityType ActivitiesActivityType
+	Valid                  bool // Valid is true if ActivitiesActivityType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullActivitiesActivityType) Scan(value interface{}) error {
+	if value == nil {
+		ns.ActivitiesActivityType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ActivitiesActivityType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullActivitiesActivityType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ActivitiesActivityType), nil
+}
+
+type ActivitiesIntensity string
+
+const (
+	ActivitiesIntensityLight    ActivitiesIntensity = "light"
+	ActivitiesIntensityModerate ActivitiesIntensity = "moderate"
+	ActivitiesIntensityVigorous ActivitiesIntensity = "vigorous"
+)
+
+func (e *ActivitiesIntensity) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ActivitiesIntensity(s)
+	case string:
+		*e = ActivitiesIntensity(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ActivitiesIntensity: %T", src)
+	}
+	return nil
+}
+
+type NullActivitiesIntensity struct {
+	ActivitiesIntensity ActivitiesIntensity
+	Valid               bool // Valid is true if ActivitiesIntensity is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullActivitiesIntensity) Scan(value interface{}) error {
+	if value == nil {
+		ns.ActivitiesIntensity, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ActivitiesIntensity.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullActivitiesIntensity) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ActivitiesIntensity), nil
+}
+
+type AlertRulesRuleType string
+
+const (
+	AlertRulesRuleTypeLow         AlertRulesRuleType = "low"
+	AlertRulesRuleTypeUrgentLow   AlertRulesRuleType = "urgent_low"
+	AlertRulesRuleTypeHigh        AlertRulesRuleType = "high"
+	AlertRulesRuleTypeRapidFall   AlertRulesRuleType = "rapid_fall"
+	AlertRulesRuleTypeRapidRise   AlertRulesRuleType = "rapid_rise"
+	AlertRulesRuleTypeMissingData AlertRulesRuleType = "missing_data"
+)
+
+func (e *AlertRulesRuleType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AlertRulesRuleType(s)
+	case string:
+		*e = AlertRulesRuleType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AlertRulesRuleType: %T", src)
+	}
+	return nil
+}
+
+type NullAlertRulesRuleType struct {
+	AlertRulesRuleType AlertRulesRuleType
+	Valid              bool // Valid is true if AlertRulesRuleType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAlertRulesRuleType) Scan(value interface{}) error {
+	if value == nil {
+		ns.AlertRulesRuleType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AlertRulesRuleType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAlertRulesRuleType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AlertRulesRuleType), nil
+}
+
+type AlertsRuleType string
+
+const (
+	AlertsRuleTypeLow         AlertsRuleType = "low"
+	AlertsRuleTypeUrgentLow   AlertsRuleType = "urgent_low"
+	AlertsRuleTypeHigh        AlertsRuleType = "high"
+	AlertsRuleTypeRapidFall   AlertsRuleType = "rapid_fall"
+	AlertsRuleTypeRapidRise   AlertsRuleType = "rapid_rise"
+	AlertsRuleTypeMissingData AlertsRuleType = "missing_data"
+)
+
+func (e *AlertsRuleType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AlertsRuleType(s)
+	case string:
+		*e = AlertsRuleType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AlertsRuleType: %T", src)
+	}
+	return nil
+}
+
+type NullAlertsRuleType struct {
+	AlertsRuleType AlertsRuleType
+	Valid          bool // Valid is true if AlertsRuleType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAlertsRuleType) Scan(value interface{}) error {
+	if value == nil {
+		ns.AlertsRuleType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AlertsRuleType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAlertsRuleType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AlertsRuleType), nil
+}
+
+type DataSharesAccess string
+
+const (
+	DataSharesAccessRead   DataSharesAccess = "read"
+	DataSharesAccessAlerts DataSharesAccess = "alerts"
+)
+
+func (e *DataSharesAccess) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DataSharesAccess(s)
+	case string:
+		*e = DataSharesAccess(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DataSharesAccess: %T", src)
+	}
+	return nil
+}
+
+type NullDataSharesAccess struct {
+	DataSharesAccess DataSharesAccess
+	Valid            bool // Valid is true if DataSharesAccess is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDataSharesAccess) Scan(value interface{}) error {
+	if value == nil {
+		ns.DataSharesAccess, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DataSharesAccess.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDataSharesAccess) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DataSharesAccess), nil
+}
+
+type DataSharesStatus string
+
+const (
+	DataSharesStatusPending  DataSharesStatus = "pending"
+	DataSharesStatusAccepted DataSharesStatus = "accepted"
+	DataSharesStatusRevoked  DataSharesStatus = "revoked"
+)
+
+func (e *DataSharesStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DataSharesStatus(s)
+	case string:
+		*e = DataSharesStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DataSharesStatus: %T", src)
+	}
+	return nil
+}
+
+type NullDataSharesStatus struct {
+	DataSharesStatus DataSharesStatus
+	Valid            bool // Valid is true if DataSharesStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDataSharesStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.DataSharesStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DataSharesStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDataSharesStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DataSharesStatus), nil
+}
+
+type FoodsSource string
+
+const (
+	FoodsSourceUsda   FoodsSource = "usda"
+	FoodsSourceCustom FoodsSource = "custom"
+)
+
+func (e *FoodsSource) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = FoodsSource(s)
+	case string:
+		*e = FoodsSource(s)
+	default:
+		return fmt.Errorf("unsupported scan type for FoodsSource: %T", src)
+	}
+	return nil
+}
+
+type NullFoodsSource struct {
+	FoodsSource FoodsSource
+	Valid       bool // Valid is true if FoodsSource is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullFoodsSource) Scan(value interface{}) error {
+	if value == nil {
+		ns.FoodsSource, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.FoodsSource.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullFoodsSource) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.FoodsSource), nil
+}
+
+type GlucoseReadingsMeasurementContext string
+
+const (
+	GlucoseReadingsMeasurementContextFasting  GlucoseReadingsMeasurementContext = "fasting"
+	GlucoseReadingsMeasurementContextPreMeal  GlucoseReadingsMeasurementContext = "pre_meal"
+	GlucoseReadingsMeasurementContextPostMeal GlucoseReadingsMeasurementContext = "post_meal"
+	GlucoseReadingsMeasurementContextBedtime  GlucoseReadingsMeasurementContext = "bedtime"
+	GlucoseReadingsMeasurementContextOther    GlucoseReadingsMeasurementContext = "other"
+)
+
+func (e *GlucoseReadingsMeasurementContext) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = GlucoseReadingsMeasurementContext(s)
+	case string:
+		*e = GlucoseReadingsMeasurementContext(s)
+	default:
+		return fmt.Errorf("unsupported scan type for GlucoseReadingsMeasurementContext: %T", src)
+	}
+	return nil
+}
+
+type NullGlucoseReadingsMeasurementContext struct {
+	GlucoseReadingsMeasurementContext GlucoseReadingsMeasurementContext
+	Valid                             bool // Valid is true if GlucoseReadingsMeasurementContext is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullGlucoseReadingsMeasurementContext) Scan(value interface{}) error {
+	if value == nil {
+		ns.GlucoseReadingsMeasurementContext, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.GlucoseReadingsMeasurementContext.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullGlucoseReadingsMeasurementContext) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.GlucoseReadingsMeasurementContext), nil
+}
+
+type GlucoseReadingsSource string
+
+const (
+	GlucoseReadingsSourceManual GlucoseReadingsSource = "manual"
+	GlucoseReadingsSourceMeter  GlucoseReadingsSource = "meter"
+	GlucoseReadingsSourceCgm    GlucoseReadingsSource = "cgm"
+	GlucoseReadingsSourceImport GlucoseReadingsSource = "import"
+)
+
+func (e *GlucoseReadingsSource) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = GlucoseReadingsSource(s)
+	case string:
+		*e = GlucoseReadingsSource(s)
+	default:
+		return fmt.Errorf("unsupported scan type for GlucoseReadingsSource: %T", src)
+	}
+	return nil
+}
+
+type NullGlucoseReadingsSource struct {
+	GlucoseReadingsSource GlucoseReadingsSource
+	Valid                 bool // Valid is true if GlucoseReadingsSource is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullGlucoseReadingsSource) Scan(value interface{}) error {
+	if value == nil {
+		ns.GlucoseReadingsSource, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.GlucoseReadingsSource.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullGlucoseReadingsSource) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.GlucoseReadingsSource), nil
+}
+
+type GlucoseReadingsUnit string
+
+const (
+	GlucoseReadingsUnitMgDL  GlucoseReadingsUnit = "mg/dL"
+	GlucoseReadingsUnitMmolL GlucoseReadingsUnit = "mmol/L"
+)
+
+func (e *GlucoseReadingsUnit) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = GlucoseReadingsUnit(s)
+	case string:
+		*e = GlucoseReadingsUnit(s)
+	default:
+		return fmt.Errorf("unsupported scan type for GlucoseReadingsUnit: %T", src)
+	}
+	return nil
+}
+
+type NullGlucoseReadingsUnit struct {
+	GlucoseReadingsUnit GlucoseReadingsUnit
+	Valid               bool // Valid is true if GlucoseReadingsUnit is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullGlucoseReadingsUnit) Scan(value interface{}) error {
+	if value == nil {
+		ns.GlucoseReadingsUnit, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.GlucoseReadingsUnit.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullGlucoseReadingsUnit) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.GlucoseReadingsUnit), nil
+}
+
+type MealItemsPortionUnit string
+
+const (
+	MealItemsPortionUnitG       MealItemsPortionUnit = "g"
+	MealItemsPortionUnitMl      MealItemsPortionUnit = "ml"
+	MealItemsPortionUnitServing MealItemsPortionUnit = "serving"
+	MealItemsPortionUnitPiece   MealItemsPortionUnit = "piece"
+	MealItemsPortionUnitCup     MealItemsPortionUnit = "cup"
+	MealItemsPortionUnitTbsp    MealItemsPortionUnit = "tbsp"
+	MealItemsPortionUnitTsp     MealItemsPortionUnit = "tsp"
+	MealItemsPortionUnitOz      MealItemsPortionUnit = "oz"
+)
+
+func (e *MealItemsPortionUnit) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = MealItemsPortionUnit(s)
+	case string:
+		*e = MealItemsPortionUnit(s)
+	default:
+		return fmt.Errorf("unsupported scan type for MealItemsPortionUnit: %T", src)
+	}
+	return nil
+}
+
+type NullMealItemsPortionUnit struct {
+	MealItemsPortionUnit MealItemsPortionUnit
+	Valid                bool // Valid is true if MealItemsPortionUnit is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullMealItemsPortionUnit) Scan(value interface{}) error {
+	if value == nil {
+		ns.MealItemsPortionUnit, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.MealItemsPortionUnit.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullMealItemsPortionUnit) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.MealItemsPortionUnit), nil
+}
+
+type MealsMealType string
+
+const (
+	MealsMealTypeBreakfast MealsMealType = "breakfast"
+	MealsMealTypeLunch     MealsMealType = "lunch"
+	MealsMealTypeDinner    MealsMealType = "dinner"
+	MealsMealTypeSnack     MealsMealType = "snack"
+	MealsMealTypeOther     MealsMealType = "other"
+)
+
+func (e *MealsMealType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = MealsMealType(s)
+	case string:
+		*e = MealsMealType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for MealsMealType: %T", src)
+	}
+	return nil
+}
+
+type NullMealsMealType struct {
+	MealsMealType MealsMealType
+	Valid         bool // Valid is true if MealsMealType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullMealsMealType) Scan(value interface{}) error {
+	if value == nil {
+		ns.MealsMealType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.MealsMealType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullMealsMealType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.MealsMealType), nil
+}
+
+type MedicationsClass string
+
+const (
+	MedicationsClassRapidActingInsulin        MedicationsClass = "rapid_acting_insulin"
+	MedicationsClassShortActingInsulin        MedicationsClass = "short_acting_insulin"
+	MedicationsClassIntermediateActingInsulin MedicationsClass = "intermediate_acting_insulin"
+	MedicationsClassLongActingInsulin         MedicationsClass = "long_acting_insulin"
+	MedicationsClassPremixedInsulin           MedicationsClass = "premixed_insulin"
+	MedicationsClassBiguanide                 MedicationsClass = "biguanide"
+	MedicationsClassSulfonylurea              MedicationsClass = "sulfonylurea"
+	MedicationsClassDpp4Inhibitor             MedicationsClass = "dpp4_inhibitor"
+	MedicationsClassSglt2Inhibitor            MedicationsClass = "sglt2_inhibitor"
+	MedicationsClassGlp1Agonist               MedicationsClass = "glp1_agonist"
+	MedicationsClassThiazolidinedione         MedicationsClass = "thiazolidinedione"
+	MedicationsClassOther                     MedicationsClass = "other"
+)
+
+func (e *MedicationsClass) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = MedicationsClass(s)
+	case string:
+		*e = MedicationsClass(s)
+	default:
+		return fmt.Errorf("unsupported scan type for MedicationsClass: %T", src)
+	}
+	return nil
+}
+
+type NullMedicationsClass struct {
+	MedicationsClass MedicationsClass
+	Valid            bool // Valid is true if MedicationsClass is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullMedicationsClass) Scan(value interface{}) error {
+	if value == nil {
+		ns.MedicationsClass, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.MedicationsClass.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullMedicationsClass) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.MedicationsClass), nil
+}
+
+type MedicationsDoseUnit string
+
+const (
+	MedicationsDoseUnitUnits MedicationsDoseUnit = "units"
+	MedicationsDoseUnitMg    MedicationsDoseUnit = "mg"
+	MedicationsDoseUnitMcg   MedicationsDoseUnit = "mcg"
+	MedicationsDoseUnitMl    MedicationsDoseUnit = "ml"
+)
+
+func (e *MedicationsDoseUnit) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = MedicationsDoseUnit(s)
+	case string:
+		*e = MedicationsDoseUnit(s)
+	default:
+		return fmt.Errorf("unsupported scan type for MedicationsDoseUnit: %T", src)
+	}
+	return nil
+}
+
+type NullMedicationsDoseUnit struct {
+	MedicationsDoseUnit MedicationsDoseUnit
+	Valid               bool // Valid is true if MedicationsDoseUnit is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullMedicationsDoseUnit) Scan(value interface{}) error {
+	if value == nil {
+		ns.MedicationsDoseUnit, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.MedicationsDoseUnit.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullMedicationsDoseUnit) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.MedicationsDoseUnit), nil
+}
+
+type MedicationsRoute string
+
+const (
+	MedicationsRouteInjection MedicationsRoute = "injection"
+	MedicationsRouteOral      MedicationsRoute = "oral"
+	MedicationsRouteInhaled   MedicationsRoute = "inhaled"
+)
+
+func (e *MedicationsRoute) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = MedicationsRoute(s)
+	case string:
+		*e = MedicationsRoute(s)
+	default:
+		return fmt.Errorf("unsupported scan type for MedicationsRoute: %T", src)
+	}
+	return nil
+}
+
+type NullMedicationsRoute struct {
+	MedicationsRoute MedicationsRoute
+	Valid            bool // Valid is true if MedicationsRoute is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullMedicationsRoute) Scan(value interface{}) error {
+	if value == nil {
+		ns.MedicationsRoute, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.MedicationsRoute.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullMedicationsRoute) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.MedicationsRoute), nil
+}
+
 type RolesName string
 
 const (
@@ -98,6 +773,416 @@ func (ns NullSubscriptionsSubscriptionType) Value() (driver.Value, error) {
 	return string(ns.SubscriptionsSubscriptionType), nil
 }
 
+type UserProfilesDiabetesType string
+
+const (
+	UserProfilesDiabetesTypeType1       UserProfilesDiabetesType = "type_1"
+	UserProfilesDiabetesTypeType2       UserProfilesDiabetesType = "type_2"
+	UserProfilesDiabetesTypeGestational UserProfilesDiabetesType = "gestational"
+	UserProfilesDiabetesTypePrediabetes UserProfilesDiabetesType = "prediabetes"
+	UserProfilesDiabetesTypeLada        UserProfilesDiabetesType = "lada"
+	UserProfilesDiabetesTypeMody        UserProfilesDiabetesType = "mody"
+	UserProfilesDiabetesTypeOther       UserProfilesDiabetesType = "other"
+	UserProfilesDiabetesTypeNone        UserProfilesDiabetesType = "none"
+)
+
+func (e *UserProfilesDiabetesType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = UserProfilesDiabetesType(s)
+	case string:
+		*e = UserProfilesDiabetesType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for UserProfilesDiabetesType: %T", src)
+	}
+	return nil
+}
+
+type NullUserProfilesDiabetesType struct {
+	UserProfilesDiabetesType UserProfilesDiabetesType
+	Valid                    bool // Valid is true if UserProfilesDiabetesType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullUserProfilesDiabetesType) Scan(value interface{}) error {
+	if value == nil {
+		ns.UserProfilesDiabetesType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.UserProfilesDiabetesType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullUserProfilesDiabetesType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.UserProfilesDiabetesType), nil
+}
+
+type UserProfilesGlucoseUnit string
+
+const (
+	UserProfilesGlucoseUnitMgDL  UserProfilesGlucoseUnit = "mg/dL"
+	UserProfilesGlucoseUnitMmolL UserProfilesGlucoseUnit = "mmol/L"
+)
+
+func (e *UserProfilesGlucoseUnit) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = UserProfilesGlucoseUnit(s)
+	case string:
+		*e = UserProfilesGlucoseUnit(s)
+	default:
+		return fmt.Errorf("unsupported scan type for UserProfilesGlucoseUnit: %T", src)
+	}
+	return nil
+}
+
+type NullUserProfilesGlucoseUnit struct {
+	UserProfilesGlucoseUnit UserProfilesGlucoseUnit
+	Valid                   bool // Valid is true if UserProfilesGlucoseUnit is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullUserProfilesGlucoseUnit) Scan(value interface{}) error {
+	if value == nil {
+		ns.UserProfilesGlucoseUnit, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.UserProfilesGlucoseUnit.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullUserProfilesGlucoseUnit) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.UserProfilesGlucoseUnit), nil
+}
+
+type UserProfilesSex string
+
+const (
+	UserProfilesSexFemale         UserProfilesSex = "female"
+	UserProfilesSexMale           UserProfilesSex = "male"
+	UserProfilesSexOther          UserProfilesSex = "other"
+	UserProfilesSexPreferNotToSay UserProfilesSex = "prefer_not_to_say"
+)
+
+func (e *UserProfilesSex) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = UserProfilesSex(s)
+	case string:
+		*e = UserProfilesSex(s)
+	default:
+		return fmt.Errorf("unsupported scan type for UserProfilesSex: %T", src)
+	}
+	return nil
+}
+
+type NullUserProfilesSex struct {
+	UserProfilesSex UserProfilesSex
+	Valid           bool // Valid is true if UserProfilesSex is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullUserProfilesSex) Scan(value interface{}) error {
+	if value == nil {
+		ns.UserProfilesSex, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.UserProfilesSex.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullUserProfilesSex) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.UserProfilesSex), nil
+}
+
+type WebauthnChallengesCeremony string
+
+const (
+	WebauthnChallengesCeremonyRegistration WebauthnChallengesCeremony = "registration"
+	WebauthnChallengesCeremonyLogin        WebauthnChallengesCeremony = "login"
+)
+
+func (e *WebauthnChallengesCeremony) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WebauthnChallengesCeremony(s)
+	case string:
+		*e = WebauthnChallengesCeremony(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WebauthnChallengesCeremony: %T", src)
+	}
+	return nil
+}
+
+type NullWebauthnChallengesCeremony struct {
+	WebauthnChallengesCeremony WebauthnChallengesCeremony
+	Valid                      bool // Valid is true if WebauthnChallengesCeremony is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWebauthnChallengesCeremony) Scan(value interface{}) error {
+	if value == nil {
+		ns.WebauthnChallengesCeremony, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WebauthnChallengesCeremony.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWebauthnChallengesCeremony) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WebauthnChallengesCeremony), nil
+}
+
+type Activity struct {
+	ActivityID      int32
+	UserID          int32
+	ActivityType    ActivitiesActivityType
+	Intensity       ActivitiesIntensity
+	StartedAt       time.Time
+	DurationMinutes int32
+	Steps           sql.NullInt32
+	Calories        sql.NullFloat64
+	Notes           string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type ActivityTarget struct {
+	UserID              int32
+	WeeklyActiveMinutes int32
+	DailySteps          int32
+	UpdatedAt           time.Time
+}
+
+type Alert struct {
+	AlertID           int32
+	UserID            int32
+	RuleType          AlertsRuleType
+	GlucoseReadingID  sql.NullInt32
+	ValueMgdl         sql.NullFloat64
+	RateMgdlPerMinute sql.NullFloat64
+	Message           string
+	TriggeredAt       time.Time
+	DeliveredChannels string
+	DeliveryError     string
+	AcknowledgedAt    sql.NullTime
+}
+
+type AlertRule struct {
+	AlertRuleID     int32
+	UserID          int32
+	RuleType        AlertRulesRuleType
+	IsEnabled       bool
+	Threshold       sql.NullFloat64
+	CooldownMinutes int32
+	Channels        string
+	SnoozedUntil    sql.NullTime
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+type DataShare struct {
+	ShareID      int32
+	OwnerID      int32
+	CaregiverID  sql.NullInt32
+	InviteeEmail string
+	Access       DataSharesAccess
+	Scopes       string
+	Status       DataSharesStatus
+	TokenHash    string
+	ExpiresAt    time.Time
+	AcceptedAt   sql.NullTime
+	RevokedAt    sql.NullTime
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type DietaryRestriction struct {
+	DietaryRestrictionID   int32
+	UserID                 int32
+	DietaryRestrictionName string
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+}
+
+type Food struct {
+	FoodID     int32
+	Name       string
+	Category   string
+	Source     FoodsSource
+	UserID     sql.NullInt32
+	EnergyKcal float64
+	CarbsG     float64
+	ProteinG   float64
+	FatG       float64
+	FibreG     float64
+	SugarG     float64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type FoodServing struct {
+	FoodServingID int32
+	FoodID        int32
+	Description   string
+	Grams         float64
+}
+
+type GlucoseReading struct {
+	ReadingID          int32
+	UserID             int32
+	Value              float64
+	Unit               GlucoseReadingsUnit
+	Source             GlucoseReadingsSource
+	MeasurementContext GlucoseReadingsMeasurementContext
+	MeasuredAt         time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+type GlycemicIndexFood struct {
+	GiFoodID        int32
+	Name            string
+	GlycemicIndex   int32
+	ServingSizeG    float64
+	AvailableCarbsG float64
+	CreatedAt       time.Time
+}
+
+type Goal struct {
+	GoalID    int32
+	UserID    int32
+	GoalName  string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type HealthCondition struct {
+	HealthConditionID   int32
+	UserID              int32
+	HealthConditionName string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+type LabResult struct {
+	LabResultID   int32
+	UserID        int32
+	TestCode      string
+	Value         float64
+	Unit          string
+	ReferenceLow  sql.NullFloat64
+	ReferenceHigh sql.NullFloat64
+	CollectedAt   time.Time
+	LabName       string
+	Notes         string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type Meal struct {
+	MealID    int32
+	UserID    int32
+	MealType  MealsMealType
+	EatenAt   time.Time
+	Notes     sql.NullString
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type MealItem struct {
+	MealItemID  int32
+	MealID      int32
+	Name        string
+	Portion     float64
+	PortionUnit MealItemsPortionUnit
+	CarbsG      float64
+	ProteinG    float64
+	FatG        float64
+	FibreG      float64
+	Calories    float64
+}
+
+type Medication struct {
+	MedicationID int32
+	Name         string
+	BrandNames   string
+	Class        MedicationsClass
+	DoseUnit     MedicationsDoseUnit
+	Route        MedicationsRoute
+}
+
+type MedicationDose struct {
+	DoseID       int32
+	UserID       int32
+	MedicationID int32
+	Amount       float64
+	TakenAt      time.Time
+	Notes        string
+	CreatedAt    time.Time
+}
+
+type MfaRecoveryCode struct {
+	RecoveryCodeID int32
+	UserID         int32
+	CodeHash       string
+	UsedAt         sql.NullTime
+	CreatedAt      time.Time
+}
+
+type OidcLoginState struct {
+	LoginStateID int32
+	StateHash    string
+	Provider     string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+}
+
+type PasswordResetToken struct {
+	PasswordResetTokenID int32
+	UserID               int32
+	TokenHash            string
+	ExpiresAt            time.Time
+	UsedAt               sql.NullTime
+	CreatedAt            time.Time
+}
+
+type RefreshToken struct {
+	RefreshTokenID int32
+	UserID         int32
+	FamilyID       string
+	TokenHash      string
+	ExpiresAt      time.Time
+	UsedAt         sql.NullTime
+	RevokedAt      sql.NullTime
+	CreatedAt      time.Time
+}
+
+type RevokedToken struct {
+	Jti       string
+	UserID    int32
+	ExpiresAt time.Time
+	RevokedAt time.Time
+}
+
 type Role struct {
 	RoleID int8
 	Name   RolesName
@@ -121,3 +1206,91 @@ type User struct {
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 }
+
+type UserIdentity struct {
+	IdentityID  int32
+	UserID      int32
+	Provider    string
+	Subject     string
+	Email       string
+	LastLoginAt sql.NullTime
+	CreatedAt   time.Time
+}
+
+type UserMedication struct {
+	UserMedicationID int32
+	UserID           int32
+	MedicationID     int32
+	Dose             sql.NullFloat64
+	Schedule         string
+	StartedOn        sql.NullTime
+	IsActive         bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type UserMfa struct {
+	UserID         int32
+	TotpSecret     string
+	IsEnabled      bool
+	LastUsedStep   int64
+	FailedAttempts int32
+	LockedUntil    sql.NullTime
+	EnabledAt      sql.NullTime
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type UserProfile struct {
+	UserID            int32
+	DateOfBirth       sql.NullTime
+	Sex               NullUserProfilesSex
+	HeightCm          sql.NullFloat64
+	DiabetesType      NullUserProfilesDiabetesType
+	DiagnosisDate     sql.NullTime
+	GlucoseUnit       UserProfilesGlucoseUnit
+	TargetLow         float64
+	TargetHigh        float64
+	VeryLowThreshold  float64
+	VeryHighThreshold float64
+	Timezone          string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+type UserSessionRevocation struct {
+	UserID        int32
+	RevokedBefore time.Time
+}
+
+type WebauthnChallenge struct {
+	ChallengeID int32
+	Challenge   string
+	Ceremony    WebauthnChallengesCeremony
+	UserID      sql.NullInt32
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+}
+
+type WebauthnCredential struct {
+	CredentialID   int32
+	UserID         int32
+	ExternalID     []byte
+	PublicKey      []byte
+	Algorithm      int32
+	SignCount      uint32
+	Transports     string
+	Aaguid         []byte
+	Name           string
+	BackupEligible bool
+	LastUsedAt     sql.NullTime
+	CreatedAt      time.Time
+}
+
+type WeightEntry struct {
+	WeightEntryID int32
+	UserID        int32
+	WeightKg      float64
+	RecordedAt    time.Time
+	CreatedAt     time.Time
+}
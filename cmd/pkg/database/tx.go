@@ -0,0 +1,29 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// RunInTx runs fn with queries bound to a new transaction. The transaction is committed
+// if fn succeeds and rolled back otherwise. The queries must have been created from a
+// *sql.DB, as a transaction cannot be started from another transaction.
+func (q *Queries) RunInTx(ctx context.Context, fn func(q *Queries) error) error {
+	conn, ok := q.db.(*sql.DB)
+	if !ok {
+		return fmt.Errorf("cannot start a transaction on %T", q.db)
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(q.WithTx(tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -240,6 +240,24 @@ func (q *Queries) GetUserRoleByUserID(ctx context.Context, userID int32) (RolesN
 	return name, err
 }
 
+const updateUserEmail = `-- name: UpdateUserEmail :exec
+UPDATE users
+SET email = ?,
+    is_verified = ?
+WHERE user_id = ?
+`
+
+type UpdateUserEmailParams struct {
+	Email      string
+	IsVerified bool
+	UserID     int32
+}
+
+func (q *Queries) UpdateUserEmail(ctx context.Context, arg UpdateUserEmailParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserEmail, arg.Email, arg.IsVerified, arg.UserID)
+	return err
+}
+
 const updateUserPassword = `-- name: UpdateUserPassword :exec
 UPDATE users
 SET password_hash = ?
@@ -294,6 +312,22 @@ func (q *Queries) UpdateUserToFreeUser(ctx context.Context, userID int32) error
 	return err
 }
 
+const updateUserUsername = `-- name: UpdateUserUsername :exec
+UPDATE users
+SET username = ?
+WHERE user_id = ?
+`
+
+type UpdateUserUsernameParams struct {
+	Username string
+	UserID   int32
+}
+
+func (q *Queries) UpdateUserUsername(ctx context.Context, arg UpdateUserUsernameParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserUsername, arg.Username, arg.UserID)
+	return err
+}
+
 const updateUserVerificationStatus = `-- name: UpdateUserVerificationStatus :exec
 UPDATE users
 SET is_verified = ?
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `glucose_readings` (
+  `reading_id` int NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `value` double NOT NULL,
+  `unit` enum('mg/dL', 'mmol/L') NOT NULL DEFAULT 'mg/dL',
+  `source` enum('manual', 'meter', 'cgm', 'import') NOT NULL DEFAULT 'manual',
+  `measurement_context` enum(
+    'fasting',
+    'pre_meal',
+    'post_meal',
+    'bedtime',
+    'other'
+  ) NOT NULL DEFAULT 'other',
+  `measured_at` datetime NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`reading_id`),
+  KEY `idx_glucose_readings_user_measured_at` (`user_id`, `measured_at`),
+  CONSTRAINT `fk_user_glucose_reading` FOREIGN KEY (`user_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `glucose_readings`;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `refresh_tokens` (
+  `refresh_token_id` int NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `family_id` char(32) NOT NULL,
+  `token_hash` char(64) NOT NULL,
+  `expires_at` datetime NOT NULL,
+  `used_at` datetime DEFAULT NULL,
+  `revoked_at` datetime DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`refresh_token_id`),
+  UNIQUE KEY `token_hash_UNIQUE` (`token_hash`),
+  KEY `idx_refresh_tokens_family_id` (`family_id`),
+  CONSTRAINT `fk_user_refresh_token` FOREIGN KEY (`user_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `refresh_tokens`;
+-- +goose StatementEnd
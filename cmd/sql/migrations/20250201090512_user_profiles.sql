@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `user_profiles` (
+  `user_id` int NOT NULL,
+  `date_of_birth` date DEFAULT NULL,
+  `sex` enum('female', 'male', 'other', 'prefer_not_to_say') DEFAULT NULL,
+  `height_cm` double DEFAULT NULL,
+  `diabetes_type` enum(
+    'type_1',
+    'type_2',
+    'gestational',
+    'prediabetes',
+    'lada',
+    'mody',
+    'other',
+    'none'
+  ) DEFAULT NULL,
+  `diagnosis_date` date DEFAULT NULL,
+  `glucose_unit` enum('mg/dL', 'mmol/L') NOT NULL DEFAULT 'mg/dL',
+  `target_low` double NOT NULL DEFAULT 70,
+  `target_high` double NOT NULL DEFAULT 180,
+  `timezone` varchar(64) NOT NULL DEFAULT 'UTC',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`user_id`),
+  CONSTRAINT `fk_user_profile` FOREIGN KEY (`user_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `weight_entries` (
+  `weight_entry_id` int NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `weight_kg` double NOT NULL,
+  `recorded_at` datetime NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`weight_entry_id`),
+  KEY `idx_weight_entries_user_recorded_at` (`user_id`, `recorded_at`),
+  CONSTRAINT `fk_user_weight_entry` FOREIGN KEY (`user_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `weight_entries`;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `user_profiles`;
+-- +goose StatementEnd
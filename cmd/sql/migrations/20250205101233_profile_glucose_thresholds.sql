@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `user_profiles`
+ADD COLUMN `very_low_threshold` double NOT NULL DEFAULT 54 AFTER `target_high`,
+ADD COLUMN `very_high_threshold` double NOT NULL DEFAULT 250 AFTER `very_low_threshold`;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `user_profiles`
+DROP COLUMN `very_high_threshold`,
+DROP COLUMN `very_low_threshold`;
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `meals` (
+  `meal_id` int NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `meal_type` enum('breakfast', 'lunch', 'dinner', 'snack', 'other') NOT NULL DEFAULT 'other',
+  `eaten_at` datetime NOT NULL,
+  `notes` varchar(1000) DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`meal_id`),
+  KEY `idx_meals_user_eaten_at` (`user_id`, `eaten_at`),
+  CONSTRAINT `fk_user_meal` FOREIGN KEY (`user_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `meal_items` (
+  `meal_item_id` int NOT NULL AUTO_INCREMENT,
+  `meal_id` int NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `portion` double NOT NULL,
+  `portion_unit` enum('g', 'ml', 'serving', 'piece', 'cup', 'tbsp', 'tsp', 'oz') NOT NULL DEFAULT 'g',
+  `carbs_g` double NOT NULL DEFAULT 0,
+  `protein_g` double NOT NULL DEFAULT 0,
+  `fat_g` double NOT NULL DEFAULT 0,
+  `fibre_g` double NOT NULL DEFAULT 0,
+  `calories` double NOT NULL DEFAULT 0,
+  PRIMARY KEY (`meal_item_id`),
+  KEY `idx_meal_items_meal_id` (`meal_id`),
+  CONSTRAINT `fk_meal_item_meal` FOREIGN KEY (`meal_id`) REFERENCES `meals`(`meal_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `meal_items`;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `meals`;
+-- +goose StatementEnd
@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `foods` (
+  `food_id` int NOT NULL AUTO_INCREMENT,
+  `name` varchar(255) NOT NULL,
+  `category` varchar(100) NOT NULL DEFAULT '',
+  `source` enum('usda', 'custom') NOT NULL,
+  `user_id` int DEFAULT NULL,
+  `energy_kcal` double NOT NULL DEFAULT 0,
+  `carbs_g` double NOT NULL DEFAULT 0,
+  `protein_g` double NOT NULL DEFAULT 0,
+  `fat_g` double NOT NULL DEFAULT 0,
+  `fibre_g` double NOT NULL DEFAULT 0,
+  `sugar_g` double NOT NULL DEFAULT 0,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`food_id`),
+  KEY `idx_foods_user_id` (`user_id`),
+  KEY `idx_foods_name` (`name`),
+  CONSTRAINT `fk_user_food` FOREIGN KEY (`user_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `food_servings` (
+  `food_serving_id` int NOT NULL AUTO_INCREMENT,
+  `food_id` int NOT NULL,
+  `description` varchar(100) NOT NULL,
+  `grams` double NOT NULL,
+  PRIMARY KEY (`food_serving_id`),
+  KEY `idx_food_servings_food_id` (`food_id`),
+  CONSTRAINT `fk_food_serving_food` FOREIGN KEY (`food_id`) REFERENCES `foods`(`food_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `food_servings`;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `foods`;
+-- +goose StatementEnd
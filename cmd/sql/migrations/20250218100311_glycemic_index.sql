@@ -0,0 +1,71 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `glycemic_index_foods` (
+  `gi_food_id` int NOT NULL AUTO_INCREMENT,
+  `name` varchar(255) NOT NULL,
+  `glycemic_index` int NOT NULL,
+  `serving_size_g` double NOT NULL,
+  `available_carbs_g` double NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`gi_food_id`),
+  UNIQUE KEY `uq_glycemic_index_foods_name` (`name`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+
+-- Reference values (glucose = 100) with a typical serving and its available
+-- carbohydrate, based on the International Tables of Glycemic Index and
+-- Glycemic Load Values (Atkinson, Foster-Powell and Brand-Miller, 2008)
+-- +goose StatementBegin
+INSERT INTO `glycemic_index_foods` (`name`, `glycemic_index`, `serving_size_g`, `available_carbs_g`)
+VALUES ('Glucose', 103, 10, 10),
+  ('Sucrose (table sugar)', 65, 10, 10),
+  ('Honey', 61, 25, 21),
+  ('White wheat bread', 75, 30, 14),
+  ('Whole wheat bread', 74, 30, 13),
+  ('Bagel, white', 69, 70, 35),
+  ('White rice, boiled', 73, 150, 36),
+  ('Brown rice, boiled', 68, 150, 33),
+  ('Basmati rice, boiled', 58, 150, 38),
+  ('Spaghetti, white, boiled', 49, 180, 48),
+  ('Rice noodles, boiled', 53, 180, 39),
+  ('Couscous', 65, 150, 35),
+  ('Quinoa, boiled', 53, 150, 25),
+  ('Pearl barley, boiled', 28, 150, 42),
+  ('Corn flakes', 81, 30, 26),
+  ('Porridge, rolled oats', 55, 250, 23),
+  ('Porridge, instant oats', 79, 250, 26),
+  ('Muesli', 57, 30, 20),
+  ('Potato, boiled', 78, 150, 26),
+  ('Potato, instant mashed', 87, 150, 20),
+  ('French fries', 63, 150, 29),
+  ('Sweet potato, boiled', 63, 150, 28),
+  ('Sweet corn, boiled', 52, 150, 32),
+  ('Carrots, boiled', 39, 80, 6),
+  ('Chickpeas, boiled', 28, 150, 30),
+  ('Kidney beans, boiled', 24, 150, 25),
+  ('Lentils, boiled', 32, 150, 18),
+  ('Soya beans, boiled', 16, 150, 6),
+  ('Apple, raw', 36, 120, 15),
+  ('Orange, raw', 43, 120, 11),
+  ('Banana, raw', 51, 120, 25),
+  ('Mango, raw', 51, 120, 15),
+  ('Pineapple, raw', 59, 120, 13),
+  ('Watermelon, raw', 76, 120, 6),
+  ('Grapes, raw', 59, 120, 18),
+  ('Dates, dried', 42, 60, 40),
+  ('Milk, full fat', 39, 250, 12),
+  ('Milk, skim', 37, 250, 13),
+  ('Yoghurt, plain', 41, 200, 9),
+  ('Soy milk', 34, 250, 17),
+  ('Apple juice, unsweetened', 41, 250, 29),
+  ('Orange juice, unsweetened', 50, 250, 26),
+  ('Cola, soft drink', 63, 250, 26),
+  ('Popcorn', 65, 20, 11),
+  ('Potato crisps', 56, 50, 25),
+  ('Dark chocolate', 40, 50, 28);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `glycemic_index_foods`;
+-- +goose StatementEnd
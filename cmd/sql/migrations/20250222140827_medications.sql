@@ -0,0 +1,103 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `medications` (
+  `medication_id` int NOT NULL AUTO_INCREMENT,
+  `name` varchar(100) NOT NULL,
+  `brand_names` varchar(255) NOT NULL DEFAULT '',
+  `class` enum(
+    'rapid_acting_insulin',
+    'short_acting_insulin',
+    'intermediate_acting_insulin',
+    'long_acting_insulin',
+    'premixed_insulin',
+    'biguanide',
+    'sulfonylurea',
+    'dpp4_inhibitor',
+    'sglt2_inhibitor',
+    'glp1_agonist',
+    'thiazolidinedione',
+    'other'
+  ) NOT NULL,
+  `dose_unit` enum('units', 'mg', 'mcg', 'ml') NOT NULL,
+  `route` enum('injection', 'oral', 'inhaled') NOT NULL,
+  PRIMARY KEY (`medication_id`),
+  UNIQUE KEY `uq_medications_name_route` (`name`, `route`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+INSERT INTO `medications` (`name`, `brand_names`, `class`, `dose_unit`, `route`)
+VALUES ('Insulin lispro', 'Humalog, Admelog, Lyumjev', 'rapid_acting_insulin', 'units', 'injection'),
+  ('Insulin aspart', 'NovoRapid, NovoLog, Fiasp', 'rapid_acting_insulin', 'units', 'injection'),
+  ('Insulin glulisine', 'Apidra', 'rapid_acting_insulin', 'units', 'injection'),
+  ('Inhaled insulin', 'Afrezza', 'rapid_acting_insulin', 'units', 'inhaled'),
+  ('Regular human insulin', 'Actrapid, Humulin R, Novolin R', 'short_acting_insulin', 'units', 'injection'),
+  ('NPH insulin', 'Insulatard, Humulin N, Novolin N', 'intermediate_acting_insulin', 'units', 'injection'),
+  ('Insulin glargine', 'Lantus, Basaglar, Toujeo', 'long_acting_insulin', 'units', 'injection'),
+  ('Insulin detemir', 'Levemir', 'long_acting_insulin', 'units', 'injection'),
+  ('Insulin degludec', 'Tresiba', 'long_acting_insulin', 'units', 'injection'),
+  ('Premixed insulin 70/30', 'Humulin 70/30, NovoMix 30, Mixtard 30', 'premixed_insulin', 'units', 'injection'),
+  ('Metformin', 'Glucophage, Glucophage XR', 'biguanide', 'mg', 'oral'),
+  ('Gliclazide', 'Diamicron', 'sulfonylurea', 'mg', 'oral'),
+  ('Glipizide', 'Glucotrol', 'sulfonylurea', 'mg', 'oral'),
+  ('Glimepiride', 'Amaryl', 'sulfonylurea', 'mg', 'oral'),
+  ('Sitagliptin', 'Januvia', 'dpp4_inhibitor', 'mg', 'oral'),
+  ('Linagliptin', 'Trajenta, Tradjenta', 'dpp4_inhibitor', 'mg', 'oral'),
+  ('Empagliflozin', 'Jardiance', 'sglt2_inhibitor', 'mg', 'oral'),
+  ('Dapagliflozin', 'Forxiga, Farxiga', 'sglt2_inhibitor', 'mg', 'oral'),
+  ('Canagliflozin', 'Invokana', 'sglt2_inhibitor', 'mg', 'oral'),
+  ('Semaglutide', 'Ozempic, Wegovy', 'glp1_agonist', 'mg', 'injection'),
+  ('Semaglutide', 'Rybelsus', 'glp1_agonist', 'mg', 'oral'),
+  ('Liraglutide', 'Victoza, Saxenda', 'glp1_agonist', 'mg', 'injection'),
+  ('Dulaglutide', 'Trulicity', 'glp1_agonist', 'mg', 'injection'),
+  ('Tirzepatide', 'Mounjaro, Zepbound', 'glp1_agonist', 'mg', 'injection'),
+  ('Pioglitazone', 'Actos', 'thiazolidinedione', 'mg', 'oral'),
+  ('Acarbose', 'Glucobay, Precose', 'other', 'mg', 'oral');
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `user_medications` (
+  `user_medication_id` int NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `medication_id` int NOT NULL,
+  `dose` double DEFAULT NULL,
+  `schedule` varchar(100) NOT NULL DEFAULT '',
+  `started_on` date DEFAULT NULL,
+  `is_active` tinyint(1) NOT NULL DEFAULT 1,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`user_medication_id`),
+  UNIQUE KEY `uq_user_medications_user_medication` (`user_id`, `medication_id`),
+  CONSTRAINT `fk_user_medication_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT `fk_user_medication_medication` FOREIGN KEY (`medication_id`) REFERENCES `medications`(`medication_id`) ON DELETE RESTRICT ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `medication_doses` (
+  `dose_id` int NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `medication_id` int NOT NULL,
+  `amount` double NOT NULL,
+  `taken_at` datetime NOT NULL,
+  `notes` varchar(500) NOT NULL DEFAULT '',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`dose_id`),
+  KEY `idx_medication_doses_user_taken_at` (`user_id`, `taken_at`),
+  CONSTRAINT `fk_medication_dose_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT `fk_medication_dose_medication` FOREIGN KEY (`medication_id`) REFERENCES `medications`(`medication_id`) ON DELETE RESTRICT ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `medication_doses`;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `user_medications`;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `medications`;
+-- +goose StatementEnd
@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `activities` (
+  `activity_id` int NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `activity_type` enum(
+    'walking',
+    'running',
+    'cycling',
+    'swimming',
+    'strength',
+    'yoga',
+    'hiit',
+    'sports',
+    'other'
+  ) NOT NULL DEFAULT 'other',
+  `intensity` enum('light', 'moderate', 'vigorous') NOT NULL DEFAULT 'moderate',
+  `started_at` datetime NOT NULL,
+  `duration_minutes` int NOT NULL,
+  `steps` int DEFAULT NULL,
+  `calories` double DEFAULT NULL,
+  `notes` varchar(500) NOT NULL DEFAULT '',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`activity_id`),
+  KEY `idx_activities_user_started_at` (`user_id`, `started_at`),
+  CONSTRAINT `fk_activity_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `activity_targets` (
+  `user_id` int NOT NULL,
+  `weekly_active_minutes` int NOT NULL DEFAULT 150,
+  `daily_steps` int NOT NULL DEFAULT 8000,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`user_id`),
+  CONSTRAINT `fk_activity_target_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `activity_targets`;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `activities`;
+-- +goose StatementEnd
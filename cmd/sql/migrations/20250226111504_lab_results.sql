@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `lab_results` (
+  `lab_result_id` int NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `test_code` varchar(50) NOT NULL,
+  `value` double NOT NULL,
+  `unit` varchar(20) NOT NULL,
+  `reference_low` double DEFAULT NULL,
+  `reference_high` double DEFAULT NULL,
+  `collected_at` datetime NOT NULL,
+  `lab_name` varchar(255) NOT NULL DEFAULT '',
+  `notes` varchar(500) NOT NULL DEFAULT '',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`lab_result_id`),
+  KEY `idx_lab_results_user_collected_at` (`user_id`, `collected_at`),
+  KEY `idx_lab_results_user_test_collected_at` (`user_id`, `test_code`, `collected_at`),
+  CONSTRAINT `fk_lab_result_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `lab_results`;
+-- +goose StatementEnd
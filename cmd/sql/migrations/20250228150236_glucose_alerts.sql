@@ -0,0 +1,63 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `alert_rules` (
+  `alert_rule_id` int NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `rule_type` enum(
+    'low',
+    'urgent_low',
+    'high',
+    'rapid_fall',
+    'rapid_rise',
+    'missing_data'
+  ) NOT NULL,
+  `is_enabled` tinyint(1) NOT NULL DEFAULT 1,
+  `threshold` double DEFAULT NULL,
+  `cooldown_minutes` int NOT NULL DEFAULT 30,
+  `channels` varchar(100) NOT NULL DEFAULT 'email',
+  `snoozed_until` datetime DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`alert_rule_id`),
+  UNIQUE KEY `uq_alert_rules_user_rule_type` (`user_id`, `rule_type`),
+  KEY `idx_alert_rules_rule_type_enabled` (`rule_type`, `is_enabled`),
+  CONSTRAINT `fk_alert_rule_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `alerts` (
+  `alert_id` int NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `rule_type` enum(
+    'low',
+    'urgent_low',
+    'high',
+    'rapid_fall',
+    'rapid_rise',
+    'missing_data'
+  ) NOT NULL,
+  `glucose_reading_id` int DEFAULT NULL,
+  `value_mgdl` double DEFAULT NULL,
+  `rate_mgdl_per_minute` double DEFAULT NULL,
+  `message` varchar(255) NOT NULL,
+  `triggered_at` datetime NOT NULL,
+  `delivered_channels` varchar(100) NOT NULL DEFAULT '',
+  `delivery_error` varchar(255) NOT NULL DEFAULT '',
+  `acknowledged_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`alert_id`),
+  KEY `idx_alerts_user_triggered_at` (`user_id`, `triggered_at`),
+  KEY `idx_alerts_user_rule_type_triggered_at` (`user_id`, `rule_type`, `triggered_at`),
+  CONSTRAINT `fk_alert_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT `fk_alert_glucose_reading` FOREIGN KEY (`glucose_reading_id`) REFERENCES `glucose_readings`(`reading_id`) ON DELETE SET NULL ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `alerts`;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `alert_rules`;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `data_shares` (
+  `share_id` int NOT NULL AUTO_INCREMENT,
+  `owner_id` int NOT NULL,
+  `caregiver_id` int DEFAULT NULL,
+  `invitee_email` varchar(100) NOT NULL,
+  `access` enum('read', 'alerts') NOT NULL,
+  `scopes` varchar(255) NOT NULL,
+  `status` enum('pending', 'accepted', 'revoked') NOT NULL DEFAULT 'pending',
+  `token_hash` char(64) NOT NULL,
+  `expires_at` datetime NOT NULL,
+  `accepted_at` datetime DEFAULT NULL,
+  `revoked_at` datetime DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`share_id`),
+  UNIQUE KEY `token_hash_UNIQUE` (`token_hash`),
+  KEY `idx_data_shares_owner_status` (`owner_id`, `status`),
+  KEY `idx_data_shares_caregiver_status` (`caregiver_id`, `status`),
+  CONSTRAINT `fk_data_share_owner` FOREIGN KEY (`owner_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE ON UPDATE CASCADE,
+  CONSTRAINT `fk_data_share_caregiver` FOREIGN KEY (`caregiver_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `data_shares`;
+-- +goose StatementEnd
@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `webauthn_credentials` (
+  `credential_id` int NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `external_id` varbinary(1023) NOT NULL,
+  `public_key` blob NOT NULL,
+  `algorithm` int NOT NULL,
+  `sign_count` int unsigned NOT NULL DEFAULT 0,
+  `transports` varchar(255) NOT NULL DEFAULT '',
+  `aaguid` binary(16) NOT NULL,
+  `name` varchar(64) NOT NULL,
+  `backup_eligible` tinyint(1) NOT NULL DEFAULT 0,
+  `last_used_at` datetime DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`credential_id`),
+  UNIQUE KEY `uq_webauthn_credentials_external_id` (`external_id`),
+  KEY `idx_webauthn_credentials_user` (`user_id`),
+  CONSTRAINT `fk_webauthn_credential_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `webauthn_challenges` (
+  `challenge_id` int NOT NULL AUTO_INCREMENT,
+  `challenge` varchar(64) NOT NULL,
+  `ceremony` enum('registration', 'login') NOT NULL,
+  `user_id` int DEFAULT NULL,
+  `expires_at` datetime NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`challenge_id`),
+  UNIQUE KEY `uq_webauthn_challenges_challenge` (`challenge`),
+  KEY `idx_webauthn_challenges_expires_at` (`expires_at`),
+  CONSTRAINT `fk_webauthn_challenge_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `webauthn_challenges`;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `webauthn_credentials`;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `user_identities` (
+  `identity_id` int NOT NULL AUTO_INCREMENT,
+  `user_id` int NOT NULL,
+  `provider` varchar(50) NOT NULL,
+  `subject` varchar(255) NOT NULL,
+  `email` varchar(100) NOT NULL,
+  `last_login_at` datetime DEFAULT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`identity_id`),
+  UNIQUE KEY `uq_user_identities_provider_subject` (`provider`, `subject`),
+  KEY `idx_user_identities_user` (`user_id`),
+  CONSTRAINT `fk_user_identity_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`user_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `oidc_login_states` (
+  `login_state_id` int NOT NULL AUTO_INCREMENT,
+  `state_hash` char(64) NOT NULL,
+  `provider` varchar(50) NOT NULL,
+  `nonce` varchar(64) NOT NULL,
+  `code_verifier` varchar(128) NOT NULL,
+  `expires_at` datetime NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`login_state_id`),
+  UNIQUE KEY `uq_oidc_login_states_state_hash` (`state_hash`),
+  KEY `idx_oidc_login_states_expires_at` (`expires_at`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `oidc_login_states`;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS `user_identities`;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `user_mfa`
+ADD COLUMN `failed_attempts` int NOT NULL DEFAULT 0 AFTER `last_used_step`,
+ADD COLUMN `locked_until` datetime DEFAULT NULL AFTER `failed_attempts`;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `user_mfa`
+DROP COLUMN `locked_until`,
+DROP COLUMN `failed_attempts`;
+-- +goose StatementEnd
@@ -0,0 +1,98 @@
+-- name: GetActivityByID :one
+SELECT activity_id,
+    user_id,
+    activity_type,
+    intensity,
+    started_at,
+    duration_minutes,
+    steps,
+    calories,
+    notes,
+    created_at,
+    updated_at
+FROM activities
+WHERE activity_id = ?
+    AND user_id = ?;
+-- name: GetActivitiesByTimeRange :many
+SELECT activity_id,
+    user_id,
+    activity_type,
+    intensity,
+    started_at,
+    duration_minutes,
+    steps,
+    calories,
+    notes,
+    created_at,
+    updated_at
+FROM activities
+WHERE user_id = sqlc.arg(user_id)
+    AND started_at >= sqlc.arg(start_time)
+    AND started_at < sqlc.arg(end_time)
+ORDER BY started_at ASC;
+-- name: GetActivitiesForTimeline :many
+SELECT activity_id,
+    user_id,
+    activity_type,
+    intensity,
+    started_at,
+    duration_minutes,
+    steps,
+    calories,
+    notes,
+    created_at,
+    updated_at
+FROM activities
+WHERE user_id = ?
+    AND (
+        started_at > ?
+        OR (
+            started_at = ?
+            AND activity_id > ?
+        )
+    )
+    AND started_at < ?
+ORDER BY started_at ASC,
+    activity_id ASC
+LIMIT ?;
+-- name: CreateActivity :execlastid
+INSERT INTO activities (
+        user_id,
+        activity_type,
+        intensity,
+        started_at,
+        duration_minutes,
+        steps,
+        calories,
+        notes
+    )
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+-- name: UpdateActivity :execrows
+UPDATE activities
+SET activity_type = ?,
+    intensity = ?,
+    started_at = ?,
+    duration_minutes = ?,
+    steps = ?,
+    calories = ?,
+    notes = ?
+WHERE activity_id = ?
+    AND user_id = ?;
+-- name: DeleteActivity :execrows
+DELETE FROM activities
+WHERE activity_id = ?
+    AND user_id = ?;
+-- name: GetActivityTarget :one
+SELECT user_id,
+    weekly_active_minutes,
+    daily_steps,
+    updated_at
+FROM activity_targets
+WHERE user_id = ?;
+-- name: UpsertActivityTarget :exec
+INSERT INTO activity_targets (user_id, weekly_active_minutes, daily_steps)
+VALUES (?, ?, ?) ON DUPLICATE KEY
+UPDATE weekly_active_minutes =
+VALUES(weekly_active_minutes),
+    daily_steps =
+VALUES(daily_steps);
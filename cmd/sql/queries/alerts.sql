@@ -0,0 +1,137 @@
+-- name: GetAlertRulesByUserID :many
+SELECT alert_rule_id,
+    user_id,
+    rule_type,
+    is_enabled,
+    threshold,
+    cooldown_minutes,
+    channels,
+    snoozed_until,
+    created_at,
+    updated_at
+FROM alert_rules
+WHERE user_id = ?;
+-- name: GetEnabledAlertRulesByType :many
+SELECT alert_rule_id,
+    user_id,
+    rule_type,
+    is_enabled,
+    threshold,
+    cooldown_minutes,
+    channels,
+    snoozed_until,
+    created_at,
+    updated_at
+FROM alert_rules
+WHERE rule_type = ?
+    AND is_enabled = TRUE;
+-- name: UpsertAlertRule :exec
+INSERT INTO alert_rules (
+        user_id,
+        rule_type,
+        is_enabled,
+        threshold,
+        cooldown_minutes,
+        channels
+    )
+VALUES (?, ?, ?, ?, ?, ?) ON DUPLICATE KEY
+UPDATE is_enabled =
+VALUES(is_enabled),
+    threshold =
+VALUES(threshold),
+    cooldown_minutes =
+VALUES(cooldown_minutes),
+    channels =
+VALUES(channels);
+-- name: SnoozeAlertRule :execrows
+UPDATE alert_rules
+SET snoozed_until = ?
+WHERE user_id = ?
+    AND rule_type = ?;
+-- name: CreateAlert :execlastid
+INSERT INTO alerts (
+        user_id,
+        rule_type,
+        glucose_reading_id,
+        value_mgdl,
+        rate_mgdl_per_minute,
+        message,
+        triggered_at
+    )
+VALUES (?, ?, ?, ?, ?, ?, ?);
+-- name: UpdateAlertDelivery :exec
+UPDATE alerts
+SET delivered_channels = ?,
+    delivery_error = ?
+WHERE alert_id = ?;
+-- name: GetLatestAlertByRuleType :one
+SELECT alert_id,
+    user_id,
+    rule_type,
+    glucose_reading_id,
+    value_mgdl,
+    rate_mgdl_per_minute,
+    message,
+    triggered_at,
+    delivered_channels,
+    delivery_error,
+    acknowledged_at
+FROM alerts
+WHERE user_id = ?
+    AND rule_type = ?
+ORDER BY triggered_at DESC
+LIMIT 1;
+-- name: GetAlertsByTimeRange :many
+SELECT alert_id,
+    user_id,
+    rule_type,
+    glucose_reading_id,
+    value_mgdl,
+    rate_mgdl_per_minute,
+    message,
+    triggered_at,
+    delivered_channels,
+    delivery_error,
+    acknowledged_at
+FROM alerts
+WHERE user_id = sqlc.arg(user_id)
+    AND triggered_at >= sqlc.arg(start_time)
+    AND triggered_at < sqlc.arg(end_time)
+ORDER BY triggered_at ASC;
+-- name: GetAlertsForTimeline :many
+SELECT alert_id,
+    user_id,
+    rule_type,
+    glucose_reading_id,
+    value_mgdl,
+    rate_mgdl_per_minute,
+    message,
+    triggered_at,
+    delivered_channels,
+    delivery_error,
+    acknowledged_at
+FROM alerts
+WHERE user_id = ?
+    AND (
+        triggered_at > ?
+        OR (
+            triggered_at = ?
+            AND alert_id > ?
+        )
+    )
+    AND triggered_at < ?
+ORDER BY triggered_at ASC,
+    alert_id ASC
+LIMIT ?;
+-- name: AcknowledgeAlert :execrows
+UPDATE alerts
+SET acknowledged_at = UTC_TIMESTAMP()
+WHERE alert_id = ?
+    AND user_id = ?
+    AND acknowledged_at IS NULL;
+-- name: GetLatestGlucoseReadingTime :one
+SELECT measured_at
+FROM glucose_readings
+WHERE user_id = ?
+ORDER BY measured_at DESC
+LIMIT 1;
@@ -0,0 +1,19 @@
+-- name: GetDietaryRestrictionsByUserID :many
+SELECT dietary_restriction_id,
+    user_id,
+    dietary_restriction_name,
+    created_at,
+    updated_at
+FROM dietary_restrictions
+WHERE user_id = ?
+ORDER BY created_at ASC;
+-- name: CreateDietaryRestriction :exec
+INSERT INTO dietary_restrictions (user_id, dietary_restriction_name)
+VALUES (?, ?);
+-- name: DeleteDietaryRestriction :execrows
+DELETE FROM dietary_restrictions
+WHERE dietary_restriction_id = ?
+    AND user_id = ?;
+-- name: DeleteDietaryRestrictionsByUserID :exec
+DELETE FROM dietary_restrictions
+WHERE user_id = ?;
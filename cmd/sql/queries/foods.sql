@@ -0,0 +1,131 @@
+-- name: CreateFood :execlastid
+INSERT INTO foods (
+        name,
+        category,
+        source,
+        user_id,
+        energy_kcal,
+        carbs_g,
+        protein_g,
+        fat_g,
+        fibre_g,
+        sugar_g
+    )
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+-- name: UpdateFoodNutrients :exec
+UPDATE foods
+SET category = ?,
+    energy_kcal = ?,
+    carbs_g = ?,
+    protein_g = ?,
+    fat_g = ?,
+    fibre_g = ?,
+    sugar_g = ?
+WHERE food_id = ?;
+-- name: GetFoodByID :one
+SELECT food_id,
+    name,
+    category,
+    source,
+    user_id,
+    energy_kcal,
+    carbs_g,
+    protein_g,
+    fat_g,
+    fibre_g,
+    sugar_g,
+    created_at,
+    updated_at
+FROM foods
+WHERE food_id = sqlc.arg(food_id)
+    AND (
+        user_id IS NULL
+        OR user_id = sqlc.arg(user_id)
+    );
+-- name: GetCatalogueFoodByName :one
+SELECT food_id,
+    name,
+    category,
+    source,
+    user_id,
+    energy_kcal,
+    carbs_g,
+    protein_g,
+    fat_g,
+    fibre_g,
+    sugar_g,
+    created_at,
+    updated_at
+FROM foods
+WHERE user_id IS NULL
+    AND name = ?;
+-- name: GetCatalogueFoods :many
+SELECT food_id,
+    name,
+    category,
+    source,
+    user_id,
+    energy_kcal,
+    carbs_g,
+    protein_g,
+    fat_g,
+    fibre_g,
+    sugar_g,
+    created_at,
+    updated_at
+FROM foods
+WHERE user_id IS NULL
+ORDER BY name ASC;
+-- name: GetCustomFoodsByUserID :many
+SELECT food_id,
+    name,
+    category,
+    source,
+    user_id,
+    energy_kcal,
+    carbs_g,
+    protein_g,
+    fat_g,
+    fibre_g,
+    sugar_g,
+    created_at,
+    updated_at
+FROM foods
+WHERE user_id = ?
+ORDER BY name ASC;
+-- name: DeleteCustomFood :execrows
+DELETE FROM foods
+WHERE food_id = ?
+    AND user_id = ?;
+-- name: CreateFoodServing :exec
+INSERT INTO food_servings (food_id, description, grams)
+VALUES (?, ?, ?);
+-- name: DeleteFoodServingsByFoodID :exec
+DELETE FROM food_servings
+WHERE food_id = ?;
+-- name: GetFoodServingsByFoodID :many
+SELECT food_serving_id,
+    food_id,
+    description,
+    grams
+FROM food_servings
+WHERE food_id = ?
+ORDER BY food_serving_id ASC;
+-- name: GetCatalogueFoodServings :many
+SELECT food_servings.food_serving_id,
+    food_servings.food_id,
+    food_servings.description,
+    food_servings.grams
+FROM food_servings
+    JOIN foods ON foods.food_id = food_servings.food_id
+WHERE foods.user_id IS NULL
+ORDER BY food_servings.food_serving_id ASC;
+-- name: GetCustomFoodServingsByUserID :many
+SELECT food_servings.food_serving_id,
+    food_servings.food_id,
+    food_servings.description,
+    food_servings.grams
+FROM food_servings
+    JOIN foods ON foods.food_id = food_servings.food_id
+WHERE foods.user_id = ?
+ORDER BY food_servings.food_serving_id ASC;
@@ -0,0 +1,74 @@
+-- name: CreateGlucoseReading :execlastid
+INSERT INTO glucose_readings (
+        user_id,
+        value,
+        unit,
+        source,
+        measurement_context,
+        measured_at
+    )
+VALUES (?, ?, ?, ?, ?, ?);
+-- name: GetGlucoseReadingByID :one
+SELECT reading_id,
+    user_id,
+    value,
+    unit,
+    source,
+    measurement_context,
+    measured_at,
+    created_at,
+    updated_at
+FROM glucose_readings
+WHERE reading_id = ?
+    AND user_id = ?;
+-- name: GetGlucoseReadingsByTimeRange :many
+SELECT reading_id,
+    user_id,
+    value,
+    unit,
+    source,
+    measurement_context,
+    measured_at,
+    created_at,
+    updated_at
+FROM glucose_readings
+WHERE user_id = sqlc.arg(user_id)
+    AND measured_at >= sqlc.arg(start_time)
+    AND measured_at < sqlc.arg(end_time)
+ORDER BY measured_at ASC;
+-- name: GetGlucoseReadingsForTimeline :many
+SELECT reading_id,
+    user_id,
+    value,
+    unit,
+    source,
+    measurement_context,
+    measured_at,
+    created_at,
+    updated_at
+FROM glucose_readings
+WHERE user_id = ?
+    AND (
+        measured_at > ?
+        OR (
+            measured_at = ?
+            AND reading_id > ?
+        )
+    )
+    AND measured_at < ?
+ORDER BY measured_at ASC,
+    reading_id ASC
+LIMIT ?;
+-- name: UpdateGlucoseReading :exec
+UPDATE glucose_readings
+SET value = ?,
+    unit = ?,
+    source = ?,
+    measurement_context = ?,
+    measured_at = ?
+WHERE reading_id = ?
+    AND user_id = ?;
+-- name: DeleteGlucoseReading :exec
+DELETE FROM glucose_readings
+WHERE reading_id = ?
+    AND user_id = ?;
@@ -0,0 +1,9 @@
+-- name: GetGlycemicIndexFoods :many
+SELECT gi_food_id,
+    name,
+    glycemic_index,
+    serving_size_g,
+    available_carbs_g,
+    created_at
+FROM glycemic_index_foods
+ORDER BY name ASC;
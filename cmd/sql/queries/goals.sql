@@ -0,0 +1,19 @@
+-- name: GetGoalsByUserID :many
+SELECT goal_id,
+    user_id,
+    goal_name,
+    created_at,
+    updated_at
+FROM goals
+WHERE user_id = ?
+ORDER BY created_at ASC;
+-- name: CreateGoal :exec
+INSERT INTO goals (user_id, goal_name)
+VALUES (?, ?);
+-- name: DeleteGoal :execrows
+DELETE FROM goals
+WHERE goal_id = ?
+    AND user_id = ?;
+-- name: DeleteGoalsByUserID :exec
+DELETE FROM goals
+WHERE user_id = ?;
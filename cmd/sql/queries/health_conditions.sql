@@ -0,0 +1,19 @@
+-- name: GetHealthConditionsByUserID :many
+SELECT health_condition_id,
+    user_id,
+    health_condition_name,
+    created_at,
+    updated_at
+FROM health_conditions
+WHERE user_id = ?
+ORDER BY created_at ASC;
+-- name: CreateHealthCondition :exec
+INSERT INTO health_conditions (user_id, health_condition_name)
+VALUES (?, ?);
+-- name: DeleteHealthCondition :execrows
+DELETE FROM health_conditions
+WHERE health_condition_id = ?
+    AND user_id = ?;
+-- name: DeleteHealthConditionsByUserID :exec
+DELETE FROM health_conditions
+WHERE user_id = ?;
@@ -0,0 +1,108 @@
+-- name: GetLabResultByID :one
+SELECT lab_result_id,
+    user_id,
+    test_code,
+    value,
+    unit,
+    reference_low,
+    reference_high,
+    collected_at,
+    lab_name,
+    notes,
+    created_at,
+    updated_at
+FROM lab_results
+WHERE lab_result_id = ?
+    AND user_id = ?;
+-- name: GetLabResultsByTimeRange :many
+SELECT lab_result_id,
+    user_id,
+    test_code,
+    value,
+    unit,
+    reference_low,
+    reference_high,
+    collected_at,
+    lab_name,
+    notes,
+    created_at,
+    updated_at
+FROM lab_results
+WHERE user_id = sqlc.arg(user_id)
+    AND collected_at >= sqlc.arg(start_time)
+    AND collected_at < sqlc.arg(end_time)
+ORDER BY collected_at ASC;
+-- name: GetLabResultsForTimeline :many
+SELECT lab_result_id,
+    user_id,
+    test_code,
+    value,
+    unit,
+    reference_low,
+    reference_high,
+    collected_at,
+    lab_name,
+    notes,
+    created_at,
+    updated_at
+FROM lab_results
+WHERE user_id = ?
+    AND (
+        collected_at > ?
+        OR (
+            collected_at = ?
+            AND lab_result_id > ?
+        )
+    )
+    AND collected_at < ?
+ORDER BY collected_at ASC,
+    lab_result_id ASC
+LIMIT ?;
+-- name: GetLabResultsByTestAndTimeRange :many
+SELECT lab_result_id,
+    user_id,
+    test_code,
+    value,
+    unit,
+    reference_low,
+    reference_high,
+    collected_at,
+    lab_name,
+    notes,
+    created_at,
+    updated_at
+FROM lab_results
+WHERE user_id = sqlc.arg(user_id)
+    AND test_code = sqlc.arg(test_code)
+    AND collected_at >= sqlc.arg(start_time)
+    AND collected_at < sqlc.arg(end_time)
+ORDER BY collected_at ASC;
+-- name: CreateLabResult :execlastid
+INSERT INTO lab_results (
+        user_id,
+        test_code,
+        value,
+        unit,
+        reference_low,
+        reference_high,
+        collected_at,
+        lab_name,
+        notes
+    )
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+-- name: UpdateLabResult :execrows
+UPDATE lab_results
+SET test_code = ?,
+    value = ?,
+    unit = ?,
+    reference_low = ?,
+    reference_high = ?,
+    collected_at = ?,
+    lab_name = ?,
+    notes = ?
+WHERE lab_result_id = ?
+    AND user_id = ?;
+-- name: DeleteLabResult :execrows
+DELETE FROM lab_results
+WHERE lab_result_id = ?
+    AND user_id = ?;
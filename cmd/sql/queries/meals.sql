@@ -0,0 +1,106 @@
+-- name: CreateMeal :execlastid
+INSERT INTO meals (user_id, meal_type, eaten_at, notes)
+VALUES (?, ?, ?, ?);
+-- name: GetMealByID :one
+SELECT meal_id,
+    user_id,
+    meal_type,
+    eaten_at,
+    notes,
+    created_at,
+    updated_at
+FROM meals
+WHERE meal_id = ?
+    AND user_id = ?;
+-- name: GetMealsByTimeRange :many
+SELECT meal_id,
+    user_id,
+    meal_type,
+    eaten_at,
+    notes,
+    created_at,
+    updated_at
+FROM meals
+WHERE user_id = sqlc.arg(user_id)
+    AND eaten_at >= sqlc.arg(start_time)
+    AND eaten_at < sqlc.arg(end_time)
+ORDER BY eaten_at ASC;
+-- name: GetMealsForTimeline :many
+SELECT meal_id,
+    user_id,
+    meal_type,
+    eaten_at,
+    notes,
+    created_at,
+    updated_at
+FROM meals
+WHERE user_id = ?
+    AND (
+        eaten_at > ?
+        OR (
+            eaten_at = ?
+            AND meal_id > ?
+        )
+    )
+    AND eaten_at < ?
+ORDER BY eaten_at ASC,
+    meal_id ASC
+LIMIT ?;
+-- name: UpdateMeal :execrows
+UPDATE meals
+SET meal_type = ?,
+    eaten_at = ?,
+    notes = ?
+WHERE meal_id = ?
+    AND user_id = ?;
+-- name: DeleteMeal :execrows
+DELETE FROM meals
+WHERE meal_id = ?
+    AND user_id = ?;
+-- name: CreateMealItem :exec
+INSERT INTO meal_items (
+        meal_id,
+        name,
+        portion,
+        portion_unit,
+        carbs_g,
+        protein_g,
+        fat_g,
+        fibre_g,
+        calories
+    )
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+-- name: GetMealItemsByMealID :many
+SELECT meal_item_id,
+    meal_id,
+    name,
+    portion,
+    portion_unit,
+    carbs_g,
+    protein_g,
+    fat_g,
+    fibre_g,
+    calories
+FROM meal_items
+WHERE meal_id = ?
+ORDER BY meal_item_id ASC;
+-- name: GetMealItemsByTimeRange :many
+SELECT meal_items.meal_item_id,
+    meal_items.meal_id,
+    meal_items.name,
+    meal_items.portion,
+    meal_items.portion_unit,
+    meal_items.carbs_g,
+    meal_items.protein_g,
+    meal_items.fat_g,
+    meal_items.fibre_g,
+    meal_items.calories
+FROM meal_items
+    JOIN meals ON meals.meal_id = meal_items.meal_id
+WHERE meals.user_id = sqlc.arg(user_id)
+    AND meals.eaten_at >= sqlc.arg(start_time)
+    AND meals.eaten_at < sqlc.arg(end_time)
+ORDER BY meal_items.meal_item_id ASC;
+-- name: DeleteMealItemsByMealID :exec
+DELETE FROM meal_items
+WHERE meal_id = ?;
@@ -0,0 +1,141 @@
+-- name: GetMedications :many
+SELECT medication_id,
+    name,
+    brand_names,
+    class,
+    dose_unit,
+    route
+FROM medications
+ORDER BY name ASC,
+    route ASC;
+-- name: GetMedicationByID :one
+SELECT medication_id,
+    name,
+    brand_names,
+    class,
+    dose_unit,
+    route
+FROM medications
+WHERE medication_id = ?;
+-- name: GetUserMedications :many
+SELECT user_medications.user_medication_id,
+    user_medications.user_id,
+    user_medications.medication_id,
+    medications.name,
+    medications.class,
+    medications.dose_unit,
+    medications.route,
+    user_medications.dose,
+    user_medications.schedule,
+    user_medications.started_on,
+    user_medications.is_active,
+    user_medications.created_at,
+    user_medications.updated_at
+FROM user_medications
+    JOIN medications ON medications.medication_id = user_medications.medication_id
+WHERE user_medications.user_id = ?
+ORDER BY medications.name ASC;
+-- name: GetUserMedicationByID :one
+SELECT user_medications.user_medication_id,
+    user_medications.user_id,
+    user_medications.medication_id,
+    medications.name,
+    medications.class,
+    medications.dose_unit,
+    medications.route,
+    user_medications.dose,
+    user_medications.schedule,
+    user_medications.started_on,
+    user_medications.is_active,
+    user_medications.created_at,
+    user_medications.updated_at
+FROM user_medications
+    JOIN medications ON medications.medication_id = user_medications.medication_id
+WHERE user_medications.user_medication_id = ?
+    AND user_medications.user_id = ?;
+-- name: CreateUserMedication :execlastid
+INSERT INTO user_medications (
+        user_id,
+        medication_id,
+        dose,
+        schedule,
+        started_on,
+        is_active
+    )
+VALUES (?, ?, ?, ?, ?, ?);
+-- name: UpdateUserMedication :execrows
+UPDATE user_medications
+SET dose = ?,
+    schedule = ?,
+    started_on = ?,
+    is_active = ?
+WHERE user_medication_id = ?
+    AND user_id = ?;
+-- name: DeleteUserMedication :execrows
+DELETE FROM user_medications
+WHERE user_medication_id = ?
+    AND user_id = ?;
+-- name: CreateMedicationDose :execlastid
+INSERT INTO medication_doses (user_id, medication_id, amount, taken_at, notes)
+VALUES (?, ?, ?, ?, ?);
+-- name: GetMedicationDoseByID :one
+SELECT medication_doses.dose_id,
+    medication_doses.user_id,
+    medication_doses.medication_id,
+    medications.name,
+    medications.class,
+    medications.dose_unit,
+    medication_doses.amount,
+    medication_doses.taken_at,
+    medication_doses.notes,
+    medication_doses.created_at
+FROM medication_doses
+    JOIN medications ON medications.medication_id = medication_doses.medication_id
+WHERE medication_doses.dose_id = ?
+    AND medication_doses.user_id = ?;
+-- name: GetMedicationDosesByTimeRange :many
+SELECT medication_doses.dose_id,
+    medication_doses.user_id,
+    medication_doses.medication_id,
+    medications.name,
+    medications.class,
+    medications.dose_unit,
+    medication_doses.amount,
+    medication_doses.taken_at,
+    medication_doses.notes,
+    medication_doses.created_at
+FROM medication_doses
+    JOIN medications ON medications.medication_id = medication_doses.medication_id
+WHERE medication_doses.user_id = sqlc.arg(user_id)
+    AND medication_doses.taken_at >= sqlc.arg(start_time)
+    AND medication_doses.taken_at < sqlc.arg(end_time)
+ORDER BY medication_doses.taken_at ASC;
+-- name: GetMedicationDosesForTimeline :many
+SELECT medication_doses.dose_id,
+    medication_doses.user_id,
+    medication_doses.medication_id,
+    medications.name,
+    medications.class,
+    medications.dose_unit,
+    medication_doses.amount,
+    medication_doses.taken_at,
+    medication_doses.notes,
+    medication_doses.created_at
+FROM medication_doses
+    JOIN medications ON medications.medication_id = medication_doses.medication_id
+WHERE medication_doses.user_id = ?
+    AND (
+        medication_doses.taken_at > ?
+        OR (
+            medication_doses.taken_at = ?
+            AND medication_doses.dose_id > ?
+        )
+    )
+    AND medication_doses.taken_at < ?
+ORDER BY medication_doses.taken_at ASC,
+    medication_doses.dose_id ASC
+LIMIT ?;
+-- name: DeleteMedicationDose :execrows
+DELETE FROM medication_doses
+WHERE dose_id = ?
+    AND user_id = ?;
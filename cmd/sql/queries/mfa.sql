@@ -0,0 +1,64 @@
+-- name: GetUserMFA :one
+SELECT user_id,
+    totp_secret,
+    is_enabled,
+    last_used_step,
+    failed_attempts,
+    locked_until,
+    enabled_at,
+    created_at,
+    updated_at
+FROM user_mfa
+WHERE user_id = ?;
+-- name: UpsertUserMFASecret :exec
+INSERT INTO user_mfa (user_id, totp_secret)
+VALUES (?, ?) ON DUPLICATE KEY
+UPDATE totp_secret =
+VALUES(totp_secret),
+    is_enabled = FALSE,
+    last_used_step = 0,
+    enabled_at = NULL;
+-- name: EnableUserMFA :exec
+UPDATE user_mfa
+SET is_enabled = TRUE,
+    enabled_at = UTC_TIMESTAMP()
+WHERE user_id = ?;
+-- name: DeleteUserMFA :exec
+DELETE FROM user_mfa
+WHERE user_id = ?;
+-- name: UseTOTPStep :execrows
+UPDATE user_mfa
+SET last_used_step = ?
+WHERE user_id = ?
+    AND last_used_step < ?;
+-- name: RecordFailedMFAAttempt :execlastid
+UPDATE user_mfa
+SET failed_attempts = LAST_INSERT_ID(failed_attempts + 1)
+WHERE user_id = ?;
+-- name: LockUserMFA :exec
+UPDATE user_mfa
+SET failed_attempts = 0,
+    locked_until = ?
+WHERE user_id = ?;
+-- name: ResetFailedMFAAttempts :exec
+UPDATE user_mfa
+SET failed_attempts = 0,
+    locked_until = NULL
+WHERE user_id = ?;
+-- name: CreateRecoveryCode :exec
+INSERT INTO mfa_recovery_codes (user_id, code_hash)
+VALUES (?, ?);
+-- name: DeleteRecoveryCodes :exec
+DELETE FROM mfa_recovery_codes
+WHERE user_id = ?;
+-- name: UseRecoveryCode :execrows
+UPDATE mfa_recovery_codes
+SET used_at = UTC_TIMESTAMP()
+WHERE user_id = ?
+    AND code_hash = ?
+    AND used_at IS NULL;
+-- name: CountUnusedRecoveryCodes :one
+SELECT COUNT(*)
+FROM mfa_recovery_codes
+WHERE user_id = ?
+    AND used_at IS NULL;
@@ -0,0 +1,56 @@
+-- name: CreateUserIdentity :exec
+INSERT INTO user_identities (user_id, provider, subject, email, last_login_at)
+VALUES (?, ?, ?, ?, UTC_TIMESTAMP());
+-- name: GetUserIdentity :one
+SELECT identity_id,
+    user_id,
+    provider,
+    subject,
+    email,
+    last_login_at,
+    created_at
+FROM user_identities
+WHERE provider = ?
+    AND subject = ?;
+-- name: GetUserIdentitiesByUserID :many
+SELECT identity_id,
+    user_id,
+    provider,
+    subject,
+    email,
+    last_login_at,
+    created_at
+FROM user_identities
+WHERE user_id = ?
+ORDER BY created_at;
+-- name: UpdateUserIdentityLogin :exec
+UPDATE user_identities
+SET email = ?,
+    last_login_at = UTC_TIMESTAMP()
+WHERE identity_id = ?;
+-- name: CreateOIDCLoginState :exec
+INSERT INTO oidc_login_states (
+        state_hash,
+        provider,
+        nonce,
+        code_verifier,
+        expires_at
+    )
+VALUES (?, ?, ?, ?, ?);
+-- name: GetOIDCLoginState :one
+SELECT login_state_id,
+    state_hash,
+    provider,
+    nonce,
+    code_verifier,
+    expires_at,
+    created_at
+FROM oidc_login_states
+WHERE state_hash = ?
+    AND expires_at > UTC_TIMESTAMP();
+-- name: DeleteOIDCLoginState :execrows
+DELETE FROM oidc_login_states
+WHERE state_hash = ?;
+-- name: DeleteExpiredOIDCLoginStates :exec
+DELETE FROM oidc_login_states
+WHERE expires_at <= UTC_TIMESTAMP();
@@ -0,0 +1,23 @@
+-- name: CreatePasswordResetToken :exec
+INSERT INTO password_reset_tokens (user_id, token_hash, expires_at)
+VALUES (?, ?, ?);
+-- name: GetPasswordResetTokenByHash :one
+SELECT password_reset_token_id,
+    user_id,
+    token_hash,
+    expires_at,
+    used_at,
+    created_at
+FROM password_reset_tokens
+WHERE token_hash = ?;
+-- name: MarkPasswordResetTokenUsed :execrows
+UPDATE password_reset_tokens
+SET used_at = UTC_TIMESTAMP()
+WHERE password_reset_token_id = ?
+    AND used_at IS NULL
+    AND expires_at > UTC_TIMESTAMP();
+-- name: InvalidateUserPasswordResetTokens :exec
+UPDATE password_reset_tokens
+SET used_at = UTC_TIMESTAMP()
+WHERE user_id = ?
+    AND used_at IS NULL;
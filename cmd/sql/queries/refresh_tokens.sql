@@ -0,0 +1,58 @@
+-- name: CreateRefreshToken :exec
+INSERT INTO refresh_tokens (
+        user_id,
+        family_id,
+        token_hash,
+        expires_at
+    )
+VALUES (?, ?, ?, ?);
+-- name: GetRefreshTokenByHash :one
+SELECT refresh_token_id,
+    user_id,
+    family_id,
+    token_hash,
+    expires_at,
+    used_at,
+    revoked_at,
+    created_at
+FROM refresh_tokens
+WHERE token_hash = ?;
+-- name: MarkRefreshTokenUsed :execrows
+UPDATE refresh_tokens
+SET used_at = UTC_TIMESTAMP()
+WHERE refresh_token_id = ?
+    AND used_at IS NULL
+    AND revoked_at IS NULL;
+-- name: RevokeRefreshTokenFamily :exec
+UPDATE refresh_tokens
+SET revoked_at = UTC_TIMESTAMP()
+WHERE family_id = ?
+    AND revoked_at IS NULL;
+-- name: RevokeUserRefreshTokens :exec
+UPDATE refresh_tokens
+SET revoked_at = UTC_TIMESTAMP()
+WHERE user_id = ?
+    AND revoked_at IS NULL;
+-- name: GetSignInsForTimeline :many
+SELECT refresh_tokens.refresh_token_id,
+    refresh_tokens.family_id,
+    refresh_tokens.created_at
+FROM refresh_tokens
+WHERE refresh_tokens.user_id = ?
+    AND (
+        refresh_tokens.created_at > ?
+        OR (
+            refresh_tokens.created_at = ?
+            AND refresh_tokens.refresh_token_id > ?
+        )
+    )
+    AND refresh_tokens.created_at < ?
+    AND NOT EXISTS (
+        SELECT 1
+        FROM refresh_tokens AS earlier
+        WHERE earlier.family_id = refresh_tokens.family_id
+            AND earlier.refresh_token_id < refresh_tokens.refresh_token_id
+    )
+ORDER BY refresh_tokens.created_at ASC,
+    refresh_tokens.refresh_token_id ASC
+LIMIT ?;
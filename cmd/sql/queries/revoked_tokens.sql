@@ -0,0 +1,21 @@
+-- name: CreateRevokedToken :exec
+INSERT IGNORE INTO revoked_tokens (jti, user_id, expires_at)
+VALUES (?, ?, ?);
+-- name: GetActiveRevokedTokens :many
+SELECT jti,
+    user_id,
+    expires_at,
+    revoked_at
+FROM revoked_tokens
+WHERE expires_at > UTC_TIMESTAMP();
+-- name: DeleteExpiredRevokedTokens :exec
+DELETE FROM revoked_tokens
+WHERE expires_at <= UTC_TIMESTAMP();
+-- name: UpsertUserSessionRevocation :exec
+INSERT INTO user_session_revocations (user_id, revoked_before)
+VALUES (?, ?) ON DUPLICATE KEY
+UPDATE revoked_before = VALUES(revoked_before);
+-- name: GetUserSessionRevocations :many
+SELECT user_id,
+    revoked_before
+FROM user_session_revocations;
@@ -0,0 +1,139 @@
+-- name: CreateShare :execlastid
+INSERT INTO data_shares (
+        owner_id,
+        invitee_email,
+        access,
+        scopes,
+        token_hash,
+        expires_at
+    )
+VALUES (?, ?, ?, ?, ?, ?);
+-- name: GetShareByID :one
+SELECT s.share_id,
+    s.owner_id,
+    o.username AS owner_username,
+    o.email AS owner_email,
+    s.caregiver_id,
+    c.username AS caregiver_username,
+    s.invitee_email,
+    s.access,
+    s.scopes,
+    s.status,
+    s.expires_at,
+    s.accepted_at,
+    s.revoked_at,
+    s.created_at
+FROM data_shares s
+    JOIN users o ON o.user_id = s.owner_id
+    LEFT JOIN users c ON c.user_id = s.caregiver_id
+WHERE s.share_id = ?;
+-- name: GetShareByTokenHash :one
+SELECT s.share_id,
+    s.owner_id,
+    o.username AS owner_username,
+    o.email AS owner_email,
+    s.caregiver_id,
+    c.username AS caregiver_username,
+    s.invitee_email,
+    s.access,
+    s.scopes,
+    s.status,
+    s.expires_at,
+    s.accepted_at,
+    s.revoked_at,
+    s.created_at
+FROM data_shares s
+    JOIN users o ON o.user_id = s.owner_id
+    LEFT JOIN users c ON c.user_id = s.caregiver_id
+WHERE s.token_hash = ?;
+-- name: GetSharesByOwnerID :many
+SELECT s.share_id,
+    s.owner_id,
+    o.username AS owner_username,
+    o.email AS owner_email,
+    s.caregiver_id,
+    c.username AS caregiver_username,
+    s.invitee_email,
+    s.access,
+    s.scopes,
+    s.status,
+    s.expires_at,
+    s.accepted_at,
+    s.revoked_at,
+    s.created_at
+FROM data_shares s
+    JOIN users o ON o.user_id = s.owner_id
+    LEFT JOIN users c ON c.user_id = s.caregiver_id
+WHERE s.owner_id = ?
+    AND s.status <> 'revoked'
+ORDER BY s.created_at DESC;
+-- name: GetSharesByCaregiverID :many
+SELECT s.share_id,
+    s.owner_id,
+    o.username AS owner_username,
+    o.email AS owner_email,
+    s.caregiver_id,
+    c.username AS caregiver_username,
+    s.invitee_email,
+    s.access,
+    s.scopes,
+    s.status,
+    s.expires_at,
+    s.accepted_at,
+    s.revoked_at,
+    s.created_at
+FROM data_shares s
+    JOIN users o ON o.user_id = s.owner_id
+    LEFT JOIN users c ON c.user_id = s.caregiver_id
+WHERE s.caregiver_id = ?
+    AND s.status = 'accepted'
+ORDER BY s.accepted_at DESC;
+-- name: GetAcceptedShare :one
+SELECT s.share_id,
+    s.owner_id,
+    o.username AS owner_username,
+    o.email AS owner_email,
+    s.caregiver_id,
+    c.username AS caregiver_username,
+    s.invitee_email,
+    s.access,
+    s.scopes,
+    s.status,
+    s.expires_at,
+    s.accepted_at,
+    s.revoked_at,
+    s.created_at
+FROM data_shares s
+    JOIN users o ON o.user_id = s.owner_id
+    LEFT JOIN users c ON c.user_id = s.caregiver_id
+WHERE s.owner_id = ?
+    AND s.caregiver_id = ?
+    AND s.status = 'accepted'
+ORDER BY s.accepted_at DESC
+LIMIT 1;
+-- name: AcceptShare :execrows
+UPDATE data_shares
+SET caregiver_id = ?,
+    status = 'accepted',
+    accepted_at = UTC_TIMESTAMP()
+WHERE share_id = ?
+    AND status = 'pending';
+-- name: UpdateShareAccess :exec
+UPDATE data_shares
+SET access = ?,
+    scopes = ?
+WHERE share_id = ?;
+-- name: RevokeShare :execrows
+UPDATE data_shares
+SET status = 'revoked',
+    revoked_at = UTC_TIMESTAMP()
+WHERE share_id = ?
+    AND status <> 'revoked';
+-- name: RevokeOtherCaregiverShares :exec
+UPDATE data_shares
+SET status = 'revoked',
+    revoked_at = UTC_TIMESTAMP()
+WHERE owner_id = ?
+    AND caregiver_id = ?
+    AND share_id <> ?
+    AND status = 'accepted';
@@ -0,0 +1,80 @@
+-- name: GetUserProfile :one
+SELECT user_id,
+    date_of_birth,
+    sex,
+    height_cm,
+    diabetes_type,
+    diagnosis_date,
+    glucose_unit,
+    target_low,
+    target_high,
+    very_low_threshold,
+    very_high_threshold,
+    timezone,
+    created_at,
+    updated_at
+FROM user_profiles
+WHERE user_id = ?;
+-- name: UpsertUserProfile :exec
+INSERT INTO user_profiles (
+        user_id,
+        date_of_birth,
+        sex,
+        height_cm,
+        diabetes_type,
+        diagnosis_date,
+        glucose_unit,
+        target_low,
+        target_high,
+        very_low_threshold,
+        very_high_threshold,
+        timezone
+    )
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON DUPLICATE KEY
+UPDATE date_of_birth = VALUES(date_of_birth),
+    sex = VALUES(sex),
+    height_cm = VALUES(height_cm),
+    diabetes_type = VALUES(diabetes_type),
+    diagnosis_date = VALUES(diagnosis_date),
+    glucose_unit = VALUES(glucose_unit),
+    target_low = VALUES(target_low),
+    target_high = VALUES(target_high),
+    very_low_threshold = VALUES(very_low_threshold),
+    very_high_threshold = VALUES(very_high_threshold),
+    timezone = VALUES(timezone);
+-- name: CreateWeightEntry :execlastid
+INSERT INTO weight_entries (user_id, weight_kg, recorded_at)
+VALUES (?, ?, ?);
+-- name: GetWeightEntriesByUserID :many
+SELECT weight_entry_id,
+    user_id,
+    weight_kg,
+    recorded_at,
+    created_at
+FROM weight_entries
+WHERE user_id = ?
+ORDER BY recorded_at DESC
+LIMIT ?;
+-- name: GetWeightEntriesForTimeline :many
+SELECT weight_entry_id,
+    user_id,
+    weight_kg,
+    recorded_at,
+    created_at
+FROM weight_entries
+WHERE user_id = ?
+    AND (
+        recorded_at > ?
+        OR (
+            recorded_at = ?
+            AND weight_entry_id > ?
+        )
+    )
+    AND recorded_at < ?
+ORDER BY recorded_at ASC,
+    weight_entry_id ASC
+LIMIT ?;
+-- name: DeleteWeightEntry :execrows
+DELETE FROM weight_entries
+WHERE weight_entry_id = ?
+    AND user_id = ?;
@@ -86,3 +86,12 @@ WHERE user_id = ?;
 UPDATE users
 SET is_verified = ?
 WHERE user_id = ?;
+-- name: UpdateUserUsername :exec
+UPDATE users
+SET username = ?
+WHERE user_id = ?;
+-- name: UpdateUserEmail :exec
+UPDATE users
+SET email = ?,
+    is_verified = ?
+WHERE user_id = ?;
@@ -0,0 +1,86 @@
+-- name: CreateWebAuthnCredential :exec
+INSERT INTO webauthn_credentials (
+        user_id,
+        external_id,
+        public_key,
+        algorithm,
+        sign_count,
+        transports,
+        aaguid,
+        name,
+        backup_eligible
+    )
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+-- name: GetWebAuthnCredentialByID :one
+SELECT credential_id,
+    user_id,
+    external_id,
+    public_key,
+    algorithm,
+    sign_count,
+    transports,
+    aaguid,
+    name,
+    backup_eligible,
+    last_used_at,
+    created_at
+FROM webauthn_credentials
+WHERE credential_id = ?;
+-- name: GetWebAuthnCredentialByExternalID :one
+SELECT credential_id,
+    user_id,
+    external_id,
+    public_key,
+    algorithm,
+    sign_count,
+    transports,
+    aaguid,
+    name,
+    backup_eligible,
+    last_used_at,
+    created_at
+FROM webauthn_credentials
+WHERE external_id = ?;
+-- name: GetWebAuthnCredentialsByUserID :many
+SELECT credential_id,
+    user_id,
+    external_id,
+    public_key,
+    algorithm,
+    sign_count,
+    transports,
+    aaguid,
+    name,
+    backup_eligible,
+    last_used_at,
+    created_at
+FROM webauthn_credentials
+WHERE user_id = ?
+ORDER BY created_at DESC;
+-- name: UpdateWebAuthnCredentialUsage :exec
+UPDATE webauthn_credentials
+SET sign_count = ?,
+    last_used_at = UTC_TIMESTAMP()
+WHERE credential_id = ?;
+-- name: DeleteWebAuthnCredential :exec
+DELETE FROM webauthn_credentials
+WHERE credential_id = ?;
+-- name: CreateWebAuthnChallenge :exec
+INSERT INTO webauthn_challenges (challenge, ceremony, user_id, expires_at)
+VALUES (?, ?, ?, ?);
+-- name: GetWebAuthnChallenge :one
+SELECT challenge_id,
+    challenge,
+    ceremony,
+    user_id,
+    expires_at,
+    created_at
+FROM webauthn_challenges
+WHERE challenge = ?
+    AND expires_at > UTC_TIMESTAMP();
+-- name: DeleteWebAuthnChallenge :execrows
+DELETE FROM webauthn_challenges
+WHERE challenge = ?;
+-- name: DeleteExpiredWebAuthnChallenges :exec
+DELETE FROM webauthn_challenges
+WHERE expires_at <= UTC_TIMESTAMP();
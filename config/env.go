@@ -4,27 +4,47 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	PublicHost             string
-	BackendHost            string
-	Port                   string
-	DBUser                 string
-	DBPasswd               string
-	DBAddr                 string
-	DBName                 string
-	DBHost                 string
-	JWTExpirationInSeconds int64
-	JWTSecret              string
-	ISProduction           bool
-	SMPTHost               string
-	SMTPPort               string
-	SMTPUsername           string
-	SMTPPassword           string
-	EMAILFrom              string
+	PublicHost                       string
+	BackendHost                      string
+	Port                             string
+	DBUser                           string
+	DBPasswd                         string
+	DBAddr                           string
+	DBName                           string
+	DBHost                           string
+	JWTExpirationInSeconds           int64
+	JWTIssuer                        string
+	JWTAudience                      string
+	JWTKeysDir                       string
+	JWTSigningAlgorithm              string
+	JWTKeyRotationInSeconds          int64
+	RefreshTokenExpirationInSeconds  int64
+	PasswordResetExpirationInSeconds int64
+	ISProduction                     bool
+	SMPTHost                         string
+	SMTPPort                         string
+	SMTPUsername                     string
+	SMTPPassword                     string
+	EMAILFrom                        string
+	WebAuthnRPID                     string
+	WebAuthnRPName                   string
+	WebAuthnOrigins                  string
+	OIDCProviders                    []OIDCProvider
+}
+
+// OIDCProvider configures an OpenID Connect provider users can log in with
+type OIDCProvider struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
 }
 
 var Envs = initConfig()
@@ -42,15 +62,25 @@ func initConfig() Config {
 		DBAddr: fmt.Sprintf(
 			"%s:%s", getEnv("DB_HOST", "127.0.0.1"), getEnv("DB_PORT", "3306"),
 		),
-		DBName:                 getEnv("DB_NAME", "event"),
-		JWTSecret:              getEnv("JWT_SECRET", "not-secret-anymore?"),
-		JWTExpirationInSeconds: getEnvAsInt("JWT_EXP", 3600*24*7),
-		ISProduction:           getEnvAsBool("IS_PRODUCTION", false),
-		SMPTHost:               getEnv("SMTP_HOST", ""),
-		SMTPPort:               getEnv("SMTP_PORT", ""),
-		SMTPUsername:           getEnv("SMTP_USERNAME", ""),
-		SMTPPassword:           getEnv("SMTP_PASSWORD", ""),
-		EMAILFrom:              getEnv("EMAIL_FROM", ""),
+		DBName:                           getEnv("DB_NAME", "event"),
+		JWTIssuer:                        getEnv("JWT_ISSUER", getEnv("BACKEND_HOST", "http://127.0.0.1:8080")),
+		JWTAudience:                      getEnv("JWT_AUDIENCE", "abundance-api"),
+		JWTKeysDir:                       getEnv("JWT_KEYS_DIR", "keys"),
+		JWTSigningAlgorithm:              getEnv("JWT_SIGNING_ALG", "EdDSA"),
+		JWTKeyRotationInSeconds:          getEnvAsInt("JWT_KEY_ROTATION", 3600*24*30),
+		JWTExpirationInSeconds:           getEnvAsInt("JWT_EXP", 60*15),
+		RefreshTokenExpirationInSeconds:  getEnvAsInt("REFRESH_TOKEN_EXP", 3600*24*30),
+		PasswordResetExpirationInSeconds: getEnvAsInt("PASSWORD_RESET_EXP", 60*30),
+		ISProduction:                     getEnvAsBool("IS_PRODUCTION", false),
+		SMPTHost:                         getEnv("SMTP_HOST", ""),
+		SMTPPort:                         getEnv("SMTP_PORT", ""),
+		SMTPUsername:                     getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:                     getEnv("SMTP_PASSWORD", ""),
+		EMAILFrom:                        getEnv("EMAIL_FROM", ""),
+		WebAuthnRPID:                     getEnv("WEBAUTHN_RP_ID", "localhost"),
+		WebAuthnRPName:                   getEnv("WEBAUTHN_RP_NAME", "Abundance"),
+		WebAuthnOrigins:                  getEnv("WEBAUTHN_ORIGINS", getEnv("PUBLIC_HOST", "http://localhost:3000")),
+		OIDCProviders:                    getOIDCProviders(getEnv("PUBLIC_HOST", "http://localhost:3000")),
 	}
 }
 
@@ -62,6 +92,34 @@ func getEnv(key string, fallback string) string {
 	return fallback
 }
 
+// getOIDCProviders reads the providers named in OIDC_PROVIDERS, e.g. "google,apple",
+// each configured with OIDC_<NAME>_ISSUER, _CLIENT_ID, _CLIENT_SECRET and _REDIRECT_URL.
+// Providers without an issuer or client ID are skipped.
+func getOIDCProviders(publicHost string) []OIDCProvider {
+	var providers []OIDCProvider
+	for _, name := range strings.Split(getEnv("OIDC_PROVIDERS", ""), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		provider := OIDCProvider{
+			Name:         name,
+			Issuer:       getEnv(prefix+"ISSUER", ""),
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			RedirectURL:  getEnv(prefix+"REDIRECT_URL", fmt.Sprintf("%s/auth/%s/callback", publicHost, name)),
+		}
+		if provider.Issuer == "" || provider.ClientID == "" {
+			continue
+		}
+		providers = append(providers, provider)
+	}
+
+	return providers
+}
+
 func getEnvAsInt(key string, fallback int64) int64 {
 	if value, ok := os.LookupEnv(key); ok {
 		i, err := strconv.ParseInt(value, 10, 64)
@@ -0,0 +1,97 @@
+package activity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+func intPtr(i int) *int { return &i }
+
+func TestWeeklyTotals(t *testing.T) {
+	loc := time.UTC
+	// Monday 3 February 2025
+	monday := time.Date(2025, 2, 3, 0, 0, 0, 0, loc)
+	target := &types.ActivityTarget{WeeklyActiveMinutes: 150, DailySteps: 1000}
+
+	activities := []*types.Activity{
+		{Intensity: "moderate", StartedAt: monday.Add(8 * time.Hour), DurationMinutes: 60, Steps: intPtr(7000)},
+		{Intensity: "vigorous", StartedAt: monday.AddDate(0, 0, 2).Add(18 * time.Hour), DurationMinutes: 45},
+		{Intensity: "light", StartedAt: monday.AddDate(0, 0, 6).Add(20 * time.Hour), DurationMinutes: 30},
+		{Intensity: "moderate", StartedAt: monday.AddDate(0, 0, 8), DurationMinutes: 20},
+	}
+
+	weeks := WeeklyTotals(activities, target, loc, monday.AddDate(0, 0, 1), monday.AddDate(0, 0, 15))
+	if len(weeks) != 3 {
+		t.Fatalf("got %d weeks, want 3", len(weeks))
+	}
+
+	first := weeks[0]
+	if first.Start != "2025-02-03" || first.Activities != 3 || first.DurationMinutes != 135 {
+		t.Errorf("first week = %+v", first)
+	}
+	if first.ActiveMinutes != 150 || !first.TargetMet || first.ActiveMinutesPercent != 100 {
+		t.Errorf("first week active minutes = %d (%v%%), target met %v, want 150 (100%%) and met", first.ActiveMinutes, first.ActiveMinutesPercent, first.TargetMet)
+	}
+	if first.AverageDailySteps != 1000 || first.StepsPercent != 100 {
+		t.Errorf("first week steps = %d (%v%%), want 1000 (100%%)", first.AverageDailySteps, first.StepsPercent)
+	}
+
+	if weeks[1].ActiveMinutes != 20 || weeks[1].TargetMet {
+		t.Errorf("second week = %+v, want 20 active minutes and target not met", weeks[1])
+	}
+	if weeks[2].Activities != 0 {
+		t.Errorf("third week should be empty, got %+v", weeks[2])
+	}
+}
+
+func TestAnalyzeGlucose(t *testing.T) {
+	start := time.Date(2025, 2, 3, 17, 0, 0, 0, time.UTC)
+	activity := &types.Activity{ID: 1, ActivityType: "running", StartedAt: start, DurationMinutes: 40}
+
+	reading := func(minutes int, value float64) *types.GlucoseReading {
+		return &types.GlucoseReading{Value: value, Unit: "mg/dL", MeasuredAt: start.Add(time.Duration(minutes) * time.Minute)}
+	}
+	readings := []*types.GlucoseReading{
+		reading(-20, 150),
+		reading(-5, 160),
+		reading(20, 130),
+		reading(42, 110),
+		reading(120, 75),
+		reading(200, 95),
+	}
+
+	result := AnalyzeGlucose(activity, readings, "mg/dL")
+	if result.Status != types.ActivityGlucoseOK {
+		t.Fatalf("status = %q, want ok", result.Status)
+	}
+	if result.Before != 160 || result.After != 110 || result.Change != -50 || result.RecoveryLow != 75 {
+		t.Errorf("result = %+v, want 160 -> 110 (-50), low 75", result)
+	}
+
+	missing := AnalyzeGlucose(activity, readings[2:], "mg/dL")
+	if missing.Status != types.ActivityGlucoseInsufficientData {
+		t.Errorf("status without a starting reading = %q, want insufficient_data", missing.Status)
+	}
+}
+
+func TestSummarizeByType(t *testing.T) {
+	results := []*types.ActivityGlucose{
+		{ActivityType: "walking", Status: types.ActivityGlucoseOK, Change: -20, RecoveryLow: 90},
+		{ActivityType: "running", Status: types.ActivityGlucoseOK, Change: -50, RecoveryLow: 70},
+		{ActivityType: "walking", Status: types.ActivityGlucoseOK, Change: -10, RecoveryLow: 100},
+		{ActivityType: "strength", Status: types.ActivityGlucoseInsufficientData},
+	}
+
+	summaries := SummarizeByType(results, "mg/dL")
+	if len(summaries) != 2 {
+		t.Fatalf("got %d summaries, want 2", len(summaries))
+	}
+	if summaries[0].ActivityType != "running" {
+		t.Errorf("largest drop = %q, want running", summaries[0].ActivityType)
+	}
+	if summaries[1].Activities != 2 || summaries[1].AverageChange != -15 || summaries[1].LowestRecovery != 90 {
+		t.Errorf("walking summary = %+v", summaries[1])
+	}
+}
@@ -0,0 +1,123 @@
+package activity
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jayden1905/abundance/service/glucose"
+	"github.com/jayden1905/abundance/types"
+)
+
+const (
+	// BeforeWindow is how long before an activity a reading still counts as the starting glucose
+	BeforeWindow = 30 * time.Minute
+	// endWindow is how far either side of the end of an activity a reading counts as the finishing glucose
+	endWindow = 15 * time.Minute
+	// RecoveryWindow is how long after an activity readings are searched for a delayed low
+	RecoveryWindow = 4 * time.Hour
+)
+
+// AnalyzeGlucose compares the glucose before and after the activity, from readings
+// sorted by time covering BeforeWindow before the start to RecoveryWindow after the end.
+// Results are converted to unit.
+func AnalyzeGlucose(activity *types.Activity, readings []*types.GlucoseReading, unit string) *types.ActivityGlucose {
+	result := &types.ActivityGlucose{
+		ActivityID:      activity.ID,
+		ActivityType:    activity.ActivityType,
+		Intensity:       activity.Intensity,
+		StartedAt:       activity.StartedAt,
+		DurationMinutes: activity.DurationMinutes,
+		Unit:            unit,
+		Status:          types.ActivityGlucoseInsufficientData,
+	}
+
+	end := activity.EndedAt()
+
+	// The starting glucose is the last reading shortly before the activity
+	var before *types.GlucoseReading
+	for _, reading := range readings {
+		if !reading.MeasuredAt.Before(activity.StartedAt.Add(-BeforeWindow)) && !reading.MeasuredAt.After(activity.StartedAt) {
+			before = reading
+		}
+	}
+
+	// The finishing glucose is the reading closest to the end of the activity
+	var after *types.GlucoseReading
+	for _, reading := range readings {
+		offset := absDuration(reading.MeasuredAt.Sub(end))
+		if offset <= endWindow && (after == nil || offset < absDuration(after.MeasuredAt.Sub(end))) {
+			after = reading
+		}
+	}
+
+	if before == nil || after == nil {
+		return result
+	}
+
+	beforeMgdL := glucose.ToMgdL(before.Value, before.Unit)
+	afterMgdL := glucose.ToMgdL(after.Value, after.Unit)
+
+	recoveryLow := afterMgdL
+	for _, reading := range readings {
+		if reading.MeasuredAt.Before(end) || reading.MeasuredAt.After(end.Add(RecoveryWindow)) {
+			continue
+		}
+		if value := glucose.ToMgdL(reading.Value, reading.Unit); value < recoveryLow {
+			recoveryLow = value
+		}
+	}
+
+	result.Status = types.ActivityGlucoseOK
+	result.Before = convert(beforeMgdL, unit)
+	result.After = convert(afterMgdL, unit)
+	result.Change = convert(afterMgdL-beforeMgdL, unit)
+	result.RecoveryLow = convert(recoveryLow, unit)
+
+	return result
+}
+
+// SummarizeByType averages the analysed activities of each type, ordered by the
+// largest average drop first
+func SummarizeByType(results []*types.ActivityGlucose, unit string) []*types.ActivityTypeGlucose {
+	summaries := make([]*types.ActivityTypeGlucose, 0)
+	byType := make(map[string]*types.ActivityTypeGlucose)
+	for _, r := range results {
+		if r.Status != types.ActivityGlucoseOK {
+			continue
+		}
+
+		s, ok := byType[r.ActivityType]
+		if !ok {
+			s = &types.ActivityTypeGlucose{ActivityType: r.ActivityType, Unit: unit, LowestRecovery: r.RecoveryLow}
+			byType[r.ActivityType] = s
+			summaries = append(summaries, s)
+		}
+		s.Activities++
+		s.AverageChange += r.Change
+		if r.RecoveryLow < s.LowestRecovery {
+			s.LowestRecovery = r.RecoveryLow
+		}
+	}
+
+	for _, s := range summaries {
+		s.AverageChange = round(s.AverageChange / float64(s.Activities))
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].AverageChange < summaries[j].AverageChange
+	})
+
+	return summaries
+}
+
+// convert converts a mg/dL value or difference to unit and rounds it
+func convert(mgdL float64, unit string) float64 {
+	return round(glucose.FromMgdL(mgdL, unit))
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
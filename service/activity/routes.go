@@ -0,0 +1,340 @@
+package activity
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+type Handler struct {
+	store        types.ActivityStore
+	glucoseStore types.GlucoseStore
+	profileStore types.ProfileStore
+	userStore    types.UserStore
+}
+
+func NewHandler(store types.ActivityStore, glucoseStore types.GlucoseStore, profileStore types.ProfileStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, glucoseStore: glucoseStore, profileStore: profileStore, userStore: userStore}
+}
+
+// RegisterRoutes for Fiber
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/activities", auth.WithSharedAccess(h.handleGetActivities, h.userStore, types.ShareScopeActivity))
+	router.Post("/activities", auth.WithJWTAuth(h.handleCreateActivity, h.userStore))
+	router.Get("/activities/weekly", auth.WithSharedAccess(h.handleGetWeeklyTotals, h.userStore, types.ShareScopeActivity))
+	router.Get("/activities/glucose", auth.WithSharedAccess(h.handleGetActivityGlucose, h.userStore, types.ShareScopeActivity, types.ShareScopeGlucose))
+	router.Get("/activities/:id", auth.WithSharedAccess(h.handleGetActivityByID, h.userStore, types.ShareScopeActivity))
+	router.Put("/activities/:id", auth.WithJWTAuth(h.handleUpdateActivity, h.userStore))
+	router.Delete("/activities/:id", auth.WithJWTAuth(h.handleDeleteActivity, h.userStore))
+	router.Get("/user/me/activity-target", auth.WithSharedAccess(h.handleGetActivityTarget, h.userStore, types.ShareScopeActivity))
+	router.Put("/user/me/activity-target", auth.WithJWTAuth(h.handleUpdateActivityTarget, h.userStore))
+}
+
+// Handler for listing the user's activities in a time range
+func (h *Handler) handleGetActivities(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Default to the last seven days when no range is given
+	from, to, err := utils.ParseTimeRange(c.Query("from"), c.Query("to"), 7*24*time.Hour)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	activities, err := h.store.GetActivitiesByTimeRange(userID, from, to)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting activities: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"from":       from,
+		"to":         to,
+		"activities": activities,
+	})
+}
+
+// Handler for logging an activity
+func (h *Handler) handleCreateActivity(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.ActivityPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	activity := toActivityFromPayload(payload)
+	activity.UserID = userID
+
+	id, err := h.store.CreateActivity(c.Context(), activity)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error logging activity: %v", err)})
+	}
+
+	created, err := h.store.GetActivityByID(id, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting activity: %v", err)})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(created)
+}
+
+// Handler for getting a single activity
+func (h *Handler) handleGetActivityByID(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+
+	activity, err := h.store.GetActivityByID(int32(intID), userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("%v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(activity)
+}
+
+// Handler for replacing an activity
+func (h *Handler) handleUpdateActivity(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+	id := int32(intID)
+
+	// Parse JSON payload
+	var payload types.ActivityPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	activity := toActivityFromPayload(payload)
+	activity.ID = id
+	activity.UserID = userID
+
+	found, err := h.store.UpdateActivity(c.Context(), activity)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error updating activity: %v", err)})
+	}
+	if !found {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Activity not found"})
+	}
+
+	updated, err := h.store.GetActivityByID(id, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting activity: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(updated)
+}
+
+// Handler for deleting an activity
+func (h *Handler) handleDeleteActivity(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+
+	deleted, err := h.store.DeleteActivity(c.Context(), int32(intID), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error deleting activity: %v", err)})
+	}
+	if !deleted {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Activity not found"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Activity deleted successfully"})
+}
+
+// Handler for weekly activity totals and progress against the user's target
+func (h *Handler) handleGetWeeklyTotals(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	const maxRange = 366 * 24 * time.Hour
+
+	// Default to the last four weeks
+	from, to, err := utils.ParseTimeRange(c.Query("from"), c.Query("to"), 28*24*time.Hour)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if to.Sub(from) > maxRange {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Range cannot be longer than a year"})
+	}
+
+	profile, err := h.profileStore.GetProfileByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting profile: %v", err)})
+	}
+	loc := profile.Location()
+
+	target, err := h.store.GetActivityTarget(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting activity target: %v", err)})
+	}
+
+	// Widen the range to whole weeks so the first and last totals are complete
+	start := weekStart(from.In(loc))
+	end := weekStart(to.In(loc)).AddDate(0, 0, 7)
+
+	activities, err := h.store.GetActivitiesByTimeRange(userID, start, end)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting activities: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"timezone": loc.String(),
+		"target":   target,
+		"weeks":    WeeklyTotals(activities, target, loc, from, to),
+	})
+}
+
+// Handler for the glucose change around each of the user's activities in a time range
+func (h *Handler) handleGetActivityGlucose(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	const maxRange = 90 * 24 * time.Hour
+
+	// Default to the last 30 days
+	from, to, err := utils.ParseTimeRange(c.Query("from"), c.Query("to"), 30*24*time.Hour)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if to.Sub(from) > maxRange {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Range cannot be longer than 90 days"})
+	}
+
+	profile, err := h.profileStore.GetProfileByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting profile: %v", err)})
+	}
+	unit := profile.GlucoseUnit
+
+	activities, err := h.store.GetActivitiesByTimeRange(userID, from, to)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting activities: %v", err)})
+	}
+
+	// Activities can last up to a day, so fetch enough readings for the longest one
+	readings, err := h.glucoseStore.GetGlucoseReadingsByTimeRange(userID, from.Add(-BeforeWindow), to.Add(24*time.Hour+RecoveryWindow+time.Second))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting glucose readings: %v", err)})
+	}
+
+	results := make([]*types.ActivityGlucose, 0, len(activities))
+	for _, activity := range activities {
+		// Readings are sorted by time, so find each activity's slice by binary search
+		first := sort.Search(len(readings), func(i int) bool {
+			return !readings[i].MeasuredAt.Before(activity.StartedAt.Add(-BeforeWindow))
+		})
+		last := sort.Search(len(readings), func(i int) bool {
+			return readings[i].MeasuredAt.After(activity.EndedAt().Add(RecoveryWindow))
+		})
+
+		results = append(results, AnalyzeGlucose(activity, readings[first:last], unit))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"from":       from,
+		"to":         to,
+		"activities": results,
+		"by_type":    SummarizeByType(results, unit),
+	})
+}
+
+// Handler for getting the user's activity target
+func (h *Handler) handleGetActivityTarget(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	target, err := h.store.GetActivityTarget(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting activity target: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(target)
+}
+
+// Handler for setting the user's activity target
+func (h *Handler) handleUpdateActivityTarget(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.UpdateActivityTargetPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	target := &types.ActivityTarget{
+		UserID:              userID,
+		WeeklyActiveMinutes: payload.WeeklyActiveMinutes,
+		DailySteps:          payload.DailySteps,
+	}
+
+	if err := h.store.UpdateActivityTarget(c.Context(), target); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error updating activity target: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(target)
+}
+
+// toActivityFromPayload converts the payload, filling in the default type and intensity
+func toActivityFromPayload(payload types.ActivityPayload) *types.Activity {
+	activity := &types.Activity{
+		ActivityType:    payload.ActivityType,
+		Intensity:       payload.Intensity,
+		StartedAt:       payload.StartedAt,
+		DurationMinutes: payload.DurationMinutes,
+		Steps:           payload.Steps,
+		Calories:        payload.Calories,
+		Notes:           strings.TrimSpace(payload.Notes),
+	}
+	if activity.ActivityType == "" {
+		activity.ActivityType = "other"
+	}
+	if activity.Intensity == "" {
+		activity.Intensity = "moderate"
+	}
+	return activity
+}
@@ -0,0 +1,182 @@
+package activity
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// GetActivityByID fetches a single activity logged by the user
+func (s *Store) GetActivityByID(id int32, userID int32) (*types.Activity, error) {
+	row, err := s.db.GetActivityByID(context.Background(), database.GetActivityByIDParams{
+		ActivityID: id,
+		UserID:     userID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("activity not found")
+		}
+		return nil, err
+	}
+
+	return toActivity(row), nil
+}
+
+// GetActivitiesByTimeRange fetches the user's activities started in [start, end) ordered by time
+func (s *Store) GetActivitiesByTimeRange(userID int32, start time.Time, end time.Time) ([]*types.Activity, error) {
+	rows, err := s.db.GetActivitiesByTimeRange(context.Background(), database.GetActivitiesByTimeRangeParams{
+		UserID:    userID,
+		StartTime: start.UTC(),
+		EndTime:   end.UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	activities := make([]*types.Activity, 0, len(rows))
+	for _, row := range rows {
+		activities = append(activities, toActivity(row))
+	}
+
+	return activities, nil
+}
+
+// CreateActivity logs an activity and returns its ID
+func (s *Store) CreateActivity(ctx context.Context, activity *types.Activity) (int32, error) {
+	id, err := s.db.CreateActivity(ctx, database.CreateActivityParams{
+		UserID:          activity.UserID,
+		ActivityType:    database.ActivitiesActivityType(activity.ActivityType),
+		Intensity:       database.ActivitiesIntensity(activity.Intensity),
+		StartedAt:       activity.StartedAt.UTC(),
+		DurationMinutes: int32(activity.DurationMinutes),
+		Steps:           toNullInt32(activity.Steps),
+		Calories:        toNullFloat64(activity.Calories),
+		Notes:           activity.Notes,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(id), nil
+}
+
+// UpdateActivity replaces an activity. It returns false if the activity does not exist.
+func (s *Store) UpdateActivity(ctx context.Context, activity *types.Activity) (bool, error) {
+	if _, err := s.GetActivityByID(activity.ID, activity.UserID); err != nil {
+		if err.Error() == "activity not found" {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// MySQL reports unchanged rows as not affected, so existence is checked above
+	_, err := s.db.UpdateActivity(ctx, database.UpdateActivityParams{
+		ActivityType:    database.ActivitiesActivityType(activity.ActivityType),
+		Intensity:       database.ActivitiesIntensity(activity.Intensity),
+		StartedAt:       activity.StartedAt.UTC(),
+		DurationMinutes: int32(activity.DurationMinutes),
+		Steps:           toNullInt32(activity.Steps),
+		Calories:        toNullFloat64(activity.Calories),
+		Notes:           activity.Notes,
+		ActivityID:      activity.ID,
+		UserID:          activity.UserID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// DeleteActivity deletes an activity logged by the user
+func (s *Store) DeleteActivity(ctx context.Context, id int32, userID int32) (bool, error) {
+	rows, err := s.db.DeleteActivity(ctx, database.DeleteActivityParams{
+		ActivityID: id,
+		UserID:     userID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+// GetActivityTarget fetches the user's activity target, or the defaults if they have not set one
+func (s *Store) GetActivityTarget(userID int32) (*types.ActivityTarget, error) {
+	row, err := s.db.GetActivityTarget(context.Background(), userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return &types.ActivityTarget{
+				UserID:              userID,
+				WeeklyActiveMinutes: types.DefaultWeeklyActiveMinutes,
+				DailySteps:          types.DefaultDailySteps,
+			}, nil
+		}
+		return nil, err
+	}
+
+	return &types.ActivityTarget{
+		UserID:              row.UserID,
+		WeeklyActiveMinutes: int(row.WeeklyActiveMinutes),
+		DailySteps:          int(row.DailySteps),
+	}, nil
+}
+
+// UpdateActivityTarget creates or replaces the user's activity target
+func (s *Store) UpdateActivityTarget(ctx context.Context, target *types.ActivityTarget) error {
+	return s.db.UpsertActivityTarget(ctx, database.UpsertActivityTargetParams{
+		UserID:              target.UserID,
+		WeeklyActiveMinutes: int32(target.WeeklyActiveMinutes),
+		DailySteps:          int32(target.DailySteps),
+	})
+}
+
+func toActivity(row database.Activity) *types.Activity {
+	activity := &types.Activity{
+		ID:              row.ActivityID,
+		UserID:          row.UserID,
+		ActivityType:    string(row.ActivityType),
+		Intensity:       string(row.Intensity),
+		StartedAt:       row.StartedAt,
+		DurationMinutes: int(row.DurationMinutes),
+		Notes:           row.Notes,
+		CreatedAt:       row.CreatedAt,
+		UpdatedAt:       row.UpdatedAt,
+	}
+	if row.Steps.Valid {
+		steps := int(row.Steps.Int32)
+		activity.Steps = &steps
+	}
+	if row.Calories.Valid {
+		calories := row.Calories.Float64
+		activity.Calories = &calories
+	}
+	return activity
+}
+
+func toNullInt32(i *int) sql.NullInt32 {
+	if i == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: int32(*i), Valid: true}
+}
+
+func toNullFloat64(f *float64) sql.NullFloat64 {
+	if f == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *f, Valid: true}
+}
@@ -0,0 +1,81 @@
+package activity
+
+import (
+	"math"
+	"time"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+// ActiveMinutes converts the activity to moderate-intensity minutes. Vigorous
+// activity counts twice and light activity does not count.
+func ActiveMinutes(activity *types.Activity) int {
+	switch activity.Intensity {
+	case "vigorous":
+		return 2 * activity.DurationMinutes
+	case "moderate":
+		return activity.DurationMinutes
+	default:
+		return 0
+	}
+}
+
+// WeeklyTotals sums the activities per week in loc, from the week containing from
+// to the week containing to, and measures each week against the target.
+// Weeks without activities are included with zero totals.
+func WeeklyTotals(activities []*types.Activity, target *types.ActivityTarget, loc *time.Location, from time.Time, to time.Time) []*types.ActivityWeek {
+	weeks := make([]*types.ActivityWeek, 0)
+	byStart := make(map[string]*types.ActivityWeek)
+
+	last := weekStart(to.In(loc))
+	for week := weekStart(from.In(loc)); !week.After(last); week = week.AddDate(0, 0, 7) {
+		w := &types.ActivityWeek{Start: week.Format("2006-01-02")}
+		weeks = append(weeks, w)
+		byStart[w.Start] = w
+	}
+
+	for _, activity := range activities {
+		w, ok := byStart[weekStart(activity.StartedAt.In(loc)).Format("2006-01-02")]
+		if !ok {
+			continue
+		}
+		w.Activities++
+		w.DurationMinutes += activity.DurationMinutes
+		w.ActiveMinutes += ActiveMinutes(activity)
+		if activity.Steps != nil {
+			w.Steps += *activity.Steps
+		}
+		if activity.Calories != nil {
+			w.Calories += *activity.Calories
+		}
+	}
+
+	for _, w := range weeks {
+		w.Calories = round(w.Calories)
+		w.AverageDailySteps = w.Steps / 7
+		w.ActiveMinutesPercent = percent(w.ActiveMinutes, target.WeeklyActiveMinutes)
+		w.StepsPercent = percent(w.AverageDailySteps, target.DailySteps)
+		w.TargetMet = w.ActiveMinutes >= target.WeeklyActiveMinutes
+	}
+
+	return weeks
+}
+
+// weekStart returns local midnight of the Monday of the week
+func weekStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+func percent(value int, target int) float64 {
+	if target <= 0 {
+		return 0
+	}
+	return round(float64(value) / float64(target) * 100)
+}
+
+// round rounds to one decimal place
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
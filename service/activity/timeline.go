@@ -0,0 +1,44 @@
+package activity
+
+import (
+	"context"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+)
+
+// TimelineTypes lists the event types of the store
+func (s *Store) TimelineTypes() []string {
+	return []string{types.TimelineActivity}
+}
+
+// TimelineEvents returns up to the query's limit of the user's activities on the
+// page, in the order they were started
+func (s *Store) TimelineEvents(userID int32, query types.TimelineQuery) ([]*types.TimelineEvent, error) {
+	start, afterID := query.Bound(types.TimelineActivity)
+
+	rows, err := s.db.GetActivitiesForTimeline(context.Background(), database.GetActivitiesForTimelineParams{
+		UserID:      userID,
+		StartedAt:   start.UTC(),
+		StartedAt_2: start.UTC(),
+		ActivityID:  afterID,
+		StartedAt_3: query.End.UTC(),
+		Limit:       int32(query.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*types.TimelineEvent, 0, len(rows))
+	for _, row := range rows {
+		activity := toActivity(row)
+		events = append(events, &types.TimelineEvent{
+			Type:       types.TimelineActivity,
+			ID:         activity.ID,
+			OccurredAt: activity.StartedAt,
+			Data:       activity,
+		})
+	}
+
+	return events, nil
+}
@@ -0,0 +1,149 @@
+package alert
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+var thresholds = types.GlucoseThresholds{VeryLow: 54, Low: 70, High: 180, VeryHigh: 250}
+
+func reading(value float64, at time.Time) *types.GlucoseReading {
+	return &types.GlucoseReading{Value: value, Unit: types.GlucoseUnitMgdL, MeasuredAt: at}
+}
+
+func enabled(ruleTypes ...string) []*types.AlertRule {
+	rules := make([]*types.AlertRule, 0, len(ruleTypes))
+	for _, ruleType := range ruleTypes {
+		rule := DefaultRule(1, ruleType)
+		rule.IsEnabled = true
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func ruleTypes(triggers []*Trigger) []string {
+	got := make([]string, 0, len(triggers))
+	for _, trigger := range triggers {
+		got = append(got, trigger.Rule.RuleType)
+	}
+	return got
+}
+
+func TestRate(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	previous := []*types.GlucoseReading{
+		reading(150, now.Add(-20*time.Minute)),
+		reading(130, now.Add(-10*time.Minute)),
+		reading(121, now.Add(-2*time.Minute)),
+	}
+	rate, ok := Rate(reading(100, now), previous)
+	if !ok || math.Abs(rate-(-3)) > 1e-9 {
+		t.Errorf("Rate() = %v, %v, want -3, true", rate, ok)
+	}
+
+	if _, ok := Rate(reading(100, now), []*types.GlucoseReading{reading(150, now.Add(-45*time.Minute))}); ok {
+		t.Error("Rate() should ignore readings outside the rate window")
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	rules := enabled(types.AlertUrgentLow, types.AlertLow, types.AlertHigh, types.AlertRapidFall, types.AlertRapidRise)
+
+	got := ruleTypes(Evaluate(rules, reading(50, now), nil, thresholds, types.GlucoseUnitMgdL))
+	if len(got) != 1 || got[0] != types.AlertUrgentLow {
+		t.Errorf("Evaluate(50) = %v, want only urgent_low", got)
+	}
+
+	got = ruleTypes(Evaluate(rules, reading(65, now), []*types.GlucoseReading{reading(95, now.Add(-10*time.Minute))}, thresholds, types.GlucoseUnitMgdL))
+	if len(got) != 2 || got[0] != types.AlertLow || got[1] != types.AlertRapidFall {
+		t.Errorf("Evaluate(65 falling) = %v, want [low rapid_fall]", got)
+	}
+
+	if got := Evaluate(rules, reading(120, now), nil, thresholds, types.GlucoseUnitMgdL); len(got) != 0 {
+		t.Errorf("Evaluate(120) = %v, want no triggers", ruleTypes(got))
+	}
+
+	// Disabled rules never trigger
+	disabled := []*types.AlertRule{DefaultRule(1, types.AlertHigh)}
+	if got := Evaluate(disabled, reading(300, now), nil, thresholds, types.GlucoseUnitMgdL); len(got) != 0 {
+		t.Errorf("Evaluate() with disabled rule = %v, want no triggers", ruleTypes(got))
+	}
+
+	// A custom threshold replaces the profile threshold
+	custom := enabled(types.AlertHigh)
+	threshold := 250.0
+	custom[0].Threshold = &threshold
+	if got := Evaluate(custom, reading(200, now), nil, thresholds, types.GlucoseUnitMgdL); len(got) != 0 {
+		t.Errorf("Evaluate(200) with threshold 250 = %v, want no triggers", ruleTypes(got))
+	}
+
+	mmol := &types.GlucoseReading{Value: 3.5, Unit: types.GlucoseUnitMmolL, MeasuredAt: now}
+	triggers := Evaluate(enabled(types.AlertLow), mmol, nil, thresholds, types.GlucoseUnitMmolL)
+	if len(triggers) != 1 || triggers[0].Message != "Low glucose: 3.5 mmol/L" {
+		t.Errorf("Evaluate(3.5 mmol/L) = %v, want a low alert in mmol/L", triggers)
+	}
+}
+
+func TestSuppressed(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	rule := enabled(types.AlertHigh)[0]
+
+	if Suppressed(rule, nil, now) {
+		t.Error("Suppressed() with no previous alert should be false")
+	}
+	if !Suppressed(rule, &types.Alert{TriggeredAt: now.Add(-10 * time.Minute)}, now) {
+		t.Error("Suppressed() within the cooldown should be true")
+	}
+	if Suppressed(rule, &types.Alert{TriggeredAt: now.Add(-40 * time.Minute)}, now) {
+		t.Error("Suppressed() after the cooldown should be false")
+	}
+
+	until := now.Add(time.Hour)
+	rule.SnoozedUntil = &until
+	if !Suppressed(rule, nil, now) {
+		t.Error("Suppressed() while snoozed should be true")
+	}
+}
+
+func TestCheckMissingData(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	rule := enabled(types.AlertMissingData)[0]
+	last := now.Add(-4 * time.Hour)
+
+	trigger := CheckMissingData(rule, &last, nil, now)
+	if trigger == nil || trigger.Message != "No glucose readings for 4 hours" {
+		t.Fatalf("CheckMissingData() = %v, want a trigger after 4 hours", trigger)
+	}
+
+	recent := now.Add(-time.Hour)
+	if CheckMissingData(rule, &recent, nil, now) != nil {
+		t.Error("CheckMissingData() should not trigger within the threshold")
+	}
+
+	// Only one alert per gap
+	if CheckMissingData(rule, &last, &types.Alert{TriggeredAt: now.Add(-time.Hour)}, now) != nil {
+		t.Error("CheckMissingData() should not alert twice for the same gap")
+	}
+}
+
+func TestWithDefaults(t *testing.T) {
+	configured := enabled(types.AlertHigh)
+	rules := WithDefaults(1, configured)
+
+	if len(rules) != len(types.AlertRuleTypes) {
+		t.Fatalf("WithDefaults() returned %d rules, want %d", len(rules), len(types.AlertRuleTypes))
+	}
+	for i, rule := range rules {
+		if rule.RuleType != types.AlertRuleTypes[i] {
+			t.Errorf("rule %d = %q, want %q", i, rule.RuleType, types.AlertRuleTypes[i])
+		}
+		if rule.IsEnabled != (rule.RuleType == types.AlertHigh) {
+			t.Errorf("rule %q enabled = %v", rule.RuleType, rule.IsEnabled)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package alert
+
+import (
+	"time"
+
+	"github.com/jayden1905/abundance/service/email"
+	"github.com/jayden1905/abundance/types"
+)
+
+// timeFormat is how alert times are shown in emails
+const timeFormat = "Mon 2 Jan 2006 15:04 MST"
+
+// EmailChannel delivers alerts to the user's account email address
+type EmailChannel struct {
+	mailer email.AlertMailer
+}
+
+func NewEmailChannel(mailer email.AlertMailer) *EmailChannel {
+	return &EmailChannel{mailer: mailer}
+}
+
+func (c *EmailChannel) Name() string {
+	return "email"
+}
+
+// Send emails the alert to the user
+func (c *EmailChannel) Send(user *types.User, alert *types.Alert, loc *time.Location) error {
+	return c.mailer.SendGlucoseAlertEmail(user.Email, alert.Message, alert.TriggeredAt.In(loc).Format(timeFormat))
+}
+
+// SendToCaregiver emails the owner's alert to a caregiver
+func (c *EmailChannel) SendToCaregiver(caregiver *types.User, owner *types.User, alert *types.Alert, loc *time.Location) error {
+	return c.mailer.SendCaregiverAlertEmail(caregiver.Email, owner.Username, alert.Message, alert.TriggeredAt.In(loc).Format(timeFormat))
+}
@@ -0,0 +1,257 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jayden1905/abundance/service/stats"
+	"github.com/jayden1905/abundance/types"
+)
+
+// Readings measured longer ago than this are backfilled data and do not raise alerts
+const maxReadingAge = time.Hour
+
+// Engine checks readings against the alert rules, records the alerts in the history
+// and delivers them through the registered channels
+type Engine struct {
+	store        types.AlertStore
+	glucoseStore types.GlucoseStore
+	profileStore types.ProfileStore
+	userStore    types.UserStore
+	shareStore   types.ShareStore
+	channels     map[string]types.AlertChannel
+}
+
+func NewEngine(store types.AlertStore, glucoseStore types.GlucoseStore, profileStore types.ProfileStore, userStore types.UserStore, shareStore types.ShareStore, channels ...types.AlertChannel) *Engine {
+	e := &Engine{
+		store:        store,
+		glucoseStore: glucoseStore,
+		profileStore: profileStore,
+		userStore:    userStore,
+		shareStore:   shareStore,
+		channels:     make(map[string]types.AlertChannel),
+	}
+	for _, channel := range channels {
+		e.channels[channel.Name()] = channel
+	}
+	return e
+}
+
+// Channels lists the names of the registered channels
+func (e *Engine) Channels() []string {
+	names := make([]string, 0, len(e.channels))
+	for name := range e.channels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasChannel reports whether a channel is registered under the name
+func (e *Engine) HasChannel(name string) bool {
+	_, ok := e.channels[name]
+	return ok
+}
+
+// CheckReading evaluates a new reading in the background so that delivery does not
+// hold up the request that recorded it
+func (e *Engine) CheckReading(reading *types.GlucoseReading) {
+	go func() {
+		if err := e.checkReading(reading, time.Now()); err != nil {
+			log.Printf("error checking glucose alerts for user %d: %v", reading.UserID, err)
+		}
+	}()
+}
+
+func (e *Engine) checkReading(reading *types.GlucoseReading, now time.Time) error {
+	if now.Sub(reading.MeasuredAt) > maxReadingAge {
+		return nil
+	}
+
+	rules, err := e.store.GetAlertRules(reading.UserID)
+	if err != nil {
+		return err
+	}
+	if !anyEnabled(rules) {
+		return nil
+	}
+
+	profile, err := e.profileStore.GetProfileByUserID(reading.UserID)
+	if err != nil {
+		return err
+	}
+
+	previous, err := e.glucoseStore.GetGlucoseReadingsByTimeRange(reading.UserID, reading.MeasuredAt.Add(-maxRateGap), reading.MeasuredAt)
+	if err != nil {
+		return err
+	}
+
+	triggers := Evaluate(rules, reading, previous, stats.ThresholdsFromProfile(profile), profile.GlucoseUnit)
+	for _, trigger := range triggers {
+		last, err := e.store.GetLatestAlert(reading.UserID, trigger.Rule.RuleType)
+		if err != nil {
+			return err
+		}
+		if Suppressed(trigger.Rule, last, now) {
+			continue
+		}
+
+		readingID := reading.ID
+		alert := &types.Alert{
+			UserID:            reading.UserID,
+			RuleType:          trigger.Rule.RuleType,
+			GlucoseReadingID:  &readingID,
+			ValueMgdL:         trigger.ValueMgdL,
+			RateMgdLPerMinute: trigger.RateMgdLPerMinute,
+			Message:           trigger.Message,
+			TriggeredAt:       now,
+		}
+		if err := e.fire(trigger.Rule, alert, profile.Location()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CheckMissingData raises an alert for every user whose readings stopped for longer
+// than their missing data rule allows
+func (e *Engine) CheckMissingData(now time.Time) error {
+	rules, err := e.store.GetEnabledAlertRules(types.AlertMissingData)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		if err := e.checkMissingData(rule, now); err != nil {
+			log.Printf("error checking missing data alert for user %d: %v", rule.UserID, err)
+		}
+	}
+
+	return nil
+}
+
+func (e *Engine) checkMissingData(rule *types.AlertRule, now time.Time) error {
+	lastReading, err := e.store.GetLatestReadingTime(rule.UserID)
+	if err != nil {
+		return err
+	}
+
+	last, err := e.store.GetLatestAlert(rule.UserID, rule.RuleType)
+	if err != nil {
+		return err
+	}
+
+	trigger := CheckMissingData(rule, lastReading, last, now)
+	if trigger == nil || Suppressed(rule, last, now) {
+		return nil
+	}
+
+	profile, err := e.profileStore.GetProfileByUserID(rule.UserID)
+	if err != nil {
+		return err
+	}
+
+	return e.fire(rule, &types.Alert{
+		UserID:      rule.UserID,
+		RuleType:    rule.RuleType,
+		Message:     trigger.Message,
+		TriggeredAt: now,
+	}, profile.Location())
+}
+
+// StartMonitor checks for missing data in the background at every interval
+func (e *Engine) StartMonitor(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := e.CheckMissingData(time.Now()); err != nil {
+				log.Printf("error checking missing data alerts: %v", err)
+			}
+		}
+	}()
+}
+
+// fire records the alert and delivers it through the rule's channels. Delivery
+// failures are recorded on the alert rather than returned.
+func (e *Engine) fire(rule *types.AlertRule, alert *types.Alert, loc *time.Location) error {
+	ctx := context.Background()
+
+	id, err := e.store.CreateAlert(ctx, alert)
+	if err != nil {
+		return err
+	}
+	alert.ID = id
+
+	user, err := e.userStore.GetUserByID(alert.UserID)
+	if err != nil {
+		return err
+	}
+
+	delivered := make([]string, 0, len(rule.Channels))
+	var failures []string
+	for _, name := range rule.Channels {
+		channel, ok := e.channels[name]
+		if !ok {
+			failures = append(failures, fmt.Sprintf("%s: channel not available", name))
+			continue
+		}
+		if err := channel.Send(user, alert, loc); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		delivered = append(delivered, name)
+	}
+
+	failures = append(failures, e.notifyCaregivers(rule, user, alert, loc)...)
+
+	return e.store.UpdateAlertDelivery(ctx, id, delivered, strings.Join(failures, "; "))
+}
+
+// notifyCaregivers forwards the alert through the rule's channels to the caregivers the
+// owner shares alerts with, returning the delivery failures
+func (e *Engine) notifyCaregivers(rule *types.AlertRule, owner *types.User, alert *types.Alert, loc *time.Location) []string {
+	shares, err := e.shareStore.GetSharesByOwnerID(owner.ID)
+	if err != nil {
+		return []string{fmt.Sprintf("caregivers: %v", err)}
+	}
+
+	var failures []string
+	for _, share := range shares {
+		if !share.ReceivesAlerts() {
+			continue
+		}
+
+		caregiver, err := e.userStore.GetUserByID(*share.CaregiverID)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("caregiver %d: %v", *share.CaregiverID, err))
+			continue
+		}
+
+		for _, name := range rule.Channels {
+			channel, ok := e.channels[name].(types.CaregiverAlertChannel)
+			if !ok {
+				continue
+			}
+			if err := channel.SendToCaregiver(caregiver, owner, alert, loc); err != nil {
+				failures = append(failures, fmt.Sprintf("caregiver %d %s: %v", caregiver.ID, name, err))
+			}
+		}
+	}
+	return failures
+}
+
+func anyEnabled(rules []*types.AlertRule) bool {
+	for _, rule := range rules {
+		if rule.IsEnabled {
+			return true
+		}
+	}
+	return false
+}
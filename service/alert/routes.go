@@ -0,0 +1,234 @@
+package alert
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/service/stats"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+type Handler struct {
+	store        types.AlertStore
+	engine       *Engine
+	profileStore types.ProfileStore
+	userStore    types.UserStore
+}
+
+func NewHandler(store types.AlertStore, engine *Engine, profileStore types.ProfileStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, engine: engine, profileStore: profileStore, userStore: userStore}
+}
+
+// RegisterRoutes for Fiber
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/alerts", auth.WithSharedAccess(h.handleGetAlerts, h.userStore, types.ShareScopeAlerts))
+	router.Post("/alerts/:id/acknowledge", auth.WithJWTAuth(h.handleAcknowledgeAlert, h.userStore))
+	router.Get("/alerts/rules", auth.WithSharedAccess(h.handleGetRules, h.userStore, types.ShareScopeAlerts))
+	router.Put("/alerts/rules/:type", auth.WithJWTAuth(h.handleUpdateRule, h.userStore))
+	router.Post("/alerts/rules/:type/snooze", auth.WithJWTAuth(h.handleSnoozeRule, h.userStore))
+	router.Delete("/alerts/rules/:type/snooze", auth.WithJWTAuth(h.handleUnsnoozeRule, h.userStore))
+}
+
+// Handler for the user's alert history in a time range
+func (h *Handler) handleGetAlerts(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Default to the last seven days when no range is given
+	from, to, err := utils.ParseTimeRange(c.Query("from"), c.Query("to"), 7*24*time.Hour)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	alerts, err := h.store.GetAlertsByTimeRange(userID, from, to)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting alerts: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"from":   from,
+		"to":     to,
+		"alerts": alerts,
+	})
+}
+
+// Handler for marking an alert as seen
+func (h *Handler) handleAcknowledgeAlert(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+
+	acknowledged, err := h.store.AcknowledgeAlert(c.Context(), int32(intID), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error acknowledging alert: %v", err)})
+	}
+	if !acknowledged {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Alert not found or already acknowledged"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Alert acknowledged successfully"})
+}
+
+// Handler for every alert rule of the user, including the ones they have not configured
+func (h *Handler) handleGetRules(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	rules, err := h.store.GetAlertRules(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting alert rules: %v", err)})
+	}
+
+	profile, err := h.profileStore.GetProfileByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting profile: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"rules":    Present(WithDefaults(userID, rules), stats.ThresholdsFromProfile(profile), profile.GlucoseUnit),
+		"channels": h.engine.Channels(),
+	})
+}
+
+// Handler for configuring one of the user's alert rules
+func (h *Handler) handleUpdateRule(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	ruleType := c.Params("type")
+	if !IsValidRuleType(ruleType) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("Unknown alert rule type %q", ruleType)})
+	}
+
+	// Parse JSON payload
+	var payload types.UpdateAlertRulePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	profile, err := h.profileStore.GetProfileByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting profile: %v", err)})
+	}
+
+	rule := DefaultRule(userID, ruleType)
+	rule.IsEnabled = *payload.IsEnabled
+
+	if payload.Threshold != nil {
+		threshold := FromDisplayThreshold(ruleType, *payload.Threshold, profile.GlucoseUnit)
+		if err := ValidateThreshold(ruleType, threshold); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		rule.Threshold = &threshold
+	}
+
+	if payload.CooldownMinutes != nil {
+		rule.CooldownMinutes = *payload.CooldownMinutes
+	}
+
+	if payload.Channels != nil {
+		for _, name := range payload.Channels {
+			if !h.engine.HasChannel(name) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Unknown alert channel %q", name)})
+			}
+		}
+		rule.Channels = payload.Channels
+	}
+
+	if err := h.store.UpsertAlertRule(c.Context(), rule); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error updating alert rule: %v", err)})
+	}
+
+	updated, err := h.getRule(userID, ruleType)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting alert rule: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(Present([]*types.AlertRule{updated}, stats.ThresholdsFromProfile(profile), profile.GlucoseUnit)[0])
+}
+
+// Handler for silencing a rule for a number of minutes
+func (h *Handler) handleSnoozeRule(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	ruleType := c.Params("type")
+	if !IsValidRuleType(ruleType) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("Unknown alert rule type %q", ruleType)})
+	}
+
+	// Parse JSON payload
+	var payload types.SnoozeAlertRulePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	until := time.Now().Add(time.Duration(payload.Minutes) * time.Minute)
+	found, err := h.store.SnoozeAlertRule(c.Context(), userID, ruleType, &until)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error snoozing alert rule: %v", err)})
+	}
+	if !found {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Alert rule is not configured"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"rule_type": ruleType, "snoozed_until": until})
+}
+
+// Handler for ending a snooze early
+func (h *Handler) handleUnsnoozeRule(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	ruleType := c.Params("type")
+	if !IsValidRuleType(ruleType) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("Unknown alert rule type %q", ruleType)})
+	}
+
+	found, err := h.store.SnoozeAlertRule(c.Context(), userID, ruleType, nil)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error ending snooze: %v", err)})
+	}
+	if !found {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Alert rule is not configured"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Snooze ended successfully"})
+}
+
+// getRule fetches the user's rule of a type, or the default if they have not configured it
+func (h *Handler) getRule(userID int32, ruleType string) (*types.AlertRule, error) {
+	rules, err := h.store.GetAlertRules(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range WithDefaults(userID, rules) {
+		if rule.RuleType == ruleType {
+			return rule, nil
+		}
+	}
+	return DefaultRule(userID, ruleType), nil
+}
@@ -0,0 +1,308 @@
+package alert
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jayden1905/abundance/service/glucose"
+	"github.com/jayden1905/abundance/types"
+)
+
+// Built-in defaults for rules the user has not given a threshold
+const (
+	DefaultRateMgdLPerMinute = 2.0
+	DefaultMissingDataHours  = 3.0
+	DefaultCooldownMinutes   = 30
+	DefaultChannel           = "email"
+)
+
+// A rate is measured against the latest earlier reading between these gaps, so that
+// duplicate readings and long gaps do not produce misleading rates
+const (
+	minRateGap = 4 * time.Minute
+	maxRateGap = 30 * time.Minute
+)
+
+// IsValidRuleType reports whether ruleType is one of types.AlertRuleTypes
+func IsValidRuleType(ruleType string) bool {
+	for _, t := range types.AlertRuleTypes {
+		if t == ruleType {
+			return true
+		}
+	}
+	return false
+}
+
+// IsLevelRule reports whether the rule compares a glucose value
+func IsLevelRule(ruleType string) bool {
+	return ruleType == types.AlertLow || ruleType == types.AlertUrgentLow || ruleType == types.AlertHigh
+}
+
+// IsRateRule reports whether the rule compares a rate of change
+func IsRateRule(ruleType string) bool {
+	return ruleType == types.AlertRapidFall || ruleType == types.AlertRapidRise
+}
+
+// DefaultRule is the disabled rule reported until the user configures one
+func DefaultRule(userID int32, ruleType string) *types.AlertRule {
+	return &types.AlertRule{
+		UserID:          userID,
+		RuleType:        ruleType,
+		CooldownMinutes: DefaultCooldownMinutes,
+		Channels:        []string{DefaultChannel},
+	}
+}
+
+// WithDefaults returns a rule for every rule type, in the order of types.AlertRuleTypes,
+// using the defaults for the types the user has not configured
+func WithDefaults(userID int32, rules []*types.AlertRule) []*types.AlertRule {
+	byType := make(map[string]*types.AlertRule, len(rules))
+	for _, rule := range rules {
+		byType[rule.RuleType] = rule
+	}
+
+	all := make([]*types.AlertRule, 0, len(types.AlertRuleTypes))
+	for _, ruleType := range types.AlertRuleTypes {
+		if rule, ok := byType[ruleType]; ok {
+			all = append(all, rule)
+		} else {
+			all = append(all, DefaultRule(userID, ruleType))
+		}
+	}
+	return all
+}
+
+// EffectiveThreshold returns the rule's threshold, or the profile threshold or built-in
+// default it follows, in mg/dL, mg/dL per minute or hours
+func EffectiveThreshold(rule *types.AlertRule, thresholds types.GlucoseThresholds) float64 {
+	if rule.Threshold != nil {
+		return *rule.Threshold
+	}
+
+	switch rule.RuleType {
+	case types.AlertUrgentLow:
+		return thresholds.VeryLow
+	case types.AlertLow:
+		return thresholds.Low
+	case types.AlertHigh:
+		return thresholds.High
+	case types.AlertRapidFall, types.AlertRapidRise:
+		return DefaultRateMgdLPerMinute
+	default:
+		return DefaultMissingDataHours
+	}
+}
+
+// ThresholdUnit returns the unit thresholds of the rule type are shown in
+func ThresholdUnit(ruleType string, unit string) string {
+	switch {
+	case IsLevelRule(ruleType):
+		return unit
+	case IsRateRule(ruleType):
+		return unit + "/min"
+	default:
+		return "hours"
+	}
+}
+
+// ToDisplayThreshold converts a stored threshold to the user's glucose unit
+func ToDisplayThreshold(ruleType string, threshold float64, unit string) float64 {
+	if IsLevelRule(ruleType) || IsRateRule(ruleType) {
+		return round(glucose.FromMgdL(threshold, unit))
+	}
+	return threshold
+}
+
+// FromDisplayThreshold converts a threshold in the user's glucose unit to the stored unit
+func FromDisplayThreshold(ruleType string, threshold float64, unit string) float64 {
+	if IsLevelRule(ruleType) || IsRateRule(ruleType) {
+		return glucose.ToMgdL(threshold, unit)
+	}
+	return threshold
+}
+
+// Present returns copies of the rules with their thresholds converted to the user's unit
+func Present(rules []*types.AlertRule, thresholds types.GlucoseThresholds, unit string) []*types.AlertRule {
+	presented := make([]*types.AlertRule, 0, len(rules))
+	for _, rule := range rules {
+		p := *rule
+		if rule.Threshold != nil {
+			threshold := ToDisplayThreshold(rule.RuleType, *rule.Threshold, unit)
+			p.Threshold = &threshold
+		}
+		p.EffectiveThreshold = ToDisplayThreshold(rule.RuleType, EffectiveThreshold(rule, thresholds), unit)
+		p.ThresholdUnit = ThresholdUnit(rule.RuleType, unit)
+		presented = append(presented, &p)
+	}
+	return presented
+}
+
+// ValidateThreshold checks a threshold in the stored unit is sensible for the rule type
+func ValidateThreshold(ruleType string, threshold float64) error {
+	switch {
+	case IsLevelRule(ruleType):
+		if threshold < 40 || threshold > 400 {
+			return fmt.Errorf("threshold must be between 40 and 400 mg/dL")
+		}
+	case IsRateRule(ruleType):
+		if threshold < 0.5 || threshold > 10 {
+			return fmt.Errorf("threshold must be between 0.5 and 10 mg/dL per minute")
+		}
+	default:
+		if threshold < 0.5 || threshold > 24 {
+			return fmt.Errorf("threshold must be between 0.5 and 24 hours")
+		}
+	}
+	return nil
+}
+
+// Trigger is a rule the reading broke, before de-duplication
+type Trigger struct {
+	Rule              *types.AlertRule
+	ValueMgdL         *float64
+	RateMgdLPerMinute *float64
+	Message           string
+}
+
+// Rate returns the rate of change in mg/dL per minute from the latest earlier
+// reading within the rate window. previous must be sorted by time.
+func Rate(reading *types.GlucoseReading, previous []*types.GlucoseReading) (float64, bool) {
+	for i := len(previous) - 1; i >= 0; i-- {
+		gap := reading.MeasuredAt.Sub(previous[i].MeasuredAt)
+		if gap < minRateGap {
+			continue
+		}
+		if gap > maxRateGap {
+			break
+		}
+		change := glucose.ToMgdL(reading.Value, reading.Unit) - glucose.ToMgdL(previous[i].Value, previous[i].Unit)
+		return change / gap.Minutes(), true
+	}
+	return 0, false
+}
+
+// Evaluate returns the enabled level and rate rules the reading breaks. An urgent
+// low replaces the low alert for the same reading. Messages use the user's unit.
+func Evaluate(rules []*types.AlertRule, reading *types.GlucoseReading, previous []*types.GlucoseReading, thresholds types.GlucoseThresholds, unit string) []*Trigger {
+	value := glucose.ToMgdL(reading.Value, reading.Unit)
+	rate, hasRate := Rate(reading, previous)
+
+	broken := make(map[string]*types.AlertRule)
+	for _, rule := range rules {
+		if !rule.IsEnabled {
+			continue
+		}
+
+		threshold := EffectiveThreshold(rule, thresholds)
+		switch rule.RuleType {
+		case types.AlertUrgentLow, types.AlertLow:
+			if value < threshold {
+				broken[rule.RuleType] = rule
+			}
+		case types.AlertHigh:
+			if value > threshold {
+				broken[rule.RuleType] = rule
+			}
+		case types.AlertRapidFall:
+			if hasRate && rate <= -threshold {
+				broken[rule.RuleType] = rule
+			}
+		case types.AlertRapidRise:
+			if hasRate && rate >= threshold {
+				broken[rule.RuleType] = rule
+			}
+		}
+	}
+	if broken[types.AlertUrgentLow] != nil {
+		delete(broken, types.AlertLow)
+	}
+
+	triggers := make([]*Trigger, 0, len(broken))
+	for _, ruleType := range types.AlertRuleTypes {
+		rule, ok := broken[ruleType]
+		if !ok {
+			continue
+		}
+
+		trigger := &Trigger{Rule: rule, ValueMgdL: &value}
+		if IsRateRule(ruleType) {
+			r := round(rate)
+			trigger.RateMgdLPerMinute = &r
+		}
+		trigger.Message = message(ruleType, value, rate, unit)
+		triggers = append(triggers, trigger)
+	}
+
+	return triggers
+}
+
+// CheckMissingData returns a trigger when the rule is enabled and no reading has been
+// recorded for longer than its threshold. Only one alert is raised per gap.
+func CheckMissingData(rule *types.AlertRule, lastReading *time.Time, lastAlert *types.Alert, now time.Time) *Trigger {
+	if !rule.IsEnabled || lastReading == nil {
+		return nil
+	}
+
+	hours := EffectiveThreshold(rule, types.GlucoseThresholds{})
+	if now.Sub(*lastReading) < time.Duration(hours*float64(time.Hour)) {
+		return nil
+	}
+	if lastAlert != nil && lastAlert.TriggeredAt.After(*lastReading) {
+		return nil
+	}
+
+	return &Trigger{
+		Rule:    rule,
+		Message: fmt.Sprintf("No glucose readings for %s", formatHours(now.Sub(*lastReading))),
+	}
+}
+
+// Suppressed reports whether a broken rule should stay quiet because it is snoozed
+// or already alerted within its cooldown
+func Suppressed(rule *types.AlertRule, lastAlert *types.Alert, now time.Time) bool {
+	if rule.SnoozedUntil != nil && now.Before(*rule.SnoozedUntil) {
+		return true
+	}
+	if lastAlert != nil && now.Sub(lastAlert.TriggeredAt) < time.Duration(rule.CooldownMinutes)*time.Minute {
+		return true
+	}
+	return false
+}
+
+func message(ruleType string, valueMgdL float64, rateMgdL float64, unit string) string {
+	value := formatGlucose(valueMgdL, unit)
+	switch ruleType {
+	case types.AlertUrgentLow:
+		return fmt.Sprintf("Urgent low glucose: %s", value)
+	case types.AlertLow:
+		return fmt.Sprintf("Low glucose: %s", value)
+	case types.AlertHigh:
+		return fmt.Sprintf("High glucose: %s", value)
+	case types.AlertRapidFall:
+		return fmt.Sprintf("Glucose falling fast: %s/min, now %s", formatGlucose(-rateMgdL, unit), value)
+	default:
+		return fmt.Sprintf("Glucose rising fast: %s/min, now %s", formatGlucose(rateMgdL, unit), value)
+	}
+}
+
+// formatGlucose formats mg/dL as a whole number and mmol/L with one decimal place
+func formatGlucose(valueMgdL float64, unit string) string {
+	if unit == types.GlucoseUnitMmolL {
+		return fmt.Sprintf("%.1f %s", glucose.FromMgdL(valueMgdL, unit), unit)
+	}
+	return fmt.Sprintf("%.0f %s", valueMgdL, types.GlucoseUnitMgdL)
+}
+
+func formatHours(d time.Duration) string {
+	hours := int(d.Hours())
+	if hours == 1 {
+		return "1 hour"
+	}
+	return fmt.Sprintf("%d hours", hours)
+}
+
+// round rounds to one decimal place
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,260 @@
+package alert
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// GetAlertRules fetches the rules the user has configured
+func (s *Store) GetAlertRules(userID int32) ([]*types.AlertRule, error) {
+	rows, err := s.db.GetAlertRulesByUserID(context.Background(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]*types.AlertRule, 0, len(rows))
+	for _, row := range rows {
+		rules = append(rules, toAlertRule(row))
+	}
+
+	return rules, nil
+}
+
+// GetEnabledAlertRules fetches every user's enabled rules of a type
+func (s *Store) GetEnabledAlertRules(ruleType string) ([]*types.AlertRule, error) {
+	rows, err := s.db.GetEnabledAlertRulesByType(context.Background(), database.AlertRulesRuleType(ruleType))
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]*types.AlertRule, 0, len(rows))
+	for _, row := range rows {
+		rules = append(rules, toAlertRule(row))
+	}
+
+	return rules, nil
+}
+
+// UpsertAlertRule creates or replaces the user's rule of the rule's type. The snooze is kept.
+func (s *Store) UpsertAlertRule(ctx context.Context, rule *types.AlertRule) error {
+	return s.db.UpsertAlertRule(ctx, database.UpsertAlertRuleParams{
+		UserID:          rule.UserID,
+		RuleType:        database.AlertRulesRuleType(rule.RuleType),
+		IsEnabled:       rule.IsEnabled,
+		Threshold:       toNullFloat64(rule.Threshold),
+		CooldownMinutes: int32(rule.CooldownMinutes),
+		Channels:        strings.Join(rule.Channels, ","),
+	})
+}
+
+// SnoozeAlertRule silences a rule until the given time, or ends the snooze when it is nil.
+// It returns false if the user has not configured the rule.
+func (s *Store) SnoozeAlertRule(ctx context.Context, userID int32, ruleType string, until *time.Time) (bool, error) {
+	rules, err := s.GetAlertRules(userID)
+	if err != nil {
+		return false, err
+	}
+
+	found := false
+	for _, rule := range rules {
+		if rule.RuleType == ruleType {
+			found = true
+		}
+	}
+	if !found {
+		return false, nil
+	}
+
+	// MySQL reports unchanged rows as not affected, so existence is checked above
+	snoozedUntil := sql.NullTime{}
+	if until != nil {
+		snoozedUntil = sql.NullTime{Time: until.UTC(), Valid: true}
+	}
+	_, err = s.db.SnoozeAlertRule(ctx, database.SnoozeAlertRuleParams{
+		SnoozedUntil: snoozedUntil,
+		UserID:       userID,
+		RuleType:     database.AlertRulesRuleType(ruleType),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetLatestAlert fetches the user's most recent alert of a rule type, or nil if there is none
+func (s *Store) GetLatestAlert(userID int32, ruleType string) (*types.Alert, error) {
+	row, err := s.db.GetLatestAlertByRuleType(context.Background(), database.GetLatestAlertByRuleTypeParams{
+		UserID:   userID,
+		RuleType: database.AlertsRuleType(ruleType),
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return toAlert(row), nil
+}
+
+// GetAlertsByTimeRange fetches the user's alerts triggered in [start, end) ordered by time
+func (s *Store) GetAlertsByTimeRange(userID int32, start time.Time, end time.Time) ([]*types.Alert, error) {
+	rows, err := s.db.GetAlertsByTimeRange(context.Background(), database.GetAlertsByTimeRangeParams{
+		UserID:    userID,
+		StartTime: start.UTC(),
+		EndTime:   end.UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]*types.Alert, 0, len(rows))
+	for _, row := range rows {
+		alerts = append(alerts, toAlert(row))
+	}
+
+	return alerts, nil
+}
+
+// CreateAlert records a triggered alert and returns its ID
+func (s *Store) CreateAlert(ctx context.Context, alert *types.Alert) (int32, error) {
+	readingID := sql.NullInt32{}
+	if alert.GlucoseReadingID != nil {
+		readingID = sql.NullInt32{Int32: *alert.GlucoseReadingID, Valid: true}
+	}
+
+	id, err := s.db.CreateAlert(ctx, database.CreateAlertParams{
+		UserID:            alert.UserID,
+		RuleType:          database.AlertsRuleType(alert.RuleType),
+		GlucoseReadingID:  readingID,
+		ValueMgdl:         toNullFloat64(alert.ValueMgdL),
+		RateMgdlPerMinute: toNullFloat64(alert.RateMgdLPerMinute),
+		Message:           alert.Message,
+		TriggeredAt:       alert.TriggeredAt.UTC(),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(id), nil
+}
+
+// UpdateAlertDelivery records which channels delivered the alert and the last delivery error
+func (s *Store) UpdateAlertDelivery(ctx context.Context, id int32, channels []string, deliveryError string) error {
+	if len(deliveryError) > 255 {
+		deliveryError = deliveryError[:255]
+	}
+
+	return s.db.UpdateAlertDelivery(ctx, database.UpdateAlertDeliveryParams{
+		DeliveredChannels: strings.Join(channels, ","),
+		DeliveryError:     deliveryError,
+		AlertID:           id,
+	})
+}
+
+// AcknowledgeAlert marks an alert as seen. It returns false if the alert does not exist
+// or was already acknowledged.
+func (s *Store) AcknowledgeAlert(ctx context.Context, id int32, userID int32) (bool, error) {
+	rows, err := s.db.AcknowledgeAlert(ctx, database.AcknowledgeAlertParams{
+		AlertID: id,
+		UserID:  userID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+// GetLatestReadingTime fetches when the user's latest glucose reading was measured, or nil
+// if they have none
+func (s *Store) GetLatestReadingTime(userID int32) (*time.Time, error) {
+	measuredAt, err := s.db.GetLatestGlucoseReadingTime(context.Background(), userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &measuredAt, nil
+}
+
+func toAlertRule(row database.AlertRule) *types.AlertRule {
+	rule := &types.AlertRule{
+		ID:              row.AlertRuleID,
+		UserID:          row.UserID,
+		RuleType:        string(row.RuleType),
+		IsEnabled:       row.IsEnabled,
+		CooldownMinutes: int(row.CooldownMinutes),
+		Channels:        splitList(row.Channels),
+	}
+	if row.Threshold.Valid {
+		threshold := row.Threshold.Float64
+		rule.Threshold = &threshold
+	}
+	if row.SnoozedUntil.Valid {
+		until := row.SnoozedUntil.Time
+		rule.SnoozedUntil = &until
+	}
+	return rule
+}
+
+func toAlert(row database.Alert) *types.Alert {
+	alert := &types.Alert{
+		ID:                row.AlertID,
+		UserID:            row.UserID,
+		RuleType:          string(row.RuleType),
+		Message:           row.Message,
+		TriggeredAt:       row.TriggeredAt,
+		DeliveredChannels: splitList(row.DeliveredChannels),
+		DeliveryError:     row.DeliveryError,
+	}
+	if row.GlucoseReadingID.Valid {
+		id := row.GlucoseReadingID.Int32
+		alert.GlucoseReadingID = &id
+	}
+	if row.ValueMgdl.Valid {
+		value := row.ValueMgdl.Float64
+		alert.ValueMgdL = &value
+	}
+	if row.RateMgdlPerMinute.Valid {
+		rate := row.RateMgdlPerMinute.Float64
+		alert.RateMgdLPerMinute = &rate
+	}
+	if row.AcknowledgedAt.Valid {
+		acknowledged := row.AcknowledgedAt.Time
+		alert.AcknowledgedAt = &acknowledged
+	}
+	return alert
+}
+
+// splitList splits a comma-separated column, returning an empty list for an empty string
+func splitList(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(s, ",")
+}
+
+func toNullFloat64(f *float64) sql.NullFloat64 {
+	if f == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *f, Valid: true}
+}
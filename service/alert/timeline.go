@@ -0,0 +1,44 @@
+package alert
+
+import (
+	"context"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+)
+
+// TimelineTypes lists the event types of the store
+func (s *Store) TimelineTypes() []string {
+	return []string{types.TimelineGlucoseAlert}
+}
+
+// TimelineEvents returns up to the query's limit of the user's alerts on the
+// page, in the order they were triggered
+func (s *Store) TimelineEvents(userID int32, query types.TimelineQuery) ([]*types.TimelineEvent, error) {
+	start, afterID := query.Bound(types.TimelineGlucoseAlert)
+
+	rows, err := s.db.GetAlertsForTimeline(context.Background(), database.GetAlertsForTimelineParams{
+		UserID:        userID,
+		TriggeredAt:   start.UTC(),
+		TriggeredAt_2: start.UTC(),
+		AlertID:       afterID,
+		TriggeredAt_3: query.End.UTC(),
+		Limit:         int32(query.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*types.TimelineEvent, 0, len(rows))
+	for _, row := range rows {
+		alert := toAlert(row)
+		events = append(events, &types.TimelineEvent{
+			Type:       types.TimelineGlucoseAlert,
+			ID:         alert.ID,
+			OccurredAt: alert.TriggeredAt,
+			Data:       alert,
+		})
+	}
+
+	return events, nil
+}
@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/jayden1905/abundance/config"
+)
+
+// Token types. Every token is only accepted where its type is expected, so that for
+// example a verification token can never be used as an access token.
+const (
+	TokenTypeAccess       = "access"
+	TokenTypeVerification = "email_verification"
+	TokenTypeEmailChange  = "email_change"
+)
+
+// TokenLeeway allows for clock skew between the services that issue and verify tokens
+const TokenLeeway = 30 * time.Second
+
+// Claims are the claims of every token this service issues. The subject is the user
+// ID, except for verification tokens, whose subject is the email being verified.
+type Claims struct {
+	jwt.RegisteredClaims
+	Type  string `json:"type"`
+	Email string `json:"email,omitempty"`
+}
+
+// newClaims creates the registered claims of a token of the type, valid for ttl from now
+func newClaims(tokenType string, subject string, ttl time.Duration) *Claims {
+	now := time.Now()
+
+	return &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    config.Envs.JWTIssuer,
+			Subject:   subject,
+			Audience:  jwt.ClaimStrings{config.Envs.JWTAudience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+		Type: tokenType,
+	}
+}
+
+// UserID parses the user ID in the subject
+func (c *Claims) UserID() (int32, error) {
+	userID, err := strconv.ParseInt(c.Subject, 10, 32)
+	if err != nil || userID <= 0 {
+		return 0, fmt.Errorf("token has an invalid subject")
+	}
+	return int32(userID), nil
+}
+
+// Validate checks the type, issuer, audience and lifetime of the claims, allowing
+// TokenLeeway of clock skew
+func (c *Claims) Validate(tokenType string, now time.Time) error {
+	if c.Type != tokenType {
+		return fmt.Errorf("token is not a %s token", tokenType)
+	}
+	if c.Issuer != config.Envs.JWTIssuer {
+		return fmt.Errorf("token is from issuer %q", c.Issuer)
+	}
+	if !c.VerifyAudience(config.Envs.JWTAudience, true) {
+		return fmt.Errorf("token is not for this audience")
+	}
+	if c.Subject == "" {
+		return fmt.Errorf("token has no subject")
+	}
+	if c.ExpiresAt == nil || !now.Before(c.ExpiresAt.Add(TokenLeeway)) {
+		return fmt.Errorf("token has expired")
+	}
+	if c.IssuedAt == nil || now.Add(TokenLeeway).Before(c.IssuedAt.Time) {
+		return fmt.Errorf("token is issued in the future")
+	}
+	if c.NotBefore != nil && now.Add(TokenLeeway).Before(c.NotBefore.Time) {
+		return fmt.Errorf("token is not valid yet")
+	}
+	return nil
+}
+
+// parseToken verifies the token's signature and claims and returns the claims if it
+// is a valid token of the type
+func parseToken(tokenString string, tokenType string) (*Claims, error) {
+	// The registered claims are checked by Validate, with leeway
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{AlgRS256, AlgEdDSA}), jwt.WithoutClaimsValidation())
+
+	var claims Claims
+	if _, err := parser.ParseWithClaims(tokenString, &claims, verificationKey); err != nil {
+		return nil, fmt.Errorf("token is invalid: %v", err)
+	}
+
+	if err := claims.Validate(tokenType, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return &claims, nil
+}
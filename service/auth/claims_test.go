@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/jayden1905/abundance/config"
+)
+
+func TestClaimsValidate(t *testing.T) {
+	now := time.Now()
+
+	if err := newClaims(TokenTypeAccess, "1", time.Minute).Validate(TokenTypeAccess, now); err != nil {
+		t.Errorf("expected the claims to be valid: %v", err)
+	}
+
+	// Clock skew within the leeway is tolerated
+	if err := newClaims(TokenTypeAccess, "1", time.Minute).Validate(TokenTypeAccess, now.Add(time.Minute+TokenLeeway/2)); err != nil {
+		t.Errorf("expected expiry within the leeway to be accepted: %v", err)
+	}
+
+	invalid := map[string]func(c *Claims){
+		"expired":         func(c *Claims) { c.ExpiresAt = jwt.NewNumericDate(now.Add(-2 * TokenLeeway)) },
+		"no expiry":       func(c *Claims) { c.ExpiresAt = nil },
+		"issued later":    func(c *Claims) { c.IssuedAt = jwt.NewNumericDate(now.Add(2 * TokenLeeway)) },
+		"not yet valid":   func(c *Claims) { c.NotBefore = jwt.NewNumericDate(now.Add(2 * TokenLeeway)) },
+		"other issuer":    func(c *Claims) { c.Issuer = "https://evil.example.com" },
+		"other audience":  func(c *Claims) { c.Audience = jwt.ClaimStrings{"other-api"} },
+		"no subject":      func(c *Claims) { c.Subject = "" },
+		"other type":      func(c *Claims) { c.Type = TokenTypeVerification },
+		"missing type":    func(c *Claims) { c.Type = "" },
+		"no issue time":   func(c *Claims) { c.IssuedAt = nil },
+		"empty audience":  func(c *Claims) { c.Audience = nil },
+		"audience prefix": func(c *Claims) { c.Audience = jwt.ClaimStrings{config.Envs.JWTAudience + "-admin"} },
+	}
+	for name, modify := range invalid {
+		claims := newClaims(TokenTypeAccess, "1", time.Minute)
+		modify(claims)
+		if err := claims.Validate(TokenTypeAccess, now); err == nil {
+			t.Errorf("%s: expected the claims to be rejected", name)
+		}
+	}
+}
+
+func TestTokenTypes(t *testing.T) {
+	verification, err := GenerateVerificationToken("jane@example.com")
+	if err != nil {
+		t.Fatalf("error creating verification token: %v", err)
+	}
+	emailChange, err := GenerateEmailChangeToken(3, "new@example.com")
+	if err != nil {
+		t.Fatalf("error creating email change token: %v", err)
+	}
+	access, err := CreateJWT(3)
+	if err != nil {
+		t.Fatalf("error creating access token: %v", err)
+	}
+
+	if email, err := ValidateVerificationToken(verification); err != nil || email != "jane@example.com" {
+		t.Errorf("ValidateVerificationToken() = %q, %v", email, err)
+	}
+	if userID, email, err := ValidateEmailChangeToken(emailChange); err != nil || userID != 3 || email != "new@example.com" {
+		t.Errorf("ValidateEmailChangeToken() = %d, %q, %v", userID, email, err)
+	}
+
+	claims, err := ValidateAccessToken(access)
+	if err != nil {
+		t.Fatalf("expected the access token to be valid: %v", err)
+	}
+	if userID, err := claims.UserID(); err != nil || userID != 3 || claims.ID == "" {
+		t.Errorf("unexpected access token claims %+v", claims)
+	}
+
+	// No token is accepted in place of another type
+	for name, token := range map[string]string{"verification": verification, "email change": emailChange} {
+		if _, err := ValidateAccessToken(token); err == nil {
+			t.Errorf("expected the %s token to be rejected as an access token", name)
+		}
+	}
+	if _, err := ValidateVerificationToken(access); err == nil {
+		t.Error("expected the access token to be rejected as a verification token")
+	}
+	if _, _, err := ValidateEmailChangeToken(verification); err == nil {
+		t.Error("expected the verification token to be rejected as an email change token")
+	}
+}
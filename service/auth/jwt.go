@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -20,16 +21,23 @@ type contextKey string
 
 const UserKey contextKey = "userID"
 
-// CreateJWT generates a new JWT token with the given secret and userID.
-func CreateJWT(secret []byte, userID int) (string, error) {
+// CreateJWT generates a new access token for the userID, signed with the current key.
+func CreateJWT(userID int) (string, error) {
 	expiration := time.Second * time.Duration(config.Envs.JWTExpirationInSeconds)
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"userID":    strconv.Itoa(userID),
-		"expiredAt": time.Now().Add(expiration).Unix(),
-	})
+	// A unique token ID lets the token be revoked before it expires
+	jti, err := GenerateTokenID()
+	if err != nil {
+		return "", err
+	}
 
-	tokenString, err := token.SignedString(secret)
+	claims := newClaims(TokenTypeAccess, strconv.Itoa(userID), expiration)
+	claims.ID = jti
+
+	// A session started in the second the user's tokens were revoked must outlive the revocation
+	claims.IssuedAt = jwt.NewNumericDate(issuedAt(int32(userID), claims.IssuedAt.Time))
+
+	tokenString, err := signToken(claims)
 	if err != nil {
 		return "", err
 	}
@@ -37,21 +45,99 @@ func CreateJWT(secret []byte, userID int) (string, error) {
 	return tokenString, nil
 }
 
-// CreateVerificationToken generates a new JWT token with the given secret and userID.
+// GenerateVerificationToken generates a token that verifies the email address.
 func GenerateVerificationToken(email string) (string, error) {
-	claims := jwt.MapClaims{
-		"email": email,
-		"exp":   time.Now().Add(5 * time.Minute).Unix(), // Token expires in 5 minutes
+	claims := newClaims(TokenTypeVerification, email, 5*time.Minute) // Token expires in 5 minutes
+
+	return signToken(claims)
+}
+
+// GenerateEmailChangeToken generates a verification token for moving the user to a new email address.
+func GenerateEmailChangeToken(userID int32, newEmail string) (string, error) {
+	claims := newClaims(TokenTypeEmailChange, strconv.Itoa(int(userID)), 30*time.Minute) // Token expires in 30 minutes
+	claims.Email = newEmail
+
+	return signToken(claims)
+}
+
+// Purposes of MFA tokens. A login that still needs a second factor gets one of these
+// instead of a session.
+const (
+	MFAPurposeVerify = "mfa_pending"
+	MFAPurposeSetup  = "mfa_setup"
+)
+
+// MaxMFATokenAttempts is how many times one MFA token can be used. After that the user
+// has to log in with their password again.
+const MaxMFATokenAttempts = 5
+
+// mfaTokenAttempts counts the uses of each MFA token by its token ID until it expires
+var mfaTokenAttempts = struct {
+	sync.Mutex
+	counts  map[string]int
+	expires map[string]time.Time
+}{counts: make(map[string]int), expires: make(map[string]time.Time)}
+
+// CreateMFAToken generates a short-lived token that lets the user finish logging in.
+// Its type is the purpose, so the auth middleware never accepts it as an access token.
+func CreateMFAToken(userID int32, purpose string) (string, error) {
+	// A unique token ID lets the attempts made with the token be counted
+	jti, err := GenerateTokenID()
+	if err != nil {
+		return "", err
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	secret := []byte(config.Envs.JWTSecret)
+	claims := newClaims(purpose, strconv.Itoa(int(userID)), 5*time.Minute) // Token expires in 5 minutes
+	claims.ID = jti
 
-	return token.SignedString(secret)
+	return signToken(claims)
+}
+
+// ValidateMFAToken validates an MFA token of the given purpose and returns its user ID.
+// Every call counts as an attempt, and the token is refused after MaxMFATokenAttempts.
+func ValidateMFAToken(tokenString string, purpose string) (int32, error) {
+	claims, err := parseToken(tokenString, purpose)
+	if err != nil {
+		return 0, err
+	}
+
+	if claims.ID == "" {
+		return 0, fmt.Errorf("token has no id")
+	}
+	if !useMFAToken(claims.ID, claims.ExpiresAt.Time, time.Now()) {
+		return 0, fmt.Errorf("token has been used too many times")
+	}
+
+	return claims.UserID()
+}
+
+// useMFAToken counts an attempt with the token and reports whether it is still allowed.
+// Counts of expired tokens are dropped, as the tokens are refused anyway.
+func useMFAToken(jti string, expiresAt time.Time, now time.Time) bool {
+	mfaTokenAttempts.Lock()
+	defer mfaTokenAttempts.Unlock()
+
+	for id, expires := range mfaTokenAttempts.expires {
+		if now.After(expires.Add(TokenLeeway)) {
+			delete(mfaTokenAttempts.expires, id)
+			delete(mfaTokenAttempts.counts, id)
+		}
+	}
+
+	mfaTokenAttempts.counts[jti]++
+	mfaTokenAttempts.expires[jti] = expiresAt
+
+	return mfaTokenAttempts.counts[jti] <= MaxMFATokenAttempts
 }
 
 // WithJWTAuth is a middleware for Fiber that validates the JWT token.
 func WithJWTAuth(handlerFunc fiber.Handler, store types.UserStore) fiber.Handler {
+	return withJWTAuth(handlerFunc, store, false, nil)
+}
+
+// withJWTAuth validates the JWT token. Requests for another user's data with the user_id
+// query parameter are denied unless shared is set and a share covers the scopes.
+func withJWTAuth(handlerFunc fiber.Handler, store types.UserStore, shared bool, scopes []string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		// Get the token from cookies or Authorization header
 		tokenString, err := getTokenFromCookie(c)
@@ -64,25 +150,29 @@ func WithJWTAuth(handlerFunc fiber.Handler, store types.UserStore) fiber.Handler
 		}
 
 		// Validate the JWT token
-		token, err := ValidateToken(tokenString)
+		claims, err := ValidateAccessToken(tokenString)
 		if err != nil {
 			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 				"error": err.Error(),
 			})
 		}
 
-		// Extract the userID from JWT claims
-		claims := token.Claims.(jwt.MapClaims)
-		str := claims["userID"].(string)
+		// Reject tokens revoked by logout, password change or account deletion
+		if IsTokenRevoked(claims) {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "token has been revoked",
+			})
+		}
 
-		userID, err := strconv.ParseUint(str, 10, 32)
+		// Extract the userID from JWT claims
+		userID, err := claims.UserID()
 		if err != nil {
-			log.Printf("failed to convert userID to int: %v", err)
+			log.Printf("failed to get userID from token: %v", err)
 			return permissionDenied(c)
 		}
 
 		// Fetch the user from the database
-		u, err := store.GetUserByID(int32(userID))
+		u, err := store.GetUserByID(userID)
 		if err != nil {
 			log.Printf("error getting user by id: %v", err)
 			return permissionDenied(c)
@@ -91,6 +181,32 @@ func WithJWTAuth(handlerFunc fiber.Handler, store types.UserStore) fiber.Handler
 		// Set userID in context (using Fiber's Locals)
 		c.Locals(UserKey, u.ID)
 
+		// Caregivers read another user's data by passing their ID
+		if param := c.Query("user_id"); param != "" {
+			ownerID, err := strconv.ParseInt(param, 10, 32)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user_id"})
+			}
+
+			if int32(ownerID) != u.ID {
+				if !shared {
+					return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Access denied"})
+				}
+
+				share, err := sharedDataOwner(int32(ownerID), u.ID, scopes)
+				if err != nil {
+					log.Printf("error getting share: %v", err)
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Error checking shared access"})
+				}
+				if share == nil {
+					return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Access denied"})
+				}
+
+				c.Locals(UserKey, share.OwnerID)
+				c.Locals(ShareKey, share)
+			}
+		}
+
 		// Call the next handler
 		return handlerFunc(c)
 	}
@@ -110,10 +226,10 @@ func BlockIfAuthenticated(handlerFunc fiber.Handler) fiber.Handler {
 		}
 
 		// Validate the JWT token
-		token, err := ValidateToken(tokenString)
+		claims, err := ValidateAccessToken(tokenString)
 
-		// If the token is valid, block the request
-		if err == nil && token.Valid {
+		// If the token is valid and not revoked, block the request
+		if err == nil && !IsTokenRevoked(claims) {
 			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": "User is already authenticated"})
 		}
 
@@ -133,60 +249,47 @@ func getTokenFromCookie(c *fiber.Ctx) (string, error) {
 
 // Helper function to validate the verification token
 func ValidateVerificationToken(tokenString string) (string, error) {
-	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-		}
-		return []byte(config.Envs.JWTSecret), nil
-	})
-	// Check for any errors during parsing
+	claims, err := parseToken(tokenString, TokenTypeVerification)
 	if err != nil {
-		if ve, ok := err.(*jwt.ValidationError); ok {
-			// Check if the error was due to token expiration
-			if ve.Errors&jwt.ValidationErrorExpired != 0 {
-				return "", fmt.Errorf("token has expired")
-			} else {
-				return "", fmt.Errorf("token is invalid: %v", err)
-			}
-		}
-		return "", fmt.Errorf("error parsing token: %v", err)
+		return "", err
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return "", fmt.Errorf("error parsing claims")
+	return claims.Subject, nil
+}
+
+// Helper function to validate an email change token and return the user ID and new email
+func ValidateEmailChangeToken(tokenString string) (int32, string, error) {
+	claims, err := parseToken(tokenString, TokenTypeEmailChange)
+	if err != nil {
+		return 0, "", err
 	}
 
-	email, ok := claims["email"].(string)
-	if !ok {
-		return "", fmt.Errorf("error parsing email")
+	// Email change tokens must carry both the user and the new address
+	if claims.Email == "" {
+		return 0, "", fmt.Errorf("error parsing email")
+	}
+
+	userID, err := claims.UserID()
+	if err != nil {
+		return 0, "", err
 	}
 
-	return email, nil
+	return userID, claims.Email, nil
 }
 
-// Helper function to validate a JWT token
-func ValidateToken(tokenString string) (*jwt.Token, error) {
-	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-		}
-		return []byte(config.Envs.JWTSecret), nil
-	})
-	// Check for any errors during parsing
+// ValidateAccessToken validates an access token and returns its claims. Revocation is
+// checked separately with IsTokenRevoked.
+func ValidateAccessToken(tokenString string) (*Claims, error) {
+	claims, err := parseToken(tokenString, TokenTypeAccess)
 	if err != nil {
-		if ve, ok := err.(*jwt.ValidationError); ok {
-			// Check if the error was due to token expiration
-			if ve.Errors&jwt.ValidationErrorExpired != 0 {
-				return nil, fmt.Errorf("token has expired")
-			} else {
-				return nil, fmt.Errorf("token is invalid: %v", err)
-			}
-		}
-		return nil, fmt.Errorf("error parsing token: %v", err)
+		return nil, err
 	}
 
-	return token, nil
+	if claims.ID == "" {
+		return nil, fmt.Errorf("token has no id")
+	}
+
+	return claims, nil
 }
 
 // Helper function to send a permission denied response in Fiber
@@ -5,9 +5,7 @@ import (
 )
 
 func TestCreateJWT(t *testing.T) {
-	secret := []byte("secret")
-
-	token, err := CreateJWT(secret, 1)
+	token, err := CreateJWT(1)
 	if err != nil {
 		t.Errorf("error creating JWT: %v", err)
 	}
@@ -16,3 +14,46 @@ func TestCreateJWT(t *testing.T) {
 		t.Error("expected token to be not empty")
 	}
 }
+
+func TestMFAToken(t *testing.T) {
+	token, err := CreateMFAToken(7, MFAPurposeVerify)
+	if err != nil {
+		t.Fatalf("error creating MFA token: %v", err)
+	}
+
+	userID, err := ValidateMFAToken(token, MFAPurposeVerify)
+	if err != nil || userID != 7 {
+		t.Errorf("ValidateMFAToken() = %d, %v, want 7", userID, err)
+	}
+
+	if _, err := ValidateMFAToken(token, MFAPurposeSetup); err == nil {
+		t.Error("expected a token of another purpose to be rejected")
+	}
+
+	// MFA tokens must never pass as access tokens
+	if _, err := ValidateAccessToken(token); err == nil {
+		t.Error("expected the MFA token to be rejected as an access token")
+	}
+}
+
+func TestMFATokenAttempts(t *testing.T) {
+	token, err := CreateMFAToken(7, MFAPurposeVerify)
+	if err != nil {
+		t.Fatalf("error creating MFA token: %v", err)
+	}
+
+	for i := 0; i < MaxMFATokenAttempts; i++ {
+		if _, err := ValidateMFAToken(token, MFAPurposeVerify); err != nil {
+			t.Fatalf("attempt %d: unexpected error: %v", i+1, err)
+		}
+	}
+	if _, err := ValidateMFAToken(token, MFAPurposeVerify); err == nil {
+		t.Error("expected the token to be refused after too many attempts")
+	}
+
+	// Other tokens of the same user have their own attempts
+	other, _ := CreateMFAToken(7, MFAPurposeVerify)
+	if _, err := ValidateMFAToken(other, MFAPurposeVerify); err != nil {
+		t.Errorf("unexpected error for a new token: %v", err)
+	}
+}
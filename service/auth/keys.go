@@ -0,0 +1,390 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Algorithms tokens can be signed with
+const (
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+// KeyPublishAhead is how long a new key is published in the JWKS before it signs
+// tokens, so that services caching the key set know it by the time they see it
+const KeyPublishAhead = time.Hour
+
+// keyFilePrefix names key files key-<unix creation time>.pem
+const keyFilePrefix = "key-"
+
+// SigningKey is a private key tokens are signed with. Its ID is the RFC 7638
+// thumbprint of the public key.
+type SigningKey struct {
+	ID        string
+	Algorithm string
+	CreatedAt time.Time
+	private   crypto.Signer
+}
+
+// method returns the JWT signing method of the key's algorithm
+func (k *SigningKey) method() jwt.SigningMethod {
+	if k.Algorithm == AlgEdDSA {
+		return jwt.SigningMethodEdDSA
+	}
+	return jwt.SigningMethodRS256
+}
+
+// Public returns the key's public key
+func (k *SigningKey) Public() crypto.PublicKey {
+	return k.private.Public()
+}
+
+// newSigningKey wraps a parsed private key, deriving its algorithm and ID
+func newSigningKey(private interface{}, createdAt time.Time) (*SigningKey, error) {
+	key := &SigningKey{CreatedAt: createdAt}
+
+	var thumbprint string
+	switch private := private.(type) {
+	case *rsa.PrivateKey:
+		if private.N.BitLen() < 2048 {
+			return nil, fmt.Errorf("RSA key must have at least 2048 bits")
+		}
+		key.Algorithm = AlgRS256
+		key.private = private
+		thumbprint = fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`,
+			base64.RawURLEncoding.EncodeToString(big.NewInt(int64(private.E)).Bytes()),
+			base64.RawURLEncoding.EncodeToString(private.N.Bytes()))
+	case ed25519.PrivateKey:
+		key.Algorithm = AlgEdDSA
+		key.private = private
+		thumbprint = fmt.Sprintf(`{"crv":"Ed25519","kty":"OKP","x":"%s"}`,
+			base64.RawURLEncoding.EncodeToString(private.Public().(ed25519.PublicKey)))
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", private)
+	}
+
+	hash := sha256.Sum256([]byte(thumbprint))
+	key.ID = base64.RawURLEncoding.EncodeToString(hash[:])
+	return key, nil
+}
+
+// GenerateSigningKey creates a new RSA 2048 or Ed25519 key
+func GenerateSigningKey(algorithm string, createdAt time.Time) (*SigningKey, error) {
+	switch algorithm {
+	case AlgRS256:
+		private, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, err
+		}
+		return newSigningKey(private, createdAt)
+	case AlgEdDSA:
+		_, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		return newSigningKey(private, createdAt)
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+}
+
+// KeySet holds the keys in a directory of PEM encoded private keys. The newest key that
+// has been published for KeyPublishAhead signs tokens; all keys verify them.
+type KeySet struct {
+	dir         string
+	algorithm   string
+	rotateEvery time.Duration
+	retireAfter time.Duration
+
+	mu   sync.RWMutex
+	keys []*SigningKey
+}
+
+// NewKeySet creates a key set for the directory. New keys use the algorithm and are
+// created every rotateEvery. A replaced key is deleted once every token it signed has
+// expired, which is after retireAfter.
+func NewKeySet(dir string, algorithm string, rotateEvery time.Duration, retireAfter time.Duration) *KeySet {
+	return &KeySet{dir: dir, algorithm: algorithm, rotateEvery: rotateEvery, retireAfter: retireAfter}
+}
+
+// Load reads the keys in the directory. Files other than key-<unix time>.pem are ignored.
+func (ks *KeySet) Load() error {
+	entries, err := os.ReadDir(ks.dir)
+	if err != nil {
+		return fmt.Errorf("error reading key directory: %v", err)
+	}
+
+	var keys []*SigningKey
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, keyFilePrefix) || !strings.HasSuffix(name, ".pem") {
+			continue
+		}
+
+		created, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(name, keyFilePrefix), ".pem"), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		key, err := readKeyFile(filepath.Join(ks.dir, name), time.Unix(created, 0))
+		if err != nil {
+			return fmt.Errorf("error loading %s: %v", name, err)
+		}
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i].CreatedAt.Before(keys[j].CreatedAt) })
+
+	ks.mu.Lock()
+	ks.keys = keys
+	ks.mu.Unlock()
+	return nil
+}
+
+// readKeyFile parses a PKCS #8 or PKCS #1 PEM private key
+func readKeyFile(path string, createdAt time.Time) (*SigningKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+
+	var private interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		private, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		private, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newSigningKey(private, createdAt)
+}
+
+// writeKeyFile saves a key as PKCS #8 PEM, readable by the owner only
+func (ks *KeySet) writeKeyFile(key *SigningKey) error {
+	der, err := x509.MarshalPKCS8PrivateKey(key.private)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s%d.pem", keyFilePrefix, key.CreatedAt.Unix())
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	return os.WriteFile(filepath.Join(ks.dir, name), data, 0600)
+}
+
+// Rotate creates a key when the newest one is due for replacement, deletes retired keys
+// and reloads the directory, which also picks up keys added by other instances
+func (ks *KeySet) Rotate(now time.Time) error {
+	if err := ks.Load(); err != nil {
+		return err
+	}
+
+	ks.mu.RLock()
+	keys := ks.keys
+	ks.mu.RUnlock()
+
+	if len(keys) == 0 || now.Sub(keys[len(keys)-1].CreatedAt) >= ks.rotateEvery {
+		key, err := GenerateSigningKey(ks.algorithm, now)
+		if err != nil {
+			return err
+		}
+		if err := ks.writeKeyFile(key); err != nil {
+			return fmt.Errorf("error saving key: %v", err)
+		}
+		log.Printf("created signing key %s", key.ID)
+		keys = append(keys, key)
+	}
+
+	// A key is retired once the key that replaced it has been signing for longer than
+	// any token lives
+	for i := 0; i+1 < len(keys); i++ {
+		successorActive := keys[i+1].CreatedAt.Add(KeyPublishAhead)
+		if now.Sub(successorActive) < ks.retireAfter {
+			break
+		}
+
+		name := fmt.Sprintf("%s%d.pem", keyFilePrefix, keys[i].CreatedAt.Unix())
+		if err := os.Remove(filepath.Join(ks.dir, name)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error deleting retired key: %v", err)
+		}
+		log.Printf("retired signing key %s", keys[i].ID)
+	}
+
+	return ks.Load()
+}
+
+// SigningKey returns the key that signs new tokens: the newest published key, or the
+// newest key if none has been published long enough, as on first start
+func (ks *KeySet) SigningKey(now time.Time) *SigningKey {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	if len(ks.keys) == 0 {
+		return nil
+	}
+	for i := len(ks.keys) - 1; i >= 0; i-- {
+		if !now.Before(ks.keys[i].CreatedAt.Add(KeyPublishAhead)) {
+			return ks.keys[i]
+		}
+	}
+	return ks.keys[len(ks.keys)-1]
+}
+
+// Key returns the key with the ID, or nil if there is none
+func (ks *KeySet) Key(id string) *SigningKey {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	for _, key := range ks.keys {
+		if key.ID == id {
+			return key
+		}
+	}
+	return nil
+}
+
+// JSONWebKey is a public key in a JWKS (RFC 7517)
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+// JWKS returns the public keys of every key in the set
+func (ks *KeySet) JWKS() []JSONWebKey {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	jwks := make([]JSONWebKey, 0, len(ks.keys))
+	for _, key := range ks.keys {
+		jwk := JSONWebKey{Kid: key.ID, Use: "sig", Alg: key.Algorithm}
+		switch public := key.Public().(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		jwks = append(jwks, jwk)
+	}
+	return jwks
+}
+
+// signingKeys signs and verifies every token. It is set by UseSigningKeys.
+var signingKeys *KeySet
+
+// UseSigningKeys loads the key directory, creating it and a first key if needed, makes
+// it sign and verify tokens and rotates the keys every checkInterval
+func UseSigningKeys(ks *KeySet, checkInterval time.Duration) error {
+	if err := os.MkdirAll(ks.dir, 0700); err != nil {
+		return fmt.Errorf("error creating key directory: %v", err)
+	}
+	if err := ks.Rotate(time.Now()); err != nil {
+		return err
+	}
+
+	signingKeys = ks
+
+	go func() {
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := ks.Rotate(time.Now()); err != nil {
+				log.Printf("error rotating signing keys: %v", err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// signToken signs the claims with the current signing key and sets its ID in the header
+func signToken(claims jwt.Claims) (string, error) {
+	if signingKeys == nil {
+		return "", fmt.Errorf("signing keys are not loaded")
+	}
+
+	key := signingKeys.SigningKey(time.Now())
+	if key == nil {
+		return "", fmt.Errorf("no signing key available")
+	}
+
+	token := jwt.NewWithClaims(key.method(), claims)
+	token.Header["kid"] = key.ID
+
+	return token.SignedString(key.private)
+}
+
+// verificationKey finds the key a token names in its header. The token's algorithm
+// must be the key's, so a public key can never be used as an HMAC secret.
+func verificationKey(t *jwt.Token) (interface{}, error) {
+	if signingKeys == nil {
+		return nil, fmt.Errorf("signing keys are not loaded")
+	}
+
+	kid, _ := t.Header["kid"].(string)
+	key := signingKeys.Key(kid)
+	if key == nil {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if t.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+	}
+
+	return key.Public(), nil
+}
+
+// HandleJWKS serves the public keys so other services can verify tokens
+func HandleJWKS(c *fiber.Ctx) error {
+	if signingKeys == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "Signing keys are not loaded"})
+	}
+
+	body, err := json.Marshal(fiber.Map{"keys": signingKeys.JWKS()})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	c.Set(fiber.HeaderContentType, "application/jwk-set+json")
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.Status(fiber.StatusOK).Send(body)
+}
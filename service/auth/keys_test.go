@@ -0,0 +1,132 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// TestMain loads a key set in a temporary directory for the tests that sign tokens
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "keys")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ks := NewKeySet(dir, AlgEdDSA, 30*24*time.Hour, 2*time.Hour)
+	if err := ks.Rotate(time.Now()); err != nil {
+		log.Fatal(err)
+	}
+	signingKeys = ks
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func TestKeyRotation(t *testing.T) {
+	dir := t.TempDir()
+	rotateEvery := 24 * time.Hour
+	retireAfter := 2 * time.Hour
+	ks := NewKeySet(dir, AlgRS256, rotateEvery, retireAfter)
+
+	start := time.Unix(1700000000, 0)
+	if err := ks.Rotate(start); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first := ks.SigningKey(start)
+	if first == nil || first.Algorithm != AlgRS256 {
+		t.Fatalf("expected a first RS256 key, got %+v", first)
+	}
+
+	// Nothing happens before the rotation is due
+	if err := ks.Rotate(start.Add(time.Hour)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ks.JWKS()) != 1 {
+		t.Fatalf("expected one key, got %d", len(ks.JWKS()))
+	}
+
+	// A new key is published before it signs
+	rotated := start.Add(rotateEvery)
+	if err := ks.Rotate(rotated); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ks.JWKS()) != 2 {
+		t.Fatalf("expected two published keys, got %d", len(ks.JWKS()))
+	}
+	if ks.SigningKey(rotated).ID != first.ID {
+		t.Error("expected the old key to sign until the new one is published long enough")
+	}
+	second := ks.SigningKey(rotated.Add(KeyPublishAhead))
+	if second.ID == first.ID {
+		t.Error("expected the new key to sign once published")
+	}
+
+	// The old key still verifies until its tokens have expired
+	if err := ks.Rotate(rotated.Add(KeyPublishAhead + retireAfter - time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ks.Key(first.ID) == nil {
+		t.Error("expected the old key to be kept")
+	}
+	if err := ks.Rotate(rotated.Add(KeyPublishAhead + retireAfter)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ks.Key(first.ID) != nil || ks.Key(second.ID) == nil {
+		t.Error("expected only the old key to be retired")
+	}
+
+	// Keys survive a restart with the same IDs
+	reloaded := NewKeySet(dir, AlgRS256, rotateEvery, retireAfter)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reloaded.Key(second.ID) == nil {
+		t.Error("expected the key to be loaded from disk")
+	}
+
+	info, err := os.Stat(filepath.Join(dir, "key-"+"1700086400"+".pem"))
+	if err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("expected the key file to be private, got %v, %v", info, err)
+	}
+}
+
+func TestValidateTokenRejectsForeignKeys(t *testing.T) {
+	token, err := CreateJWT(1)
+	if err != nil {
+		t.Fatalf("error creating JWT: %v", err)
+	}
+	if _, err := ValidateAccessToken(token); err != nil {
+		t.Errorf("expected the token to be valid: %v", err)
+	}
+
+	key := signingKeys.SigningKey(time.Now())
+	claims := newClaims(TokenTypeAccess, "1", time.Minute)
+	claims.ID = "id"
+
+	// A token signed with HMAC using the public key as the secret
+	hmacToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	hmacToken.Header["kid"] = key.ID
+	signed, _ := hmacToken.SignedString([]byte(key.Public().(ed25519.PublicKey)))
+	if _, err := ValidateAccessToken(signed); err == nil {
+		t.Error("expected an HMAC token to be rejected")
+	}
+
+	// A token signed with a key that is not in the set
+	other, err := GenerateSigningKey(AlgEdDSA, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherToken := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	otherToken.Header["kid"] = key.ID
+	signed, _ = otherToken.SignedString(other.private)
+	if _, err := ValidateAccessToken(signed); err == nil {
+		t.Error("expected a token signed with an unknown key to be rejected")
+	}
+}
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// GenerateOpaqueToken returns a random URL-safe token to hand to the client.
+// Only its hash should ever be stored.
+func GenerateOpaqueToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// GenerateTokenID returns a random 128-bit identifier in hex
+func GenerateTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// GenerateTokenFamilyID returns a random identifier grouping all refresh tokens
+// issued from a single login
+func GenerateTokenFamilyID() (string, error) {
+	return GenerateTokenID()
+}
+
+// HashToken hashes an opaque token using SHA-256
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
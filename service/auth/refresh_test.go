@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"testing"
+)
+
+func TestGenerateOpaqueToken(t *testing.T) {
+	first, err := GenerateOpaqueToken()
+	if err != nil {
+		t.Errorf("error generating token: %v", err)
+	}
+
+	second, err := GenerateOpaqueToken()
+	if err != nil {
+		t.Errorf("error generating token: %v", err)
+	}
+
+	if first == "" || first == second {
+		t.Error("expected tokens to be non-empty and unique")
+	}
+}
+
+func TestHashToken(t *testing.T) {
+	if HashToken("token") != HashToken("token") {
+		t.Error("expected hash to be deterministic")
+	}
+
+	if len(HashToken("token")) != 64 {
+		t.Errorf("expected a 64 character hex hash, got %d", len(HashToken("token")))
+	}
+
+	if HashToken("token") == HashToken("other") {
+		t.Error("expected different tokens to have different hashes")
+	}
+}
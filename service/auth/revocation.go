@@ -0,0 +1,203 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+// RevocationList keeps revoked token IDs and per-user revocation cut-offs in
+// memory so the auth middleware does not hit MySQL on every request. The
+// database stays the source of truth and is reloaded periodically so that
+// revocations made by other instances are picked up.
+type RevocationList struct {
+	store types.RevocationStore
+
+	mu     sync.RWMutex
+	tokens map[string]time.Time
+	users  map[int32]time.Time
+}
+
+var revocations *RevocationList
+
+// NewRevocationList creates a revocation list backed by the given store
+func NewRevocationList(store types.RevocationStore) *RevocationList {
+	return &RevocationList{
+		store:  store,
+		tokens: make(map[string]time.Time),
+		users:  make(map[int32]time.Time),
+	}
+}
+
+// UseRevocationStore loads the revocation list from the store, makes the auth
+// middleware consult it and keeps it in sync every refreshInterval
+func UseRevocationStore(store types.RevocationStore, refreshInterval time.Duration) error {
+	list := NewRevocationList(store)
+	if err := list.Reload(); err != nil {
+		return err
+	}
+
+	revocations = list
+
+	go func() {
+		ticker := time.NewTicker(refreshInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if err := list.store.DeleteExpiredRevokedTokens(context.Background()); err != nil {
+				log.Printf("error deleting expired revoked tokens: %v", err)
+			}
+			if err := list.Reload(); err != nil {
+				log.Printf("error reloading revoked tokens: %v", err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Reload replaces the in-memory cache with the current contents of the store
+func (l *RevocationList) Reload() error {
+	revoked, err := l.store.GetActiveRevokedTokens()
+	if err != nil {
+		return fmt.Errorf("error loading revoked tokens: %v", err)
+	}
+
+	users, err := l.store.GetUserSessionRevocations()
+	if err != nil {
+		return fmt.Errorf("error loading user session revocations: %v", err)
+	}
+
+	tokens := make(map[string]time.Time, len(revoked))
+	for _, token := range revoked {
+		tokens[token.JTI] = token.ExpiresAt
+	}
+
+	l.mu.Lock()
+	l.tokens = tokens
+	l.users = users
+	l.mu.Unlock()
+
+	return nil
+}
+
+// RevokeToken revokes a single access token until it expires
+func (l *RevocationList) RevokeToken(ctx context.Context, jti string, userID int32, expiresAt time.Time) error {
+	err := l.store.CreateRevokedToken(ctx, &types.RevokedToken{
+		JTI:       jti,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	l.tokens[jti] = expiresAt
+	l.mu.Unlock()
+
+	return nil
+}
+
+// RevokeUserTokens revokes every access token issued to the user up to now
+func (l *RevocationList) RevokeUserTokens(ctx context.Context, userID int32) error {
+	// JWT issue times only have second precision, so the cut-off is the end of the
+	// current second. A token issued earlier in the same second is revoked too.
+	revokedBefore := time.Now().UTC().Truncate(time.Second).Add(time.Second)
+
+	if err := l.store.RevokeUserSessions(ctx, userID, revokedBefore); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	l.users[userID] = revokedBefore
+	l.mu.Unlock()
+
+	return nil
+}
+
+// IsRevoked reports whether a token with the given ID, owner and issue time was revoked
+func (l *RevocationList) IsRevoked(jti string, userID int32, issuedAt time.Time) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if expiresAt, ok := l.tokens[jti]; ok && time.Now().Before(expiresAt) {
+		return true
+	}
+
+	if revokedBefore, ok := l.users[userID]; ok && issuedAt.Before(revokedBefore) {
+		return true
+	}
+
+	return false
+}
+
+// IssuedAt returns the issue time of a new token for the user. A token issued before
+// the user's cut-off would be revoked at once, so it is issued at the cut-off instead.
+func (l *RevocationList) IssuedAt(userID int32, now time.Time) time.Time {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if revokedBefore, ok := l.users[userID]; ok && now.Before(revokedBefore) {
+		return revokedBefore
+	}
+
+	return now
+}
+
+// RevokeAccessToken revokes the given access token so it can no longer be used
+func RevokeAccessToken(ctx context.Context, tokenString string) error {
+	if revocations == nil {
+		return nil
+	}
+
+	claims, err := ValidateAccessToken(tokenString)
+	if err != nil {
+		return err
+	}
+
+	userID, err := claims.UserID()
+	if err != nil {
+		return err
+	}
+
+	return revocations.RevokeToken(ctx, claims.ID, userID, claims.ExpiresAt.Time)
+}
+
+// RevokeUserTokens revokes every access token issued to the user so far
+func RevokeUserTokens(ctx context.Context, userID int32) error {
+	if revocations == nil {
+		return nil
+	}
+
+	return revocations.RevokeUserTokens(ctx, userID)
+}
+
+// IsTokenRevoked reports whether the access token carrying these claims was revoked.
+// Tokens without an ID cannot be revoked individually and are treated as revoked.
+func IsTokenRevoked(claims *Claims) bool {
+	userID, err := claims.UserID()
+	if err != nil || claims.ID == "" || claims.IssuedAt == nil {
+		return true
+	}
+
+	if revocations == nil {
+		return false
+	}
+
+	return revocations.IsRevoked(claims.ID, userID, claims.IssuedAt.Time)
+}
+
+// issuedAt returns the issue time of a new access token for the user, which is never
+// before the cut-off of the user's last revocation
+func issuedAt(userID int32, now time.Time) time.Time {
+	if revocations == nil {
+		return now
+	}
+
+	return revocations.IssuedAt(userID, now)
+}
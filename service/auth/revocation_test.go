@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+type mockRevocationStore struct {
+	tokens []*types.RevokedToken
+	users  map[int32]time.Time
+}
+
+func (m *mockRevocationStore) CreateRevokedToken(ctx context.Context, token *types.RevokedToken) error {
+	m.tokens = append(m.tokens, token)
+	return nil
+}
+
+func (m *mockRevocationStore) GetActiveRevokedTokens() ([]*types.RevokedToken, error) {
+	return m.tokens, nil
+}
+
+func (m *mockRevocationStore) DeleteExpiredRevokedTokens(ctx context.Context) error {
+	return nil
+}
+
+func (m *mockRevocationStore) RevokeUserSessions(ctx context.Context, userID int32, revokedBefore time.Time) error {
+	m.users[userID] = revokedBefore
+	return nil
+}
+
+func (m *mockRevocationStore) GetUserSessionRevocations() (map[int32]time.Time, error) {
+	return m.users, nil
+}
+
+func TestRevocationList(t *testing.T) {
+	store := &mockRevocationStore{users: make(map[int32]time.Time)}
+	list := NewRevocationList(store)
+
+	issuedAt := time.Now().Add(-time.Minute)
+	if list.IsRevoked("abc", 1, issuedAt) {
+		t.Error("expected token not to be revoked")
+	}
+
+	if err := list.RevokeToken(context.Background(), "abc", 1, time.Now().Add(time.Hour)); err != nil {
+		t.Errorf("error revoking token: %v", err)
+	}
+	if !list.IsRevoked("abc", 1, issuedAt) {
+		t.Error("expected token to be revoked")
+	}
+
+	if err := list.RevokeUserTokens(context.Background(), 2); err != nil {
+		t.Errorf("error revoking user tokens: %v", err)
+	}
+	if !list.IsRevoked("def", 2, issuedAt) {
+		t.Error("expected tokens issued before the cut-off to be revoked")
+	}
+	if list.IsRevoked("ghi", 2, time.Now().Add(time.Minute)) {
+		t.Error("expected tokens issued after the cut-off to be accepted")
+	}
+
+	// A fresh list loaded from the store sees the same revocations
+	reloaded := NewRevocationList(store)
+	if err := reloaded.Reload(); err != nil {
+		t.Errorf("error reloading revocations: %v", err)
+	}
+	if !reloaded.IsRevoked("abc", 1, issuedAt) || !reloaded.IsRevoked("def", 2, issuedAt) {
+		t.Error("expected revocations to survive a reload")
+	}
+}
+
+func TestIsTokenRevokedRequiresTokenID(t *testing.T) {
+	claims := newClaims(TokenTypeAccess, "1", time.Minute)
+
+	if !IsTokenRevoked(claims) {
+		t.Error("expected a token without an id to be rejected")
+	}
+}
+
+func TestRevocationCoversItsSecond(t *testing.T) {
+	store := &mockRevocationStore{users: make(map[int32]time.Time)}
+	list := NewRevocationList(store)
+
+	saved := revocations
+	revocations = list
+	defer func() { revocations = saved }()
+
+	if err := list.RevokeUserTokens(context.Background(), 3); err != nil {
+		t.Fatalf("error revoking user tokens: %v", err)
+	}
+
+	// JWT issue times are whole seconds, so a token issued just before the revocation
+	// carries the same issue time as one issued just after it
+	sameSecond := store.users[3].Add(-time.Second)
+	if !list.IsRevoked("jkl", 3, sameSecond) {
+		t.Error("expected a token issued in the revocation second to be revoked")
+	}
+
+	// A session started right after the revocation is still accepted
+	token, err := CreateJWT(3)
+	if err != nil {
+		t.Fatalf("error creating JWT: %v", err)
+	}
+	claims, err := ValidateAccessToken(token)
+	if err != nil {
+		t.Fatalf("error validating JWT: %v", err)
+	}
+	if IsTokenRevoked(claims) {
+		t.Error("expected a token issued after the revocation to be accepted")
+	}
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+// ShareKey holds the share a caregiver is reading another user's data through
+const ShareKey contextKey = "share"
+
+var shares types.ShareStore
+
+// UseShareStore makes WithSharedAccess look up caregiver shares in the store.
+// Until it is called, every request for another user's data is denied.
+func UseShareStore(store types.ShareStore) {
+	shares = store
+}
+
+// WithSharedAccess is WithJWTAuth for read-only handlers whose data can be shared. A
+// caregiver selects the user with the user_id query parameter and needs an accepted
+// read share covering every given scope; the handler then runs as that user. With no
+// scopes any read share is accepted and the handler must limit the response to the
+// scopes of GetShareFromContext.
+func WithSharedAccess(handlerFunc fiber.Handler, store types.UserStore, scopes ...string) fiber.Handler {
+	return withJWTAuth(handlerFunc, store, true, scopes)
+}
+
+// sharedDataOwner returns the share that lets the user read the owner's data, or nil
+// if there is none or it does not cover the scopes
+func sharedDataOwner(ownerID int32, userID int32, scopes []string) (*types.Share, error) {
+	if shares == nil {
+		return nil, nil
+	}
+
+	share, err := shares.GetAcceptedShare(ownerID, userID)
+	if err != nil || share == nil {
+		return nil, err
+	}
+
+	if share.Access != types.ShareAccessRead {
+		return nil, nil
+	}
+	for _, scope := range scopes {
+		if !share.HasScope(scope) {
+			return nil, nil
+		}
+	}
+
+	return share, nil
+}
+
+// GetShareFromContext returns the share the request reads another user's data through,
+// or nil when users read their own data
+func GetShareFromContext(c *fiber.Ctx) *types.Share {
+	share, ok := c.Locals(ShareKey).(*types.Share)
+	if !ok {
+		return nil
+	}
+	return share
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+// mockShareStore only answers GetAcceptedShare, which is all the middleware uses
+type mockShareStore struct {
+	types.ShareStore
+	shares []*types.Share
+}
+
+func (m *mockShareStore) GetAcceptedShare(ownerID int32, caregiverID int32) (*types.Share, error) {
+	for _, share := range m.shares {
+		if share.OwnerID == ownerID && share.CaregiverID != nil && *share.CaregiverID == caregiverID {
+			return share, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestSharedDataOwner(t *testing.T) {
+	defer UseShareStore(nil)
+
+	caregiverID := int32(2)
+	if share, _ := sharedDataOwner(1, caregiverID, nil); share != nil {
+		t.Error("expected no access without a share store")
+	}
+
+	UseShareStore(&mockShareStore{shares: []*types.Share{
+		{OwnerID: 1, CaregiverID: &caregiverID, Access: types.ShareAccessRead, Scopes: []string{types.ShareScopeGlucose, types.ShareScopeMeals}},
+		{OwnerID: 3, CaregiverID: &caregiverID, Access: types.ShareAccessAlerts, Scopes: []string{types.ShareScopeAlerts}},
+	}})
+
+	tests := []struct {
+		ownerID int32
+		scopes  []string
+		allowed bool
+	}{
+		{1, []string{types.ShareScopeGlucose}, true},
+		{1, []string{types.ShareScopeGlucose, types.ShareScopeMeals}, true},
+		{1, nil, true},
+		{1, []string{types.ShareScopeLabs}, false},
+		{1, []string{types.ShareScopeGlucose, types.ShareScopeLabs}, false},
+		{3, []string{types.ShareScopeAlerts}, false},
+		{4, nil, false},
+	}
+
+	for _, tt := range tests {
+		share, err := sharedDataOwner(tt.ownerID, caregiverID, tt.scopes)
+		if err != nil {
+			t.Fatalf("sharedDataOwner(%d, %v) error: %v", tt.ownerID, tt.scopes, err)
+		}
+		if (share != nil) != tt.allowed {
+			t.Errorf("sharedDataOwner(%d, %v) allowed = %v, want %v", tt.ownerID, tt.scopes, share != nil, tt.allowed)
+		}
+	}
+}
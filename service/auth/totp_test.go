@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The SHA-1 test vectors of RFC 6238, truncated to six digits
+func TestTOTPCode(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		got, err := TOTPCode(secret, TOTPStep(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("TOTPCode() error: %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("TOTPCode() at %d = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("error generating secret: %v", err)
+	}
+
+	now := time.Unix(1700000000, 0)
+	previous, _ := TOTPCode(secret, TOTPStep(now)-1)
+	if step, ok := ValidateTOTP(secret, previous, now); !ok || step != TOTPStep(now)-1 {
+		t.Errorf("expected the previous step's code to be accepted, got step %d, %v", step, ok)
+	}
+
+	stale, _ := TOTPCode(secret, TOTPStep(now)-3)
+	if _, ok := ValidateTOTP(secret, stale, now); ok {
+		t.Error("expected a code from three steps ago to be rejected")
+	}
+
+	if _, ok := ValidateTOTP(secret, "12345", now); ok {
+		t.Error("expected a short code to be rejected")
+	}
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := TOTPURI("Abundance", "jane@example.com", "ABC")
+	if !strings.HasPrefix(uri, "otpauth://totp/Abundance:jane@example.com?") || !strings.Contains(uri, "secret=ABC") {
+		t.Errorf("unexpected URI %s", uri)
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	if err != nil {
+		t.Fatalf("error generating recovery codes: %v", err)
+	}
+	if len(codes) != 10 || len(codes[0]) != 11 || codes[0][5] != '-' {
+		t.Fatalf("unexpected recovery codes %v", codes)
+	}
+
+	typed := strings.ToUpper(strings.ReplaceAll(codes[0], "-", " "))
+	if HashRecoveryCode(typed) != HashRecoveryCode(codes[0]) {
+		t.Error("expected case, spaces and dashes to be ignored")
+	}
+}
@@ -0,0 +1,214 @@
+package cgmimport
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/service/glucose"
+	"github.com/jayden1905/abundance/types"
+)
+
+// jobRetention is how long finished jobs stay available for polling
+const jobRetention = time.Hour
+
+// progressInterval is how many rows are imported between progress updates
+const progressInterval = 100
+
+// Registry keeps import jobs in memory so clients can poll their progress.
+// Jobs are lost on restart, which only affects the progress view since the
+// imported readings are already stored.
+type Registry struct {
+	mu   sync.Mutex
+	jobs map[string]*types.ImportJob
+}
+
+// NewRegistry creates an empty job registry
+func NewRegistry() *Registry {
+	return &Registry{jobs: make(map[string]*types.ImportJob)}
+}
+
+// Create registers a pending job for the user
+func (r *Registry) Create(userID int32, format string, fileName string) (*types.ImportJob, error) {
+	id, err := auth.GenerateTokenID()
+	if err != nil {
+		return nil, err
+	}
+
+	job := &types.ImportJob{
+		ID:        id,
+		UserID:    userID,
+		Format:    format,
+		FileName:  fileName,
+		Status:    types.ImportStatusPending,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.prune()
+	r.jobs[id] = job
+
+	return r.snapshot(job), nil
+}
+
+// Get returns a copy of the job if it exists and belongs to the user
+func (r *Registry) Get(id string, userID int32) (*types.ImportJob, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.jobs[id]
+	if !ok || job.UserID != userID {
+		return nil, false
+	}
+
+	return r.snapshot(job), true
+}
+
+// List returns copies of the user's jobs
+func (r *Registry) List(userID int32) []*types.ImportJob {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	jobs := []*types.ImportJob{}
+	for _, job := range r.jobs {
+		if job.UserID == userID {
+			jobs = append(jobs, r.snapshot(job))
+		}
+	}
+
+	return jobs
+}
+
+func (r *Registry) update(id string, fn func(job *types.ImportJob)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if job, ok := r.jobs[id]; ok {
+		fn(job)
+	}
+}
+
+func (r *Registry) finish(id string, err error) {
+	r.update(id, func(job *types.ImportJob) {
+		now := time.Now().UTC()
+		job.FinishedAt = &now
+		job.Status = types.ImportStatusCompleted
+		if err != nil {
+			job.Status = types.ImportStatusFailed
+			job.Error = err.Error()
+		}
+	})
+}
+
+// prune drops finished jobs past their retention. Callers must hold the lock.
+func (r *Registry) prune() {
+	for id, job := range r.jobs {
+		if job.FinishedAt != nil && time.Since(*job.FinishedAt) > jobRetention {
+			delete(r.jobs, id)
+		}
+	}
+}
+
+// snapshot copies the job so it can be read without holding the lock
+func (r *Registry) snapshot(job *types.ImportJob) *types.ImportJob {
+	copied := *job
+	copied.Summary.Errors = append([]types.ImportRowError{}, job.Summary.Errors...)
+	return &copied
+}
+
+// Run stores the parsed readings for the job's user, skipping readings that are
+// already stored or repeated in the file
+func (r *Registry) Run(store types.GlucoseStore, jobID string, userID int32, parsed *ParseResult) {
+	r.update(jobID, func(job *types.ImportJob) {
+		job.Status = types.ImportStatusRunning
+		job.Total = len(parsed.Readings)
+		job.Summary.Skipped = parsed.Skipped
+		job.Summary.Invalid = parsed.Invalid
+		job.Summary.Errors = parsed.Errors
+	})
+
+	seen, err := existingReadings(store, userID, parsed.Readings)
+	if err != nil {
+		log.Printf("error loading readings for import %s: %v", jobID, err)
+		r.finish(jobID, fmt.Errorf("error loading existing readings"))
+		return
+	}
+
+	var imported, duplicates int
+	for i, reading := range parsed.Readings {
+		key := dedupKey(reading)
+		if seen[key] {
+			duplicates++
+		} else {
+			reading.UserID = userID
+			if _, err := store.CreateGlucoseReading(context.Background(), reading); err != nil {
+				log.Printf("error storing reading for import %s: %v", jobID, err)
+				r.update(jobID, func(job *types.ImportJob) {
+					job.Processed = i
+					job.Summary.Imported = imported
+					job.Summary.Duplicates = duplicates
+				})
+				r.finish(jobID, fmt.Errorf("error storing readings, %d of %d imported", imported, len(parsed.Readings)))
+				return
+			}
+			seen[key] = true
+			imported++
+		}
+
+		if (i+1)%progressInterval == 0 || i+1 == len(parsed.Readings) {
+			processed := i + 1
+			r.update(jobID, func(job *types.ImportJob) {
+				job.Processed = processed
+				job.Summary.Imported = imported
+				job.Summary.Duplicates = duplicates
+			})
+		}
+	}
+
+	r.finish(jobID, nil)
+}
+
+// existingReadings returns the dedup keys of the user's stored readings in the
+// time span covered by the import
+func existingReadings(store types.GlucoseStore, userID int32, readings []*types.GlucoseReading) (map[string]bool, error) {
+	seen := make(map[string]bool)
+	if len(readings) == 0 {
+		return seen, nil
+	}
+
+	start, end := readings[0].MeasuredAt, readings[0].MeasuredAt
+	for _, reading := range readings {
+		if reading.MeasuredAt.Before(start) {
+			start = reading.MeasuredAt
+		}
+		if reading.MeasuredAt.After(end) {
+			end = reading.MeasuredAt
+		}
+	}
+
+	// Widen the range so readings in the same minute as the first and last are found
+	stored, err := store.GetGlucoseReadingsByTimeRange(userID, start.Add(-time.Minute), end.Add(time.Minute))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, reading := range stored {
+		seen[dedupKey(reading)] = true
+	}
+
+	return seen, nil
+}
+
+// dedupKey identifies a reading by its minute and value in whole mg/dL, so the
+// same reading exported twice or in another unit is recognised
+func dedupKey(reading *types.GlucoseReading) string {
+	minute := reading.MeasuredAt.Unix() / 60
+	value := math.Round(glucose.ToMgdL(reading.Value, reading.Unit))
+	return fmt.Sprintf("%d:%d", minute, int(value))
+}
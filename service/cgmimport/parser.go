@@ -0,0 +1,277 @@
+package cgmimport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jayden1905/abundance/service/glucose"
+	"github.com/jayden1905/abundance/types"
+)
+
+// maxRowErrors caps how many row errors are reported back to the user
+const maxRowErrors = 50
+
+// readingSource is the source of every imported reading, whether the device took it
+// from the sensor or a finger stick, so imports can be told apart from synced data
+const readingSource = "import"
+
+// Dexcom reports readings outside the sensor range as "Low" and "High".
+// Like Clarity, we count them as the sensor limits.
+const (
+	dexcomLowMgdL  = 40.0
+	dexcomHighMgdL = 400.0
+)
+
+// LibreView record types that carry a glucose value
+const (
+	libreRecordHistoric = "0"
+	libreRecordScan     = "1"
+	libreRecordStrip    = "2"
+)
+
+// ParseResult holds the readings found in an export and the rows that were not usable
+type ParseResult struct {
+	Format   string
+	Readings []*types.GlucoseReading
+	Skipped  int
+	Invalid  int
+	Errors   []types.ImportRowError
+}
+
+func (r *ParseResult) invalid(line int, format string, args ...any) {
+	r.Invalid++
+	if len(r.Errors) < maxRowErrors {
+		r.Errors = append(r.Errors, types.ImportRowError{Line: line, Reason: fmt.Sprintf(format, args...)})
+	}
+}
+
+type record struct {
+	line   int
+	fields []string
+}
+
+// Parse reads a Dexcom Clarity or LibreView CSV export. When format is empty it is
+// detected from the header. Timestamps in the file are local device time and are
+// interpreted in loc.
+func Parse(r io.Reader, format string, loc *time.Location) (*ParseResult, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	var records []record
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading CSV: %v", err)
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, record{line: line, fields: fields})
+	}
+
+	detected, headerIndex := detectFormat(records)
+	if detected == "" {
+		return nil, fmt.Errorf("unrecognised file, expected a Dexcom Clarity or LibreView CSV export")
+	}
+	if format != "" && format != detected {
+		return nil, fmt.Errorf("file looks like a %s export, not %s", detected, format)
+	}
+
+	header := records[headerIndex].fields
+	rows := records[headerIndex+1:]
+
+	if detected == types.ImportFormatDexcom {
+		return parseDexcom(header, rows, loc)
+	}
+	return parseLibre(header, rows, loc)
+}
+
+// detectFormat looks for a known header in the first few rows and returns the
+// format and the index of the header row
+func detectFormat(records []record) (string, int) {
+	for i := 0; i < len(records) && i < 5; i++ {
+		fields := records[i].fields
+		if columnIndex(fields, "Event Type") >= 0 && columnIndex(fields, "Timestamp") >= 0 {
+			return types.ImportFormatDexcom, i
+		}
+		if columnIndex(fields, "Record Type") >= 0 && columnIndex(fields, "Device Timestamp") >= 0 {
+			return types.ImportFormatLibre, i
+		}
+	}
+	return "", 0
+}
+
+func parseDexcom(header []string, rows []record, loc *time.Location) (*ParseResult, error) {
+	result := &ParseResult{Format: types.ImportFormatDexcom}
+
+	timestampCol := columnIndex(header, "Timestamp")
+	eventCol := columnIndex(header, "Event Type")
+	glucoseCol := columnIndex(header, "Glucose Value")
+	if glucoseCol < 0 {
+		return nil, fmt.Errorf("missing Glucose Value column")
+	}
+	unit, err := unitFromHeader(header[glucoseCol])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		// Sensor readings and calibrations carry a glucose value. The other rows are
+		// patient and device details, alerts, insulin and carb events.
+		if event := field(row.fields, eventCol); event != "EGV" && event != "Calibration" {
+			result.Skipped++
+			continue
+		}
+
+		measuredAt, err := parseTimestamp(field(row.fields, timestampCol), loc, "2006-01-02T15:04:05", "2006-01-02 15:04:05")
+		if err != nil {
+			result.invalid(row.line, "invalid timestamp %q", field(row.fields, timestampCol))
+			continue
+		}
+
+		raw := field(row.fields, glucoseCol)
+		var value float64
+		switch strings.ToLower(raw) {
+		case "low":
+			value = glucose.FromMgdL(dexcomLowMgdL, unit)
+		case "high":
+			value = glucose.FromMgdL(dexcomHighMgdL, unit)
+		default:
+			value, err = parseValue(raw)
+			if err != nil {
+				result.invalid(row.line, "invalid glucose value %q", raw)
+				continue
+			}
+		}
+
+		if !glucose.IsPlausible(value, unit) {
+			result.invalid(row.line, "glucose value %v %s is out of range", value, unit)
+			continue
+		}
+
+		result.Readings = append(result.Readings, &types.GlucoseReading{
+			Value:              value,
+			Unit:               unit,
+			Source:             readingSource,
+			MeasurementContext: "other",
+			MeasuredAt:         measuredAt,
+		})
+	}
+
+	return result, nil
+}
+
+func parseLibre(header []string, rows []record, loc *time.Location) (*ParseResult, error) {
+	result := &ParseResult{Format: types.ImportFormatLibre}
+
+	timestampCol := columnIndex(header, "Device Timestamp")
+	recordCol := columnIndex(header, "Record Type")
+	valueCols := map[string]int{
+		libreRecordHistoric: columnIndex(header, "Historic Glucose"),
+		libreRecordScan:     columnIndex(header, "Scan Glucose"),
+		libreRecordStrip:    columnIndex(header, "Strip Glucose"),
+	}
+	if valueCols[libreRecordHistoric] < 0 {
+		return nil, fmt.Errorf("missing Historic Glucose column")
+	}
+	unit, err := unitFromHeader(header[valueCols[libreRecordHistoric]])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		recordType := field(row.fields, recordCol)
+		valueCol, ok := valueCols[recordType]
+		if !ok || valueCol < 0 {
+			// Insulin, food, notes and sensor events
+			result.Skipped++
+			continue
+		}
+
+		// US exports use a 12-hour clock and month first, other regions a
+		// 24-hour clock and day first
+		measuredAt, err := parseTimestamp(field(row.fields, timestampCol), loc, "01-02-2006 03:04 PM", "02-01-2006 15:04", "2006-01-02 15:04", "2006-01-02 15:04:05")
+		if err != nil {
+			result.invalid(row.line, "invalid timestamp %q", field(row.fields, timestampCol))
+			continue
+		}
+
+		raw := field(row.fields, valueCol)
+		value, err := parseValue(raw)
+		if err != nil {
+			result.invalid(row.line, "invalid glucose value %q", raw)
+			continue
+		}
+
+		if !glucose.IsPlausible(value, unit) {
+			result.invalid(row.line, "glucose value %v %s is out of range", value, unit)
+			continue
+		}
+
+		result.Readings = append(result.Readings, &types.GlucoseReading{
+			Value:              value,
+			Unit:               unit,
+			Source:             readingSource,
+			MeasurementContext: "other",
+			MeasuredAt:         measuredAt,
+		})
+	}
+
+	return result, nil
+}
+
+// columnIndex returns the index of the first column starting with prefix, or -1
+func columnIndex(header []string, prefix string) int {
+	for i, name := range header {
+		if strings.HasPrefix(strings.TrimSpace(name), prefix) {
+			return i
+		}
+	}
+	return -1
+}
+
+func field(fields []string, i int) string {
+	if i < 0 || i >= len(fields) {
+		return ""
+	}
+	return strings.TrimSpace(fields[i])
+}
+
+// unitFromHeader reads the glucose unit from a column name such as "Glucose Value (mg/dL)"
+func unitFromHeader(name string) (string, error) {
+	switch {
+	case strings.Contains(name, types.GlucoseUnitMgdL):
+		return types.GlucoseUnitMgdL, nil
+	case strings.Contains(name, types.GlucoseUnitMmolL):
+		return types.GlucoseUnitMmolL, nil
+	}
+	return "", fmt.Errorf("unknown glucose unit in column %q", name)
+}
+
+func parseTimestamp(value string, loc *time.Location, layouts ...string) (time.Time, error) {
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognised timestamp %q", value)
+}
+
+// parseValue parses a glucose value, accepting a decimal comma
+func parseValue(value string) (float64, error) {
+	return strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+}
@@ -0,0 +1,104 @@
+package cgmimport
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+const dexcomExport = `Index,Timestamp (YYYY-MM-DDThh:mm:ss),Event Type,Event Subtype,Patient Info,Device Info,Source Device ID,Glucose Value (mg/dL),Insulin Value (u),Carb Value (grams),Duration (hh:mm:ss),Glucose Rate of Change (mg/dL/min),Transmitter Time (Long Integer),Transmitter ID
+1,,FirstName,,Jane,,,,,,,,,
+2,,Device,,,G6,,,,,,,,
+3,2025-01-15T08:00:00,EGV,,,,Android G6,120,,,,,1000,8AB123
+4,2025-01-15T08:05:00,EGV,,,,Android G6,Low,,,,,1300,8AB123
+5,2025-01-15T08:10:00,Insulin,Fast-Acting,,,Android G6,,4,,,,,
+6,2025-01-15T08:15:00,Calibration,,,,Android G6,130,,,,,,
+7,2025-01-15T08:20:00,EGV,,,,Android G6,abc,,,,,1900,8AB123
+8,not a time,EGV,,,,Android G6,110,,,,,2200,8AB123
+`
+
+const libreExport = `Glucose Data,Generated on,01-16-2025 10:00 AM UTC,Generated by,Jane Doe
+Device,Serial Number,Device Timestamp,Record Type,Historic Glucose mmol/L,Scan Glucose mmol/L,Non-numeric Rapid-Acting Insulin,Rapid-Acting Insulin (units),Non-numeric Food,Carbohydrates (grams),Carbohydrates (servings),Non-numeric Long-Acting Insulin,Long-Acting Insulin (units),Notes,Strip Glucose mmol/L,Ketone mmol/L,Meal Insulin (units),Correction Insulin (units),User Change Insulin (units)
+FreeStyle LibreLink,ABC-123,01-15-2025 08:00 AM,0,6.5,,,,,,,,,,,,,,
+FreeStyle LibreLink,ABC-123,01-15-2025 08:07 PM,1,,"7,2",,,,,,,,,,,,,
+FreeStyle LibreLink,ABC-123,01-15-2025 09:00 PM,5,,,,,,30,,,,,,,,,
+FreeStyle LibreLink,ABC-123,01-15-2025 09:30 PM,2,,,,,,,,,,,5.8,,,,
+FreeStyle LibreLink,ABC-123,01-15-2025 10:00 PM,0,99,,,,,,,,,,,,,,
+`
+
+func TestParseDexcom(t *testing.T) {
+	result, err := Parse(strings.NewReader(dexcomExport), "", time.UTC)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Format != types.ImportFormatDexcom {
+		t.Errorf("expected dexcom format, got %s", result.Format)
+	}
+	if len(result.Readings) != 3 {
+		t.Fatalf("expected 3 readings, got %d", len(result.Readings))
+	}
+	if result.Skipped != 3 || result.Invalid != 2 || len(result.Errors) != 2 {
+		t.Errorf("expected 3 skipped and 2 invalid rows, got %d and %d", result.Skipped, result.Invalid)
+	}
+
+	if result.Readings[1].Value != dexcomLowMgdL {
+		t.Errorf("expected Low to be stored as %v, got %v", dexcomLowMgdL, result.Readings[1].Value)
+	}
+	for _, reading := range result.Readings {
+		if reading.Source != "import" {
+			t.Errorf("expected imported readings to have the import source, got %s", reading.Source)
+		}
+	}
+	if result.Errors[0].Line != 8 {
+		t.Errorf("expected the first error on line 8, got %d", result.Errors[0].Line)
+	}
+}
+
+func TestParseLibreInLocalTime(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*60*60)
+
+	result, err := Parse(strings.NewReader(libreExport), types.ImportFormatLibre, loc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Readings) != 3 || result.Skipped != 1 || result.Invalid != 1 {
+		t.Fatalf("expected 3 readings, 1 skipped and 1 invalid, got %d, %d and %d", len(result.Readings), result.Skipped, result.Invalid)
+	}
+
+	first := result.Readings[0]
+	if first.Unit != types.GlucoseUnitMmolL || first.Value != 6.5 {
+		t.Errorf("expected 6.5 mmol/L, got %v %s", first.Value, first.Unit)
+	}
+	if !first.MeasuredAt.Equal(time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected 08:00 local to be midnight UTC, got %v", first.MeasuredAt)
+	}
+	if result.Readings[1].Value != 7.2 {
+		t.Errorf("expected scan value 7.2 with a decimal comma, got %v", result.Readings[1].Value)
+	}
+	if result.Readings[2].Value == 0 || result.Readings[2].Source != "import" {
+		t.Errorf("expected the strip reading to be imported, got %v from %s", result.Readings[2].Value, result.Readings[2].Source)
+	}
+}
+
+func TestParseRejectsUnknownOrMismatchedFormat(t *testing.T) {
+	if _, err := Parse(strings.NewReader("a,b,c\n1,2,3\n"), "", time.UTC); err == nil {
+		t.Error("expected an error for an unknown file")
+	}
+	if _, err := Parse(strings.NewReader(dexcomExport), types.ImportFormatLibre, time.UTC); err == nil {
+		t.Error("expected an error when the format does not match")
+	}
+}
+
+func TestDedupKeyAcrossUnits(t *testing.T) {
+	at := time.Date(2025, 1, 15, 8, 0, 10, 0, time.UTC)
+	mgdl := &types.GlucoseReading{Value: 99, Unit: types.GlucoseUnitMgdL, MeasuredAt: at}
+	mmol := &types.GlucoseReading{Value: 5.5, Unit: types.GlucoseUnitMmolL, MeasuredAt: at.Add(30 * time.Second)}
+
+	if dedupKey(mgdl) != dedupKey(mmol) {
+		t.Errorf("expected %s and %s to match", dedupKey(mgdl), dedupKey(mmol))
+	}
+}
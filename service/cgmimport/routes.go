@@ -0,0 +1,110 @@
+package cgmimport
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+// maxFileSize is the largest export accepted, roughly a year of CGM data
+const maxFileSize = 16 * 1024 * 1024
+
+// maxUploadSize is the largest upload request, the file plus the rest of the form
+const maxUploadSize = 20 * 1024 * 1024
+
+// UploadPath is where exports are uploaded, relative to the API group
+const UploadPath = "/glucose/imports"
+
+type Handler struct {
+	store        types.GlucoseStore
+	profileStore types.ProfileStore
+	userStore    types.UserStore
+	jobs         *Registry
+}
+
+func NewHandler(store types.GlucoseStore, profileStore types.ProfileStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, profileStore: profileStore, userStore: userStore, jobs: NewRegistry()}
+}
+
+// RegisterRoutes for Fiber
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/glucose/imports", auth.WithSharedAccess(h.handleGetImports, h.userStore, types.ShareScopeGlucose))
+	router.Post(UploadPath, utils.LimitBody(maxUploadSize, nil), auth.WithJWTAuth(h.handleCreateImport, h.userStore))
+	router.Get("/glucose/imports/:id", auth.WithSharedAccess(h.handleGetImport, h.userStore, types.ShareScopeGlucose))
+}
+
+// Handler for uploading a CGM export. The file is parsed straight away so format
+// problems are reported immediately, and the readings are stored in the background.
+func (h *Handler) handleCreateImport(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	format := c.FormValue("format")
+	if format != "" && format != types.ImportFormatDexcom && format != types.ImportFormatLibre {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "format must be dexcom or libre"})
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Missing file"})
+	}
+	if fileHeader.Size > maxFileSize {
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{"error": "File is too large"})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Error reading file: %v", err)})
+	}
+	defer file.Close()
+
+	// The upload is only valid for the lifetime of the request
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Error reading file: %v", err)})
+	}
+
+	// Export timestamps are local device time
+	profile, err := h.profileStore.GetProfileByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting profile: %v", err)})
+	}
+
+	parsed, err := Parse(bytes.NewReader(data), format, profile.Location())
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	job, err := h.jobs.Create(userID, parsed.Format, fileHeader.Filename)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error creating import: %v", err)})
+	}
+
+	go h.jobs.Run(h.store, job.ID, userID, parsed)
+
+	return c.Status(fiber.StatusAccepted).JSON(job)
+}
+
+// Handler for listing the user's recent imports
+func (h *Handler) handleGetImports(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"imports": h.jobs.List(userID)})
+}
+
+// Handler for polling an import's progress and summary
+func (h *Handler) handleGetImport(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	job, ok := h.jobs.Get(c.Params("id"), userID)
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Import not found"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(job)
+}
@@ -0,0 +1,158 @@
+package dietaryrestriction
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+type Handler struct {
+	store     types.DietaryRestrictionStore
+	userStore types.UserStore
+}
+
+func NewHandler(store types.DietaryRestrictionStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, userStore: userStore}
+}
+
+// RegisterRoutes for Fiber
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/dietary-restrictions/options", h.handleGetOptions)
+	router.Get("/user/me/dietary-restrictions", auth.WithSharedAccess(h.handleGetDietaryRestrictions, h.userStore, types.ShareScopeHealth))
+	router.Put("/user/me/dietary-restrictions", auth.WithJWTAuth(h.handleReplaceDietaryRestrictions, h.userStore))
+	router.Post("/user/me/dietary-restrictions", auth.WithJWTAuth(h.handleAddDietaryRestrictions, h.userStore))
+	router.Delete("/user/me/dietary-restrictions/:id", auth.WithJWTAuth(h.handleDeleteDietaryRestriction, h.userStore))
+	router.Get("/users/:id/dietary-restrictions", auth.WithJWTAuth(h.handleGetUserDietaryRestrictions, h.userStore))
+}
+
+// Handler for listing the accepted dietary restriction values
+func (h *Handler) handleGetOptions(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"options": types.DietaryRestrictionOptions})
+}
+
+// Handler for listing the current user's dietary restrictions
+func (h *Handler) handleGetDietaryRestrictions(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	restrictions, err := h.store.GetDietaryRestrictionsByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting dietary restrictions: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"restrictions": restrictions})
+}
+
+// Handler for replacing all of the current user's dietary restrictions
+func (h *Handler) handleReplaceDietaryRestrictions(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.UpdateDietaryRestrictionsPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	if err := h.store.ReplaceDietaryRestrictions(c.Context(), userID, payload.Restrictions); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error replacing dietary restrictions: %v", err)})
+	}
+
+	return h.handleGetDietaryRestrictions(c)
+}
+
+// Handler for adding dietary restrictions for the current user
+func (h *Handler) handleAddDietaryRestrictions(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.CreateDietaryRestrictionsPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	if err := h.store.AddDietaryRestrictions(c.Context(), userID, payload.Restrictions); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error adding dietary restrictions: %v", err)})
+	}
+
+	restrictions, err := h.store.GetDietaryRestrictionsByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting dietary restrictions: %v", err)})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"restrictions": restrictions})
+}
+
+// Handler for removing one of the current user's dietary restrictions
+func (h *Handler) handleDeleteDietaryRestriction(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+
+	deleted, err := h.store.DeleteDietaryRestriction(c.Context(), int32(intID), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error deleting dietary restriction: %v", err)})
+	}
+	if !deleted {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Dietary restriction not found"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Dietary restriction deleted successfully"})
+}
+
+// Handler for reading another user's dietary restrictions, limited to admins and nutritionists
+func (h *Handler) handleGetUserDietaryRestrictions(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	allowed, err := utils.HasAnyRole(userID, h.userStore, "admin", "nutritionist")
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting user role by id: %v", err)})
+	}
+	if !allowed {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Access denied"})
+	}
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+	id := int32(intID)
+
+	// Check if the user exists in the database
+	if _, err := h.userStore.GetUserByID(id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("%v", err)})
+	}
+
+	restrictions, err := h.store.GetDietaryRestrictionsByUserID(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting dietary restrictions: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"restrictions": restrictions})
+}
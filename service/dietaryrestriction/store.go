@@ -0,0 +1,112 @@
+package dietaryrestriction
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// GetDietaryRestrictionsByUserID fetches the user's dietary restrictions from the database
+func (s *Store) GetDietaryRestrictionsByUserID(userID int32) ([]*types.DietaryRestriction, error) {
+	rows, err := s.db.GetDietaryRestrictionsByUserID(context.Background(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	restrictions := make([]*types.DietaryRestriction, 0, len(rows))
+	for _, row := range rows {
+		restrictions = append(restrictions, &types.DietaryRestriction{
+			DietaryRestrictionID: row.DietaryRestrictionID,
+			UserID:               row.UserID,
+			RestrictionName:      row.DietaryRestrictionName,
+			CreatedAt:            row.CreatedAt,
+		})
+	}
+
+	return restrictions, nil
+}
+
+// AddDietaryRestrictions adds the dietary restrictions the user does not have yet
+func (s *Store) AddDietaryRestrictions(ctx context.Context, userID int32, restrictions []string) error {
+	existing, err := s.GetDietaryRestrictionsByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(existing))
+	for _, restriction := range existing {
+		seen[strings.ToLower(restriction.RestrictionName)] = true
+	}
+
+	var missing []string
+	for _, name := range normalize(restrictions) {
+		if !seen[strings.ToLower(name)] {
+			missing = append(missing, name)
+		}
+	}
+
+	return s.db.RunInTx(ctx, func(q *database.Queries) error {
+		return createDietaryRestrictions(ctx, q, userID, missing)
+	})
+}
+
+// ReplaceDietaryRestrictions replaces all of the user's dietary restrictions in a single transaction
+func (s *Store) ReplaceDietaryRestrictions(ctx context.Context, userID int32, restrictions []string) error {
+	return s.db.RunInTx(ctx, func(q *database.Queries) error {
+		if err := q.DeleteDietaryRestrictionsByUserID(ctx, userID); err != nil {
+			return err
+		}
+
+		return createDietaryRestrictions(ctx, q, userID, normalize(restrictions))
+	})
+}
+
+// DeleteDietaryRestriction deletes one of the user's dietary restrictions. It returns false if it did not exist.
+func (s *Store) DeleteDietaryRestriction(ctx context.Context, dietaryRestrictionID int32, userID int32) (bool, error) {
+	rows, err := s.db.DeleteDietaryRestriction(ctx, database.DeleteDietaryRestrictionParams{
+		DietaryRestrictionID: dietaryRestrictionID,
+		UserID:               userID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func createDietaryRestrictions(ctx context.Context, q *database.Queries, userID int32, names []string) error {
+	for _, name := range names {
+		err := q.CreateDietaryRestriction(ctx, database.CreateDietaryRestrictionParams{
+			UserID:                 userID,
+			DietaryRestrictionName: name,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// normalize drops empty and duplicate names. Restrictions come from a fixed vocabulary,
+// so they are stored in lower case.
+func normalize(names []string) []string {
+	result := utils.UniqueNames(names)
+	for i, name := range result {
+		result[i] = strings.ToLower(name)
+	}
+
+	return result
+}
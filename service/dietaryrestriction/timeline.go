@@ -0,0 +1,31 @@
+package dietaryrestriction
+
+import "github.com/jayden1905/abundance/types"
+
+// TimelineTypes lists the event types of the store
+func (s *Store) TimelineTypes() []string {
+	return []string{types.TimelineDietaryRestriction}
+}
+
+// TimelineEvents returns the user's dietary restrictions added on the query's page
+func (s *Store) TimelineEvents(userID int32, query types.TimelineQuery) ([]*types.TimelineEvent, error) {
+	restrictions, err := s.GetDietaryRestrictionsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*types.TimelineEvent, 0)
+	for _, restriction := range restrictions {
+		if !query.Includes(types.TimelineDietaryRestriction, restriction.CreatedAt, restriction.DietaryRestrictionID) {
+			continue
+		}
+		events = append(events, &types.TimelineEvent{
+			Type:       types.TimelineDietaryRestriction,
+			ID:         restriction.DietaryRestrictionID,
+			OccurredAt: restriction.CreatedAt,
+			Data:       restriction,
+		})
+	}
+
+	return events, nil
+}
@@ -2,4 +2,16 @@ package email
 
 type Mailer interface {
 	SendVerificationEmail(toEmail string, token string) error
+	SendPasswordResetEmail(toEmail string, token string) error
+}
+
+// AlertMailer sends glucose alerts
+type AlertMailer interface {
+	SendGlucoseAlertEmail(toEmail string, message string, triggeredAt string) error
+	SendCaregiverAlertEmail(toEmail string, ownerName string, message string, triggeredAt string) error
+}
+
+// ShareMailer sends invitations to follow a user's data
+type ShareMailer interface {
+	SendShareInvitationEmail(toEmail string, ownerName string, token string) error
 }
@@ -6,6 +6,7 @@ import (
 	"html/template"
 	"log"
 	"net/smtp"
+	"net/url"
 	"os"
 
 	"github.com/jayden1905/abundance/config"
@@ -33,13 +34,95 @@ func NewEmailService() *EmailService {
 
 // SendVerificationEmail sends a verification email with a token link in HTML format
 func (es *EmailService) SendVerificationEmail(toEmail string, token string) error {
-	auth := smtp.PlainAuth("", es.SMTPUsername, es.SMTPPassword, es.SMTPHost)
-
 	// Verification link
 	verificationLink := fmt.Sprintf("%s/api/v1/user/verify/email?token=%s", config.Envs.BackendHost, token)
 
+	// Prepare template data
+	data := struct {
+		VerificationLink string
+	}{
+		VerificationLink: verificationLink,
+	}
+
+	return es.sendTemplate(toEmail, "Verify Your Account", "templates/verify_email.html", data)
+}
+
+// SendPasswordResetEmail sends a password reset email with a single-use token link in HTML format
+func (es *EmailService) SendPasswordResetEmail(toEmail string, token string) error {
+	// Reset link handled by the frontend
+	resetLink := fmt.Sprintf("%s/reset-password?token=%s", config.Envs.PublicHost, url.QueryEscape(token))
+
+	// Prepare template data
+	data := struct {
+		ResetLink        string
+		ExpiresInMinutes int64
+	}{
+		ResetLink:        resetLink,
+		ExpiresInMinutes: config.Envs.PasswordResetExpirationInSeconds / 60,
+	}
+
+	return es.sendTemplate(toEmail, "Reset Your Password", "templates/reset_password.html", data)
+}
+
+// SendGlucoseAlertEmail sends a glucose alert in HTML format
+func (es *EmailService) SendGlucoseAlertEmail(toEmail string, message string, triggeredAt string) error {
+	// Prepare template data
+	data := struct {
+		OwnerName   string
+		Message     string
+		TriggeredAt string
+		AlertsLink  string
+	}{
+		Message:     message,
+		TriggeredAt: triggeredAt,
+		AlertsLink:  fmt.Sprintf("%s/alerts", config.Envs.PublicHost),
+	}
+
+	return es.sendTemplate(toEmail, message, "templates/glucose_alert.html", data)
+}
+
+// SendCaregiverAlertEmail forwards a user's glucose alert to a caregiver in HTML format
+func (es *EmailService) SendCaregiverAlertEmail(toEmail string, ownerName string, message string, triggeredAt string) error {
+	// Prepare template data
+	data := struct {
+		OwnerName   string
+		Message     string
+		TriggeredAt string
+		AlertsLink  string
+	}{
+		OwnerName:   ownerName,
+		Message:     message,
+		TriggeredAt: triggeredAt,
+		AlertsLink:  fmt.Sprintf("%s/shared", config.Envs.PublicHost),
+	}
+
+	return es.sendTemplate(toEmail, fmt.Sprintf("%s: %s", ownerName, message), "templates/glucose_alert.html", data)
+}
+
+// SendShareInvitationEmail invites someone to follow the owner's data in HTML format
+func (es *EmailService) SendShareInvitationEmail(toEmail string, ownerName string, token string) error {
+	// Invitation link handled by the frontend
+	acceptLink := fmt.Sprintf("%s/shares/accept?token=%s", config.Envs.PublicHost, url.QueryEscape(token))
+
+	// Prepare template data
+	data := struct {
+		OwnerName     string
+		AcceptLink    string
+		ExpiresInDays int
+	}{
+		OwnerName:     ownerName,
+		AcceptLink:    acceptLink,
+		ExpiresInDays: 7,
+	}
+
+	return es.sendTemplate(toEmail, fmt.Sprintf("%s wants to share their health data with you", ownerName), "templates/share_invitation.html", data)
+}
+
+// sendTemplate renders an HTML template with the given data and sends it
+func (es *EmailService) sendTemplate(toEmail string, subjectLine string, tmplPath string, data interface{}) error {
+	auth := smtp.PlainAuth("", es.SMTPUsername, es.SMTPPassword, es.SMTPHost)
+
 	// Load the HTML template
-	tmplPath := "templates/verify_email.html"
 	tmplContent, err := os.ReadFile(tmplPath)
 	if err != nil {
 		log.Printf("Error reading email template: %v", err)
@@ -47,19 +130,12 @@ func (es *EmailService) SendVerificationEmail(toEmail string, token string) erro
 	}
 
 	// Parse the template
-	tmpl, err := template.New("verify_email").Parse(string(tmplContent))
+	tmpl, err := template.New(tmplPath).Parse(string(tmplContent))
 	if err != nil {
 		log.Printf("Error parsing email template: %v", err)
 		return err
 	}
 
-	// Prepare template data
-	data := struct {
-		VerificationLink string
-	}{
-		VerificationLink: verificationLink,
-	}
-
 	// Render the template
 	var renderedBody bytes.Buffer
 	if err := tmpl.Execute(&renderedBody, data); err != nil {
@@ -68,7 +144,7 @@ func (es *EmailService) SendVerificationEmail(toEmail string, token string) erro
 	}
 
 	// Create the email content
-	subject := "Subject: Verify Your Account\r\n"
+	subject := "Subject: " + subjectLine + "\r\n"
 	contentType := "MIME-Version: 1.0\r\nContent-Type: text/html; charset=\"UTF-8\";\r\n"
 	msg := []byte(subject + contentType + "\r\n" + renderedBody.String())
 
@@ -0,0 +1,140 @@
+package food
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+// BundledCatalogue is the USDA FoodData Central subset shipped with the repository
+//
+//go:embed data/foods.csv
+var BundledCatalogue string
+
+var catalogueColumns = []string{"name", "category", "energy_kcal", "carbs_g", "protein_g", "fat_g", "fibre_g", "sugar_g", "servings"}
+
+// ParseCatalogue reads foods from a catalogue CSV with nutrients per 100 g and
+// servings as "description:grams" pairs separated by "|"
+func ParseCatalogue(r io.Reader) ([]*types.Food, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading header: %v", err)
+	}
+	if strings.Join(header, ",") != strings.Join(catalogueColumns, ",") {
+		return nil, fmt.Errorf("unexpected header, expected %s", strings.Join(catalogueColumns, ","))
+	}
+
+	var foods []*types.Food
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading CSV: %v", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		values := make([]float64, 6)
+		for i := range values {
+			values[i], err = strconv.ParseFloat(record[i+2], 64)
+			if err != nil || values[i] < 0 {
+				return nil, fmt.Errorf("line %d: invalid %s %q", line, catalogueColumns[i+2], record[i+2])
+			}
+		}
+
+		servings, err := parseServings(record[8])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+
+		foods = append(foods, &types.Food{
+			Name:     strings.TrimSpace(record[0]),
+			Category: strings.TrimSpace(record[1]),
+			Source:   types.FoodSourceUSDA,
+			Per100g: types.Nutrients{
+				EnergyKcal: values[0],
+				CarbsG:     values[1],
+				ProteinG:   values[2],
+				FatG:       values[3],
+				FibreG:     values[4],
+				SugarG:     values[5],
+			},
+			Servings: servings,
+		})
+	}
+
+	return foods, nil
+}
+
+func parseServings(value string) ([]types.FoodServing, error) {
+	servings := []types.FoodServing{}
+	if strings.TrimSpace(value) == "" {
+		return servings, nil
+	}
+
+	for _, part := range strings.Split(value, "|") {
+		i := strings.LastIndex(part, ":")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid serving %q", part)
+		}
+
+		grams, err := strconv.ParseFloat(strings.TrimSpace(part[i+1:]), 64)
+		if err != nil || grams <= 0 {
+			return nil, fmt.Errorf("invalid serving weight in %q", part)
+		}
+
+		servings = append(servings, types.FoodServing{
+			Description: strings.TrimSpace(part[:i]),
+			Grams:       grams,
+		})
+	}
+
+	return servings, nil
+}
+
+// Catalogue caches the shared food catalogue in memory for searching. It only
+// changes when the loader runs, so it is reloaded after ttl.
+type Catalogue struct {
+	store types.FoodStore
+	ttl   time.Duration
+
+	mu       sync.Mutex
+	foods    []*types.Food
+	loadedAt time.Time
+}
+
+// NewCatalogue creates a catalogue cache backed by the given store
+func NewCatalogue(store types.FoodStore, ttl time.Duration) *Catalogue {
+	return &Catalogue{store: store, ttl: ttl}
+}
+
+// Foods returns the cached catalogue, reloading it from the store when stale
+func (c *Catalogue) Foods() ([]*types.Food, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.foods != nil && time.Since(c.loadedAt) < c.ttl {
+		return c.foods, nil
+	}
+
+	foods, err := c.store.GetCatalogueFoods()
+	if err != nil {
+		return nil, err
+	}
+
+	c.foods = foods
+	c.loadedAt = time.Now()
+
+	return c.foods, nil
+}
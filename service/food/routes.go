@@ -0,0 +1,192 @@
+package food
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/service/meal"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+type Handler struct {
+	store     types.FoodStore
+	userStore types.UserStore
+	catalogue *Catalogue
+}
+
+func NewHandler(store types.FoodStore, userStore types.UserStore) *Handler {
+	return &Handler{
+		store:     store,
+		userStore: userStore,
+		catalogue: NewCatalogue(store, 10*time.Minute),
+	}
+}
+
+// RegisterRoutes for Fiber
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/foods/search", auth.WithJWTAuth(h.handleSearchFoods, h.userStore))
+	router.Get("/foods/custom", auth.WithJWTAuth(h.handleGetCustomFoods, h.userStore))
+	router.Post("/foods/custom", auth.WithJWTAuth(h.handleCreateCustomFood, h.userStore))
+	router.Delete("/foods/custom/:id", auth.WithJWTAuth(h.handleDeleteCustomFood, h.userStore))
+	router.Get("/foods/:id", auth.WithJWTAuth(h.handleGetFoodByID, h.userStore))
+}
+
+// Handler for searching the catalogue and the user's custom foods by name
+func (h *Handler) handleSearchFoods(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	const (
+		defaultLimit = 20
+		maxLimit     = 100
+	)
+
+	query := strings.TrimSpace(c.Query("q"))
+	if len(query) < 2 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Query must be at least 2 characters"})
+	}
+
+	limit := defaultLimit
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= maxLimit {
+		limit = l
+	}
+
+	catalogue, err := h.catalogue.Foods()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting foods: %v", err)})
+	}
+
+	custom, err := h.store.GetCustomFoods(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting custom foods: %v", err)})
+	}
+
+	// Custom foods come first so they win ties with catalogue entries of the same name
+	foods := append(custom, catalogue...)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"query": query,
+		"foods": Search(foods, query, limit),
+	})
+}
+
+// Handler for getting a single food
+func (h *Handler) handleGetFoodByID(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+
+	food, err := h.store.GetFoodByID(int32(intID), userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("%v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(food)
+}
+
+// Handler for listing the user's custom foods
+func (h *Handler) handleGetCustomFoods(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	foods, err := h.store.GetCustomFoods(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting custom foods: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"foods": foods})
+}
+
+// Handler for adding a custom food
+func (h *Handler) handleCreateCustomFood(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.CreateCustomFoodPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	if payload.CarbsG+payload.ProteinG+payload.FatG > 100 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Nutrients cannot add up to more than 100 g per 100 g"})
+	}
+
+	energy := meal.EstimateCalories(payload.CarbsG, payload.ProteinG, payload.FatG)
+	if payload.EnergyKcal != nil {
+		energy = *payload.EnergyKcal
+	}
+
+	servings := make([]types.FoodServing, 0, len(payload.Servings))
+	for _, serving := range payload.Servings {
+		servings = append(servings, types.FoodServing{
+			Description: strings.TrimSpace(serving.Description),
+			Grams:       serving.Grams,
+		})
+	}
+
+	food := &types.Food{
+		Name:     strings.TrimSpace(payload.Name),
+		Category: strings.TrimSpace(payload.Category),
+		Source:   types.FoodSourceCustom,
+		UserID:   &userID,
+		Per100g: types.Nutrients{
+			EnergyKcal: energy,
+			CarbsG:     payload.CarbsG,
+			ProteinG:   payload.ProteinG,
+			FatG:       payload.FatG,
+			FibreG:     payload.FibreG,
+			SugarG:     payload.SugarG,
+		},
+		Servings: servings,
+	}
+
+	id, err := h.store.CreateCustomFood(c.Context(), food)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error creating custom food: %v", err)})
+	}
+
+	created, err := h.store.GetFoodByID(id, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting custom food: %v", err)})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(created)
+}
+
+// Handler for deleting a custom food
+func (h *Handler) handleDeleteCustomFood(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+
+	deleted, err := h.store.DeleteCustomFood(c.Context(), int32(intID), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error deleting custom food: %v", err)})
+	}
+	if !deleted {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Custom food not found"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Custom food deleted successfully"})
+}
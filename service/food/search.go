@@ -0,0 +1,155 @@
+package food
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+// Scores for how well a query word matches a word of the food name
+const (
+	scoreExact  = 3.0
+	scorePrefix = 2.0
+	scoreInside = 1.0
+	scoreFuzzy  = 0.75
+)
+
+type match struct {
+	food  *types.Food
+	score float64
+}
+
+// Search ranks the foods against the query and returns the best limit matches.
+// Every query word has to match a word of the name exactly, as a prefix, inside
+// it, or within a small edit distance to tolerate typos.
+func Search(foods []*types.Food, query string, limit int) []*types.Food {
+	terms := tokenize(query)
+	if len(terms) == 0 {
+		return []*types.Food{}
+	}
+	phrase := strings.Join(terms, " ")
+
+	var matches []match
+	for _, food := range foods {
+		words := tokenize(food.Name)
+
+		total := 0.0
+		for _, term := range terms {
+			best := 0.0
+			for i, word := range words {
+				score := wordScore(term, word)
+				// Earlier words describe the food best, e.g. "Rice, white" vs "Noodles, rice"
+				if score > 0 && i == 0 {
+					score += 0.5
+				}
+				best = max(best, score)
+			}
+			if best == 0 {
+				total = 0
+				break
+			}
+			total += best
+		}
+		if total == 0 {
+			continue
+		}
+
+		if strings.HasPrefix(strings.Join(words, " "), phrase) {
+			total += scorePrefix
+		}
+
+		matches = append(matches, match{food: food, score: total})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		if len(matches[i].food.Name) != len(matches[j].food.Name) {
+			return len(matches[i].food.Name) < len(matches[j].food.Name)
+		}
+		return matches[i].food.Name < matches[j].food.Name
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	results := make([]*types.Food, 0, len(matches))
+	for _, m := range matches {
+		results = append(results, m.food)
+	}
+
+	return results
+}
+
+func wordScore(term string, word string) float64 {
+	switch {
+	case term == word:
+		return scoreExact
+	case strings.HasPrefix(word, term):
+		return scorePrefix
+	case len(term) >= 3 && strings.Contains(word, term):
+		return scoreInside
+	}
+
+	allowed := maxTypos(term)
+	if allowed == 0 {
+		return 0
+	}
+
+	// Compare against the whole word and against a prefix of the same
+	// length, so a misspelt partial word still matches
+	if levenshtein(term, word) <= allowed {
+		return scoreFuzzy
+	}
+	if len(word) > len(term) && levenshtein(term, word[:len(term)]) <= allowed {
+		return scoreFuzzy
+	}
+
+	return 0
+}
+
+// maxTypos is the edit distance tolerated for a query word of this length
+func maxTypos(term string) int {
+	switch {
+	case len(term) >= 7:
+		return 2
+	case len(term) >= 4:
+		return 1
+	}
+	return 0
+}
+
+// tokenize lower-cases the text and splits it into words, dropping punctuation
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '%'
+	})
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
@@ -0,0 +1,104 @@
+package food
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+func bundledFoods(t *testing.T) []*types.Food {
+	foods, err := ParseCatalogue(strings.NewReader(BundledCatalogue))
+	if err != nil {
+		t.Fatalf("error parsing the bundled catalogue: %v", err)
+	}
+	return foods
+}
+
+func TestParseBundledCatalogue(t *testing.T) {
+	foods := bundledFoods(t)
+	if len(foods) < 50 {
+		t.Fatalf("expected the bundled catalogue to have at least 50 foods, got %d", len(foods))
+	}
+
+	seen := make(map[string]bool)
+	for _, food := range foods {
+		if seen[food.Name] {
+			t.Errorf("duplicate food %q", food.Name)
+		}
+		seen[food.Name] = true
+
+		n := food.Per100g
+		if n.CarbsG+n.ProteinG+n.FatG > 100.5 {
+			t.Errorf("%q has more than 100 g of macronutrients per 100 g", food.Name)
+		}
+	}
+
+	banana := Search(foods, "banana", 1)[0]
+	if banana.Per100g.CarbsG != 22.84 || len(banana.Servings) != 2 || banana.Servings[0].Grams != 118 {
+		t.Errorf("unexpected banana entry: %+v", banana)
+	}
+}
+
+func TestParseCatalogueRejectsBadRows(t *testing.T) {
+	header := strings.Join(catalogueColumns, ",") + "\n"
+
+	if _, err := ParseCatalogue(strings.NewReader(header + "Apple,Fruits,52,x,0,0,0,0,\n")); err == nil {
+		t.Error("expected an error for a non-numeric nutrient")
+	}
+	if _, err := ParseCatalogue(strings.NewReader(header + "Apple,Fruits,52,14,0,0,0,0,1 medium\n")); err == nil {
+		t.Error("expected an error for a serving without a weight")
+	}
+	if _, err := ParseCatalogue(strings.NewReader("name,kcal\n")); err == nil {
+		t.Error("expected an error for an unknown header")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	foods := bundledFoods(t)
+
+	cases := []struct {
+		query    string
+		expected string
+	}{
+		{"white rice", "Rice, white, long-grain, regular, cooked"},
+		{"brown rice", "Rice, brown, medium-grain, cooked"},
+		{"chick", "Chickpeas, mature seeds, cooked, boiled"},
+		{"bananna", "Bananas, raw"},
+		{"brocoli cooked", "Broccoli, cooked, boiled, drained"},
+		{"GREEK yogurt", "Yogurt, Greek, plain, nonfat"},
+	}
+
+	for _, c := range cases {
+		results := Search(foods, c.query, 5)
+		if len(results) == 0 {
+			t.Errorf("expected results for %q", c.query)
+			continue
+		}
+		if results[0].Name != c.expected {
+			t.Errorf("expected %q to find %q first, got %q", c.query, c.expected, results[0].Name)
+		}
+	}
+
+	// Foods named after the query rank above foods that only mention it
+	results := Search(foods, "rice", 3)
+	if len(results) != 3 || !strings.HasPrefix(results[0].Name, "Rice") || !strings.HasPrefix(results[1].Name, "Rice") {
+		t.Errorf("expected rice dishes first, got %v", results)
+	}
+
+	if results := Search(foods, "xylophone", 5); len(results) != 0 {
+		t.Errorf("expected no results, got %d", len(results))
+	}
+	if results := Search(foods, "raw", 3); len(results) != 3 {
+		t.Errorf("expected the results to be limited to 3, got %d", len(results))
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	if d := levenshtein("kitten", "sitting"); d != 3 {
+		t.Errorf("expected distance 3, got %d", d)
+	}
+	if d := levenshtein("", "abc"); d != 3 {
+		t.Errorf("expected distance 3, got %d", d)
+	}
+}
@@ -0,0 +1,241 @@
+package food
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries. Foods are saved with
+// their servings in a transaction.
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// GetCatalogueFoods fetches every food of the shared catalogue with its servings
+func (s *Store) GetCatalogueFoods() ([]*types.Food, error) {
+	rows, err := s.db.GetCatalogueFoods(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	servings, err := s.db.GetCatalogueFoodServings(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return withServings(rows, servings), nil
+}
+
+// GetCustomFoods fetches the foods the user added with their servings
+func (s *Store) GetCustomFoods(userID int32) ([]*types.Food, error) {
+	owner := sql.NullInt32{Int32: userID, Valid: true}
+
+	rows, err := s.db.GetCustomFoodsByUserID(context.Background(), owner)
+	if err != nil {
+		return nil, err
+	}
+
+	servings, err := s.db.GetCustomFoodServingsByUserID(context.Background(), owner)
+	if err != nil {
+		return nil, err
+	}
+
+	return withServings(rows, servings), nil
+}
+
+// GetFoodByID fetches a catalogue food or one of the user's custom foods
+func (s *Store) GetFoodByID(id int32, userID int32) (*types.Food, error) {
+	row, err := s.db.GetFoodByID(context.Background(), database.GetFoodByIDParams{
+		FoodID: id,
+		UserID: sql.NullInt32{Int32: userID, Valid: true},
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("food not found")
+		}
+		return nil, err
+	}
+
+	servings, err := s.db.GetFoodServingsByFoodID(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	return withServings([]database.Food{row}, servings)[0], nil
+}
+
+// CreateCustomFood stores a food owned by the user and returns its ID
+func (s *Store) CreateCustomFood(ctx context.Context, food *types.Food) (int32, error) {
+	var id int32
+
+	err := s.db.RunInTx(ctx, func(q *database.Queries) error {
+		var err error
+		id, err = createFood(ctx, q, food)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// DeleteCustomFood deletes one of the user's foods. It returns false if it did not exist.
+func (s *Store) DeleteCustomFood(ctx context.Context, id int32, userID int32) (bool, error) {
+	rows, err := s.db.DeleteCustomFood(ctx, database.DeleteCustomFoodParams{
+		FoodID: id,
+		UserID: sql.NullInt32{Int32: userID, Valid: true},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+// SaveCatalogueFoods adds the foods to the shared catalogue, updating the nutrients
+// and servings of foods that are already there. It returns how many foods were
+// created and updated.
+func (s *Store) SaveCatalogueFoods(ctx context.Context, foods []*types.Food) (int, int, error) {
+	var created, updated int
+
+	err := s.db.RunInTx(ctx, func(q *database.Queries) error {
+		for _, food := range foods {
+			existing, err := q.GetCatalogueFoodByName(ctx, food.Name)
+			if err == sql.ErrNoRows {
+				if _, err := createFood(ctx, q, food); err != nil {
+					return err
+				}
+				created++
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			err = q.UpdateFoodNutrients(ctx, database.UpdateFoodNutrientsParams{
+				Category:   food.Category,
+				EnergyKcal: food.Per100g.EnergyKcal,
+				CarbsG:     food.Per100g.CarbsG,
+				ProteinG:   food.Per100g.ProteinG,
+				FatG:       food.Per100g.FatG,
+				FibreG:     food.Per100g.FibreG,
+				SugarG:     food.Per100g.SugarG,
+				FoodID:     existing.FoodID,
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := q.DeleteFoodServingsByFoodID(ctx, existing.FoodID); err != nil {
+				return err
+			}
+			if err := createServings(ctx, q, existing.FoodID, food.Servings); err != nil {
+				return err
+			}
+			updated++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return created, updated, nil
+}
+
+func createFood(ctx context.Context, q *database.Queries, food *types.Food) (int32, error) {
+	owner := sql.NullInt32{}
+	if food.UserID != nil {
+		owner = sql.NullInt32{Int32: *food.UserID, Valid: true}
+	}
+
+	id, err := q.CreateFood(ctx, database.CreateFoodParams{
+		Name:       food.Name,
+		Category:   food.Category,
+		Source:     database.FoodsSource(food.Source),
+		UserID:     owner,
+		EnergyKcal: food.Per100g.EnergyKcal,
+		CarbsG:     food.Per100g.CarbsG,
+		ProteinG:   food.Per100g.ProteinG,
+		FatG:       food.Per100g.FatG,
+		FibreG:     food.Per100g.FibreG,
+		SugarG:     food.Per100g.SugarG,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if err := createServings(ctx, q, int32(id), food.Servings); err != nil {
+		return 0, err
+	}
+
+	return int32(id), nil
+}
+
+func createServings(ctx context.Context, q *database.Queries, foodID int32, servings []types.FoodServing) error {
+	for _, serving := range servings {
+		err := q.CreateFoodServing(ctx, database.CreateFoodServingParams{
+			FoodID:      foodID,
+			Description: serving.Description,
+			Grams:       serving.Grams,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// withServings converts the rows and attaches their servings
+func withServings(rows []database.Food, servings []database.FoodServing) []*types.Food {
+	foods := make([]*types.Food, 0, len(rows))
+	byID := make(map[int32]*types.Food, len(rows))
+
+	for _, row := range rows {
+		food := &types.Food{
+			ID:       row.FoodID,
+			Name:     row.Name,
+			Category: row.Category,
+			Source:   string(row.Source),
+			Per100g: types.Nutrients{
+				EnergyKcal: row.EnergyKcal,
+				CarbsG:     row.CarbsG,
+				ProteinG:   row.ProteinG,
+				FatG:       row.FatG,
+				FibreG:     row.FibreG,
+				SugarG:     row.SugarG,
+			},
+			Servings:  []types.FoodServing{},
+			CreatedAt: row.CreatedAt,
+		}
+		if row.UserID.Valid {
+			owner := row.UserID.Int32
+			food.UserID = &owner
+		}
+
+		foods = append(foods, food)
+		byID[food.ID] = food
+	}
+
+	for _, serving := range servings {
+		if food, ok := byID[serving.FoodID]; ok {
+			food.Servings = append(food.Servings, types.FoodServing{
+				Description: serving.Description,
+				Grams:       serving.Grams,
+			})
+		}
+	}
+
+	return foods
+}
@@ -0,0 +1,208 @@
+package glucose
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+type Handler struct {
+	store     types.GlucoseStore
+	alerter   types.GlucoseAlerter
+	userStore types.UserStore
+}
+
+// NewHandler creates the glucose handler. The alerter is optional and checks every new reading.
+func NewHandler(store types.GlucoseStore, alerter types.GlucoseAlerter, userStore types.UserStore) *Handler {
+	return &Handler{store: store, alerter: alerter, userStore: userStore}
+}
+
+// RegisterRoutes for Fiber
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/glucose/readings", auth.WithSharedAccess(h.handleGetReadings, h.userStore, types.ShareScopeGlucose))
+	router.Post("/glucose/readings", auth.WithJWTAuth(h.handleCreateReading, h.userStore))
+	router.Get("/glucose/readings/:id", auth.WithSharedAccess(h.handleGetReadingByID, h.userStore, types.ShareScopeGlucose))
+	router.Put("/glucose/readings/:id", auth.WithJWTAuth(h.handleUpdateReading, h.userStore))
+	router.Delete("/glucose/readings/:id", auth.WithJWTAuth(h.handleDeleteReading, h.userStore))
+}
+
+// Handler for listing the user's readings in a time range
+func (h *Handler) handleGetReadings(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Default to the last seven days when no range is given
+	from, to, err := utils.ParseTimeRange(c.Query("from"), c.Query("to"), 7*24*time.Hour)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	readings, err := h.store.GetGlucoseReadingsByTimeRange(userID, from, to)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting glucose readings: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"from":     from,
+		"to":       to,
+		"readings": readings,
+	})
+}
+
+// Handler for recording a new reading
+func (h *Handler) handleCreateReading(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.CreateGlucoseReadingPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	if !IsPlausible(payload.Value, payload.Unit) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Glucose value is out of range"})
+	}
+
+	if payload.Source == "" {
+		payload.Source = "manual"
+	}
+	if payload.MeasurementContext == "" {
+		payload.MeasurementContext = "other"
+	}
+
+	reading := &types.GlucoseReading{
+		UserID:             userID,
+		Value:              payload.Value,
+		Unit:               payload.Unit,
+		Source:             payload.Source,
+		MeasurementContext: payload.MeasurementContext,
+		MeasuredAt:         payload.MeasuredAt,
+	}
+
+	id, err := h.store.CreateGlucoseReading(c.Context(), reading)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error creating glucose reading: %v", err)})
+	}
+
+	created, err := h.store.GetGlucoseReadingByID(id, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting glucose reading: %v", err)})
+	}
+
+	if h.alerter != nil {
+		h.alerter.CheckReading(created)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(created)
+}
+
+// Handler for getting a single reading
+func (h *Handler) handleGetReadingByID(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+
+	reading, err := h.store.GetGlucoseReadingByID(int32(intID), userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("%v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(reading)
+}
+
+// Handler for editing a reading
+func (h *Handler) handleUpdateReading(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+	id := int32(intID)
+
+	// Parse JSON payload
+	var payload types.UpdateGlucoseReadingPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	if !IsPlausible(payload.Value, payload.Unit) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Glucose value is out of range"})
+	}
+
+	// Check if the reading exists and belongs to the user
+	if _, err := h.store.GetGlucoseReadingByID(id, userID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("%v", err)})
+	}
+
+	err = h.store.UpdateGlucoseReading(c.Context(), &types.GlucoseReading{
+		ID:                 id,
+		UserID:             userID,
+		Value:              payload.Value,
+		Unit:               payload.Unit,
+		Source:             payload.Source,
+		MeasurementContext: payload.MeasurementContext,
+		MeasuredAt:         payload.MeasuredAt,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error updating glucose reading: %v", err)})
+	}
+
+	updated, err := h.store.GetGlucoseReadingByID(id, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting glucose reading: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(updated)
+}
+
+// Handler for deleting a reading
+func (h *Handler) handleDeleteReading(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+	id := int32(intID)
+
+	// Check if the reading exists and belongs to the user
+	if _, err := h.store.GetGlucoseReadingByID(id, userID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("%v", err)})
+	}
+
+	if err := h.store.DeleteGlucoseReading(c.Context(), id, userID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error deleting glucose reading: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Glucose reading deleted successfully"})
+}
@@ -0,0 +1,118 @@
+package glucose
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// GetGlucoseReadingByID fetches a single reading owned by the user
+func (s *Store) GetGlucoseReadingByID(id int32, userID int32) (*types.GlucoseReading, error) {
+	reading, err := s.db.GetGlucoseReadingByID(context.Background(), database.GetGlucoseReadingByIDParams{
+		ReadingID: id,
+		UserID:    userID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("glucose reading not found")
+		}
+		return nil, err
+	}
+
+	return toGlucoseReading(reading), nil
+}
+
+// GetGlucoseReadingsByTimeRange fetches the user's readings in [start, end) ordered by time
+func (s *Store) GetGlucoseReadingsByTimeRange(userID int32, start time.Time, end time.Time) ([]*types.GlucoseReading, error) {
+	readings, err := s.db.GetGlucoseReadingsByTimeRange(context.Background(), database.GetGlucoseReadingsByTimeRangeParams{
+		UserID:    userID,
+		StartTime: start.UTC(),
+		EndTime:   end.UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	allReadings := make([]*types.GlucoseReading, 0, len(readings))
+	for _, reading := range readings {
+		allReadings = append(allReadings, toGlucoseReading(reading))
+	}
+
+	return allReadings, nil
+}
+
+// CreateGlucoseReading stores a new reading and returns its ID
+func (s *Store) CreateGlucoseReading(ctx context.Context, reading *types.GlucoseReading) (int32, error) {
+	id, err := s.db.CreateGlucoseReading(ctx, database.CreateGlucoseReadingParams{
+		UserID:             reading.UserID,
+		Value:              reading.Value,
+		Unit:               database.GlucoseReadingsUnit(reading.Unit),
+		Source:             database.GlucoseReadingsSource(reading.Source),
+		MeasurementContext: database.GlucoseReadingsMeasurementContext(reading.MeasurementContext),
+		MeasuredAt:         reading.MeasuredAt.UTC(),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(id), nil
+}
+
+// UpdateGlucoseReading updates a reading owned by the user
+func (s *Store) UpdateGlucoseReading(ctx context.Context, reading *types.GlucoseReading) error {
+	err := s.db.UpdateGlucoseReading(ctx, database.UpdateGlucoseReadingParams{
+		Value:              reading.Value,
+		Unit:               database.GlucoseReadingsUnit(reading.Unit),
+		Source:             database.GlucoseReadingsSource(reading.Source),
+		MeasurementContext: database.GlucoseReadingsMeasurementContext(reading.MeasurementContext),
+		MeasuredAt:         reading.MeasuredAt.UTC(),
+		ReadingID:          reading.ID,
+		UserID:             reading.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteGlucoseReading deletes a reading owned by the user
+func (s *Store) DeleteGlucoseReading(ctx context.Context, id int32, userID int32) error {
+	err := s.db.DeleteGlucoseReading(ctx, database.DeleteGlucoseReadingParams{
+		ReadingID: id,
+		UserID:    userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Convert the database reading to the reading type
+func toGlucoseReading(reading database.GlucoseReading) *types.GlucoseReading {
+	return &types.GlucoseReading{
+		ID:                 reading.ReadingID,
+		UserID:             reading.UserID,
+		Value:              reading.Value,
+		Unit:               string(reading.Unit),
+		Source:             string(reading.Source),
+		MeasurementContext: string(reading.MeasurementContext),
+		MeasuredAt:         reading.MeasuredAt,
+		CreatedAt:          reading.CreatedAt,
+		UpdatedAt:          reading.UpdatedAt,
+	}
+}
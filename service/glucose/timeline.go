@@ -0,0 +1,44 @@
+package glucose
+
+import (
+	"context"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+)
+
+// TimelineTypes lists the event types of the store
+func (s *Store) TimelineTypes() []string {
+	return []string{types.TimelineGlucoseReading}
+}
+
+// TimelineEvents returns up to the query's limit of the user's glucose readings on the
+// page, in the order they were measured
+func (s *Store) TimelineEvents(userID int32, query types.TimelineQuery) ([]*types.TimelineEvent, error) {
+	start, afterID := query.Bound(types.TimelineGlucoseReading)
+
+	rows, err := s.db.GetGlucoseReadingsForTimeline(context.Background(), database.GetGlucoseReadingsForTimelineParams{
+		UserID:       userID,
+		MeasuredAt:   start.UTC(),
+		MeasuredAt_2: start.UTC(),
+		ReadingID:    afterID,
+		MeasuredAt_3: query.End.UTC(),
+		Limit:        int32(query.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*types.TimelineEvent, 0, len(rows))
+	for _, row := range rows {
+		reading := toGlucoseReading(row)
+		events = append(events, &types.TimelineEvent{
+			Type:       types.TimelineGlucoseReading,
+			ID:         reading.ID,
+			OccurredAt: reading.MeasuredAt,
+			Data:       reading,
+		})
+	}
+
+	return events, nil
+}
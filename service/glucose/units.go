@@ -0,0 +1,36 @@
+package glucose
+
+import "github.com/jayden1905/abundance/types"
+
+// MgdLPerMmolL is the conversion factor between mmol/L and mg/dL for glucose
+const MgdLPerMmolL = 18.0182
+
+// ToMgdL converts a value in the given unit to mg/dL
+func ToMgdL(value float64, unit string) float64 {
+	if unit == types.GlucoseUnitMmolL {
+		return value * MgdLPerMmolL
+	}
+	return value
+}
+
+// FromMgdL converts a value in mg/dL to the given unit
+func FromMgdL(value float64, unit string) float64 {
+	if unit == types.GlucoseUnitMmolL {
+		return value / MgdLPerMmolL
+	}
+	return value
+}
+
+// ConvertValue converts a glucose value between mg/dL and mmol/L
+func ConvertValue(value float64, from string, to string) float64 {
+	if from == to {
+		return value
+	}
+	return FromMgdL(ToMgdL(value, from), to)
+}
+
+// IsPlausible reports whether the value is within what a meter or CGM can report
+func IsPlausible(value float64, unit string) bool {
+	mgdl := ToMgdL(value, unit)
+	return mgdl >= 10 && mgdl <= 1000
+}
@@ -0,0 +1,36 @@
+package glucose
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+func TestConvertValue(t *testing.T) {
+	mmol := ConvertValue(180, types.GlucoseUnitMgdL, types.GlucoseUnitMmolL)
+	if math.Abs(mmol-9.99) > 0.01 {
+		t.Errorf("expected 180 mg/dL to be about 9.99 mmol/L, got %v", mmol)
+	}
+
+	mgdl := ConvertValue(5.5, types.GlucoseUnitMmolL, types.GlucoseUnitMgdL)
+	if math.Abs(mgdl-99.1) > 0.1 {
+		t.Errorf("expected 5.5 mmol/L to be about 99.1 mg/dL, got %v", mgdl)
+	}
+
+	if ConvertValue(120, types.GlucoseUnitMgdL, types.GlucoseUnitMgdL) != 120 {
+		t.Error("expected same-unit conversion to be a no-op")
+	}
+}
+
+func TestIsPlausible(t *testing.T) {
+	if !IsPlausible(110, types.GlucoseUnitMgdL) {
+		t.Error("expected 110 mg/dL to be plausible")
+	}
+	if IsPlausible(110, types.GlucoseUnitMmolL) {
+		t.Error("expected 110 mmol/L to be implausible")
+	}
+	if IsPlausible(2, types.GlucoseUnitMgdL) {
+		t.Error("expected 2 mg/dL to be implausible")
+	}
+}
@@ -0,0 +1,77 @@
+package glycemic
+
+import (
+	"math"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+// ClassifyIndex classifies a glycemic index: low up to 55, high from 70
+func ClassifyIndex(gi float64) string {
+	switch {
+	case gi <= 55:
+		return types.GlycemicLow
+	case gi < 70:
+		return types.GlycemicMedium
+	}
+	return types.GlycemicHigh
+}
+
+// ClassifyLoad classifies the glycemic load of a serving or meal: low up to 10, high from 20
+func ClassifyLoad(gl float64) string {
+	switch {
+	case gl <= 10:
+		return types.GlycemicLow
+	case gl < 20:
+		return types.GlycemicMedium
+	}
+	return types.GlycemicHigh
+}
+
+// CarbsForPortion scales the reference serving's available carbohydrate to a portion in grams
+func CarbsForPortion(food *types.GlycemicIndexFood, grams float64) float64 {
+	if food.ServingSizeG <= 0 {
+		return 0
+	}
+	return food.AvailableCarbsG / food.ServingSizeG * grams
+}
+
+// GlycemicLoad is the glycemic index multiplied by the available carbohydrate in grams, divided by 100
+func GlycemicLoad(gi int, availableCarbsG float64) float64 {
+	return float64(gi) * availableCarbsG / 100
+}
+
+// Calculate fills in the glycemic load of each item, whose glycemic index and
+// available carbohydrate must already be set, and totals them for the meal
+func Calculate(items []*types.GlycemicLoadItem) *types.GlycemicLoadResult {
+	result := &types.GlycemicLoadResult{Items: items}
+
+	var load, carbs float64
+	for _, item := range items {
+		gl := GlycemicLoad(item.GlycemicIndex, item.AvailableCarbsG)
+		item.AvailableCarbsG = round(item.AvailableCarbsG)
+		item.GlycemicLoad = round(gl)
+		item.Classification = ClassifyLoad(gl)
+
+		load += gl
+		carbs += item.AvailableCarbsG
+	}
+
+	result.TotalAvailableCarbsG = round(carbs)
+	result.TotalGlycemicLoad = round(load)
+	result.Classification = ClassifyLoad(load)
+
+	// Foods without carbohydrate do not affect the meal's glycemic index
+	if carbs > 0 {
+		gi := load / carbs * 100
+		result.MealGlycemicIndex = round(gi)
+		result.MealGlycemicIndexClassification = ClassifyIndex(gi)
+	}
+
+	return result
+}
+
+// round rounds to one decimal place
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
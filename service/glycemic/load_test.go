@@ -0,0 +1,58 @@
+package glycemic
+
+import (
+	"testing"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+func TestClassify(t *testing.T) {
+	indexCases := map[float64]string{40: types.GlycemicLow, 55: types.GlycemicLow, 56: types.GlycemicMedium, 70: types.GlycemicHigh}
+	for gi, expected := range indexCases {
+		if got := ClassifyIndex(gi); got != expected {
+			t.Errorf("expected GI %v to be %s, got %s", gi, expected, got)
+		}
+	}
+
+	loadCases := map[float64]string{5: types.GlycemicLow, 10: types.GlycemicLow, 15: types.GlycemicMedium, 20: types.GlycemicHigh}
+	for gl, expected := range loadCases {
+		if got := ClassifyLoad(gl); got != expected {
+			t.Errorf("expected GL %v to be %s, got %s", gl, expected, got)
+		}
+	}
+}
+
+func TestCarbsForPortion(t *testing.T) {
+	rice := &types.GlycemicIndexFood{GlycemicIndex: 73, ServingSizeG: 150, AvailableCarbsG: 36}
+	if got := CarbsForPortion(rice, 75); got != 18 {
+		t.Errorf("expected half a serving to have 18 g of carbs, got %v", got)
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	result := Calculate([]*types.GlycemicLoadItem{
+		{Name: "White rice", GlycemicIndex: 73, AvailableCarbsG: 36},
+		{Name: "Lentils", GlycemicIndex: 32, AvailableCarbsG: 18},
+		{Name: "Chicken", GlycemicIndex: 0, AvailableCarbsG: 0},
+	})
+
+	if result.Items[0].GlycemicLoad != 26.3 || result.Items[0].Classification != types.GlycemicHigh {
+		t.Errorf("expected rice to have a high GL of 26.3, got %v (%s)", result.Items[0].GlycemicLoad, result.Items[0].Classification)
+	}
+	if result.Items[1].GlycemicLoad != 5.8 || result.Items[1].Classification != types.GlycemicLow {
+		t.Errorf("expected lentils to have a low GL of 5.8, got %v (%s)", result.Items[1].GlycemicLoad, result.Items[1].Classification)
+	}
+	if result.TotalAvailableCarbsG != 54 || result.TotalGlycemicLoad != 32 || result.Classification != types.GlycemicHigh {
+		t.Errorf("expected 54 g carbs and a high GL of 32, got %v g and %v (%s)", result.TotalAvailableCarbsG, result.TotalGlycemicLoad, result.Classification)
+	}
+	if result.MealGlycemicIndex != 59.3 || result.MealGlycemicIndexClassification != types.GlycemicMedium {
+		t.Errorf("expected a medium meal GI of 59.3, got %v (%s)", result.MealGlycemicIndex, result.MealGlycemicIndexClassification)
+	}
+}
+
+func TestCalculateWithoutCarbs(t *testing.T) {
+	result := Calculate([]*types.GlycemicLoadItem{{Name: "Egg"}})
+	if result.TotalGlycemicLoad != 0 || result.MealGlycemicIndex != 0 || result.Classification != types.GlycemicLow {
+		t.Errorf("expected a carb-free meal to have no glycemic load, got %+v", result)
+	}
+}
@@ -0,0 +1,110 @@
+package glycemic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+type Handler struct {
+	store     types.GlycemicIndexStore
+	userStore types.UserStore
+}
+
+func NewHandler(store types.GlycemicIndexStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, userStore: userStore}
+}
+
+// RegisterRoutes for Fiber
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/glycemic-index", auth.WithJWTAuth(h.handleGetGlycemicIndexFoods, h.userStore))
+	router.Post("/glycemic-load", auth.WithJWTAuth(h.handleCalculateGlycemicLoad, h.userStore))
+}
+
+// Handler for listing the glycemic index reference foods, optionally filtered by name
+func (h *Handler) handleGetGlycemicIndexFoods(c *fiber.Ctx) error {
+	foods, err := h.store.GetGlycemicIndexFoods()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting glycemic index foods: %v", err)})
+	}
+
+	if query := strings.ToLower(strings.TrimSpace(c.Query("q"))); query != "" {
+		filtered := []*types.GlycemicIndexFood{}
+		for _, food := range foods {
+			if strings.Contains(strings.ToLower(food.Name), query) {
+				filtered = append(filtered, food)
+			}
+		}
+		foods = filtered
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"foods": foods})
+}
+
+// Handler for calculating the glycemic load of a list of foods and portions
+func (h *Handler) handleCalculateGlycemicLoad(c *fiber.Ctx) error {
+	// Parse JSON payload
+	var payload types.GlycemicLoadPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	foods, err := h.store.GetGlycemicIndexFoods()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting glycemic index foods: %v", err)})
+	}
+
+	byID := make(map[int32]*types.GlycemicIndexFood, len(foods))
+	for _, food := range foods {
+		byID[food.ID] = food
+	}
+
+	items := make([]*types.GlycemicLoadItem, 0, len(payload.Items))
+	for i, p := range payload.Items {
+		switch {
+		case p.FoodID != 0:
+			food, ok := byID[p.FoodID]
+			if !ok {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Item %d: unknown food_id %d", i+1, p.FoodID)})
+			}
+			if p.Grams <= 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Item %d: grams is required with food_id", i+1)})
+			}
+
+			foodID, grams := p.FoodID, p.Grams
+			items = append(items, &types.GlycemicLoadItem{
+				FoodID:          &foodID,
+				Name:            food.Name,
+				Grams:           &grams,
+				GlycemicIndex:   food.GlycemicIndex,
+				AvailableCarbsG: CarbsForPortion(food, grams),
+			})
+
+		case p.GlycemicIndex != 0 && p.AvailableCarbsG != nil:
+			items = append(items, &types.GlycemicLoadItem{
+				Name:            strings.TrimSpace(p.Name),
+				GlycemicIndex:   p.GlycemicIndex,
+				AvailableCarbsG: *p.AvailableCarbsG,
+			})
+
+		default:
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Item %d: give either food_id and grams, or glycemic_index and available_carbs_g", i+1)})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(Calculate(items))
+}
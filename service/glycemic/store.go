@@ -0,0 +1,39 @@
+package glycemic
+
+import (
+	"context"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// GetGlycemicIndexFoods fetches the glycemic index reference table
+func (s *Store) GetGlycemicIndexFoods() ([]*types.GlycemicIndexFood, error) {
+	rows, err := s.db.GetGlycemicIndexFoods(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	foods := make([]*types.GlycemicIndexFood, 0, len(rows))
+	for _, row := range rows {
+		foods = append(foods, &types.GlycemicIndexFood{
+			ID:              row.GiFoodID,
+			Name:            row.Name,
+			GlycemicIndex:   int(row.GlycemicIndex),
+			Classification:  ClassifyIndex(float64(row.GlycemicIndex)),
+			ServingSizeG:    row.ServingSizeG,
+			AvailableCarbsG: row.AvailableCarbsG,
+		})
+	}
+
+	return foods, nil
+}
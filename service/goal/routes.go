@@ -0,0 +1,152 @@
+package goal
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+type Handler struct {
+	store     types.GoalStore
+	userStore types.UserStore
+}
+
+func NewHandler(store types.GoalStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, userStore: userStore}
+}
+
+// RegisterRoutes for Fiber
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/user/me/goals", auth.WithSharedAccess(h.handleGetGoals, h.userStore, types.ShareScopeHealth))
+	router.Put("/user/me/goals", auth.WithJWTAuth(h.handleReplaceGoals, h.userStore))
+	router.Post("/user/me/goals", auth.WithJWTAuth(h.handleAddGoals, h.userStore))
+	router.Delete("/user/me/goals/:id", auth.WithJWTAuth(h.handleDeleteGoal, h.userStore))
+	router.Get("/users/:id/goals", auth.WithJWTAuth(h.handleGetUserGoals, h.userStore))
+}
+
+// Handler for listing the current user's goals
+func (h *Handler) handleGetGoals(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	goals, err := h.store.GetGoalsByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting goals: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"goals": goals})
+}
+
+// Handler for replacing all of the current user's goals
+func (h *Handler) handleReplaceGoals(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.UpdateGoalsPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	if err := h.store.ReplaceGoals(c.Context(), userID, payload.Goals); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error replacing goals: %v", err)})
+	}
+
+	return h.handleGetGoals(c)
+}
+
+// Handler for adding goals for the current user
+func (h *Handler) handleAddGoals(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.CreateGoalsPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	if err := h.store.AddGoals(c.Context(), userID, payload.Goals); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error adding goals: %v", err)})
+	}
+
+	goals, err := h.store.GetGoalsByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting goals: %v", err)})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"goals": goals})
+}
+
+// Handler for removing one of the current user's goals
+func (h *Handler) handleDeleteGoal(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+
+	deleted, err := h.store.DeleteGoal(c.Context(), int32(intID), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error deleting goal: %v", err)})
+	}
+	if !deleted {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Goal not found"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Goal deleted successfully"})
+}
+
+// Handler for reading another user's goals, limited to admins and nutritionists
+func (h *Handler) handleGetUserGoals(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	allowed, err := utils.HasAnyRole(userID, h.userStore, "admin", "nutritionist")
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting user role by id: %v", err)})
+	}
+	if !allowed {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Access denied"})
+	}
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+	id := int32(intID)
+
+	// Check if the user exists in the database
+	if _, err := h.userStore.GetUserByID(id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("%v", err)})
+	}
+
+	goals, err := h.store.GetGoalsByUserID(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting goals: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"goals": goals})
+}
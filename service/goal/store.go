@@ -0,0 +1,101 @@
+package goal
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// GetGoalsByUserID fetches the user's goals from the database
+func (s *Store) GetGoalsByUserID(userID int32) ([]*types.Goal, error) {
+	rows, err := s.db.GetGoalsByUserID(context.Background(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	goals := make([]*types.Goal, 0, len(rows))
+	for _, row := range rows {
+		goals = append(goals, &types.Goal{
+			GoalID:    row.GoalID,
+			UserID:    row.UserID,
+			GoalName:  row.GoalName,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+
+	return goals, nil
+}
+
+// AddGoals adds the goals the user does not have yet
+func (s *Store) AddGoals(ctx context.Context, userID int32, goals []string) error {
+	existing, err := s.GetGoalsByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(existing))
+	for _, goal := range existing {
+		seen[strings.ToLower(goal.GoalName)] = true
+	}
+
+	var missing []string
+	for _, name := range utils.UniqueNames(goals) {
+		if !seen[strings.ToLower(name)] {
+			missing = append(missing, name)
+		}
+	}
+
+	return s.db.RunInTx(ctx, func(q *database.Queries) error {
+		return createGoals(ctx, q, userID, missing)
+	})
+}
+
+// ReplaceGoals replaces all of the user's goals in a single transaction
+func (s *Store) ReplaceGoals(ctx context.Context, userID int32, goals []string) error {
+	return s.db.RunInTx(ctx, func(q *database.Queries) error {
+		if err := q.DeleteGoalsByUserID(ctx, userID); err != nil {
+			return err
+		}
+
+		return createGoals(ctx, q, userID, utils.UniqueNames(goals))
+	})
+}
+
+// DeleteGoal deletes one of the user's goals. It returns false if it did not exist.
+func (s *Store) DeleteGoal(ctx context.Context, goalID int32, userID int32) (bool, error) {
+	rows, err := s.db.DeleteGoal(ctx, database.DeleteGoalParams{
+		GoalID: goalID,
+		UserID: userID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func createGoals(ctx context.Context, q *database.Queries, userID int32, names []string) error {
+	for _, name := range names {
+		err := q.CreateGoal(ctx, database.CreateGoalParams{
+			UserID:   userID,
+			GoalName: name,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package goal
+
+import "github.com/jayden1905/abundance/types"
+
+// TimelineTypes lists the event types of the store
+func (s *Store) TimelineTypes() []string {
+	return []string{types.TimelineGoal}
+}
+
+// TimelineEvents returns the user's goals added on the query's page. Users have few
+// goals, so they are selected in memory.
+func (s *Store) TimelineEvents(userID int32, query types.TimelineQuery) ([]*types.TimelineEvent, error) {
+	goals, err := s.GetGoalsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*types.TimelineEvent, 0)
+	for _, goal := range goals {
+		if !query.Includes(types.TimelineGoal, goal.CreatedAt, goal.GoalID) {
+			continue
+		}
+		events = append(events, &types.TimelineEvent{
+			Type:       types.TimelineGoal,
+			ID:         goal.GoalID,
+			OccurredAt: goal.CreatedAt,
+			Data:       goal,
+		})
+	}
+
+	return events, nil
+}
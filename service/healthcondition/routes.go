@@ -0,0 +1,152 @@
+package healthcondition
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+type Handler struct {
+	store     types.HealthConditionStore
+	userStore types.UserStore
+}
+
+func NewHandler(store types.HealthConditionStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, userStore: userStore}
+}
+
+// RegisterRoutes for Fiber
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/user/me/health-conditions", auth.WithSharedAccess(h.handleGetHealthConditions, h.userStore, types.ShareScopeHealth))
+	router.Put("/user/me/health-conditions", auth.WithJWTAuth(h.handleReplaceHealthConditions, h.userStore))
+	router.Post("/user/me/health-conditions", auth.WithJWTAuth(h.handleAddHealthConditions, h.userStore))
+	router.Delete("/user/me/health-conditions/:id", auth.WithJWTAuth(h.handleDeleteHealthCondition, h.userStore))
+	router.Get("/users/:id/health-conditions", auth.WithJWTAuth(h.handleGetUserHealthConditions, h.userStore))
+}
+
+// Handler for listing the current user's health conditions
+func (h *Handler) handleGetHealthConditions(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	conditions, err := h.store.GetHealthConditionsByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting health conditions: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"conditions": conditions})
+}
+
+// Handler for replacing all of the current user's health conditions
+func (h *Handler) handleReplaceHealthConditions(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.UpdateHealthConditionPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	if err := h.store.ReplaceHealthConditions(c.Context(), userID, payload.Conditions); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error replacing health conditions: %v", err)})
+	}
+
+	return h.handleGetHealthConditions(c)
+}
+
+// Handler for adding health conditions for the current user
+func (h *Handler) handleAddHealthConditions(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.CreateHealthConditionPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	if err := h.store.AddHealthConditions(c.Context(), userID, payload.Conditions); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error adding health conditions: %v", err)})
+	}
+
+	conditions, err := h.store.GetHealthConditionsByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting health conditions: %v", err)})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"conditions": conditions})
+}
+
+// Handler for removing one of the current user's health conditions
+func (h *Handler) handleDeleteHealthCondition(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+
+	deleted, err := h.store.DeleteHealthCondition(c.Context(), int32(intID), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error deleting health condition: %v", err)})
+	}
+	if !deleted {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Health condition not found"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Health condition deleted successfully"})
+}
+
+// Handler for reading another user's health conditions, limited to admins and nutritionists
+func (h *Handler) handleGetUserHealthConditions(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	allowed, err := utils.HasAnyRole(userID, h.userStore, "admin", "nutritionist")
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting user role by id: %v", err)})
+	}
+	if !allowed {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Access denied"})
+	}
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+	id := int32(intID)
+
+	// Check if the user exists in the database
+	if _, err := h.userStore.GetUserByID(id); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("%v", err)})
+	}
+
+	conditions, err := h.store.GetHealthConditionsByUserID(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting health conditions: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"conditions": conditions})
+}
@@ -0,0 +1,101 @@
+package healthcondition
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// GetHealthConditionsByUserID fetches the user's health conditions from the database
+func (s *Store) GetHealthConditionsByUserID(userID int32) ([]*types.HealthCondition, error) {
+	rows, err := s.db.GetHealthConditionsByUserID(context.Background(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := make([]*types.HealthCondition, 0, len(rows))
+	for _, row := range rows {
+		conditions = append(conditions, &types.HealthCondition{
+			HealthConditionID: row.HealthConditionID,
+			UserID:            row.UserID,
+			ConditionName:     row.HealthConditionName,
+			CreatedAt:         row.CreatedAt,
+		})
+	}
+
+	return conditions, nil
+}
+
+// AddHealthConditions adds the health conditions the user does not have yet
+func (s *Store) AddHealthConditions(ctx context.Context, userID int32, conditions []string) error {
+	existing, err := s.GetHealthConditionsByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(existing))
+	for _, condition := range existing {
+		seen[strings.ToLower(condition.ConditionName)] = true
+	}
+
+	var missing []string
+	for _, name := range utils.UniqueNames(conditions) {
+		if !seen[strings.ToLower(name)] {
+			missing = append(missing, name)
+		}
+	}
+
+	return s.db.RunInTx(ctx, func(q *database.Queries) error {
+		return createHealthConditions(ctx, q, userID, missing)
+	})
+}
+
+// ReplaceHealthConditions replaces all of the user's health conditions in a single transaction
+func (s *Store) ReplaceHealthConditions(ctx context.Context, userID int32, conditions []string) error {
+	return s.db.RunInTx(ctx, func(q *database.Queries) error {
+		if err := q.DeleteHealthConditionsByUserID(ctx, userID); err != nil {
+			return err
+		}
+
+		return createHealthConditions(ctx, q, userID, utils.UniqueNames(conditions))
+	})
+}
+
+// DeleteHealthCondition deletes one of the user's health conditions. It returns false if it did not exist.
+func (s *Store) DeleteHealthCondition(ctx context.Context, healthConditionID int32, userID int32) (bool, error) {
+	rows, err := s.db.DeleteHealthCondition(ctx, database.DeleteHealthConditionParams{
+		HealthConditionID: healthConditionID,
+		UserID:            userID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func createHealthConditions(ctx context.Context, q *database.Queries, userID int32, names []string) error {
+	for _, name := range names {
+		err := q.CreateHealthCondition(ctx, database.CreateHealthConditionParams{
+			UserID:              userID,
+			HealthConditionName: name,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package healthcondition
+
+import "github.com/jayden1905/abundance/types"
+
+// TimelineTypes lists the event types of the store
+func (s *Store) TimelineTypes() []string {
+	return []string{types.TimelineHealthCondition}
+}
+
+// TimelineEvents returns the user's health conditions added on the query's page. The
+// list is short, so it is filtered here rather than in the query.
+func (s *Store) TimelineEvents(userID int32, query types.TimelineQuery) ([]*types.TimelineEvent, error) {
+	conditions, err := s.GetHealthConditionsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*types.TimelineEvent, 0)
+	for _, condition := range conditions {
+		if !query.Includes(types.TimelineHealthCondition, condition.CreatedAt, condition.HealthConditionID) {
+			continue
+		}
+		events = append(events, &types.TimelineEvent{
+			Type:       types.TimelineHealthCondition,
+			ID:         condition.HealthConditionID,
+			OccurredAt: condition.CreatedAt,
+			Data:       condition,
+		})
+	}
+
+	return events, nil
+}
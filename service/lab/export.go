@@ -0,0 +1,76 @@
+package lab
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+// CSVHeader is the first row of a lab result export
+var CSVHeader = []string{
+	"collected_at",
+	"test_code",
+	"test_name",
+	"value",
+	"unit",
+	"reference_low",
+	"reference_high",
+	"flag",
+	"lab_name",
+	"notes",
+}
+
+// WriteCSV writes the results as CSV with collection times in loc
+func WriteCSV(w io.Writer, results []*types.LabResult, loc *time.Location) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(CSVHeader); err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		record := []string{
+			r.CollectedAt.In(loc).Format(time.RFC3339),
+			escapeCell(r.TestCode),
+			escapeCell(r.TestName),
+			formatFloat(r.Value),
+			escapeCell(r.Unit),
+			formatLimit(r.ReferenceLow),
+			formatLimit(r.ReferenceHigh),
+			escapeCell(r.Flag),
+			escapeCell(r.LabName),
+			escapeCell(r.Notes),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// escapeCell keeps user text from being run as a formula when the export is opened in
+// a spreadsheet, which could be someone else's if the results are shared. Cells that
+// start like a formula are prefixed with a quote so they are shown as text.
+func escapeCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func formatLimit(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return formatFloat(*value)
+}
@@ -0,0 +1,165 @@
+package lab
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+func TestUnitConversion(t *testing.T) {
+	tests := []struct {
+		code  string
+		value float64
+		unit  string
+		want  float64
+	}{
+		{"hba1c", 53, "mmol/mol", 7.0},
+		{"hba1c", 6.5, "%", 6.5},
+		{"fasting_glucose", 5.5, "mmol/L", 99.1},
+		{"ldl", 2.6, "mmol/L", 100.5},
+		{"triglycerides", 1.7, "mmol/L", 150.6},
+		{"creatinine", 88.42, "umol/L", 1.0},
+		{"uacr", 3.4, "mg/mmol", 30.1},
+	}
+
+	for _, tt := range tests {
+		got, err := ToTestUnit(tt.code, tt.value, tt.unit)
+		if err != nil {
+			t.Fatalf("ToTestUnit(%q, %v, %q) error: %v", tt.code, tt.value, tt.unit, err)
+		}
+		if math.Abs(got-tt.want) > 0.05 {
+			t.Errorf("ToTestUnit(%q, %v, %q) = %v, want %v", tt.code, tt.value, tt.unit, got, tt.want)
+		}
+
+		back, err := FromTestUnit(tt.code, got, tt.unit)
+		if err != nil || math.Abs(back-tt.value) > 1e-9 {
+			t.Errorf("FromTestUnit round trip for %q = %v (%v), want %v", tt.code, back, err, tt.value)
+		}
+	}
+
+	if _, err := ToTestUnit("egfr", 80, "mg/dL"); err == nil {
+		t.Error("eGFR in mg/dL should be rejected")
+	}
+	if _, err := ToTestUnit("vitamin_d", 30, "ng/mL"); err == nil {
+		t.Error("unknown test should be rejected")
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	// The default HbA1c range is converted to mmol/mol: 5.6% is 37.71 mmol/mol
+	result := &types.LabResult{TestCode: "hba1c", Value: 48, Unit: "mmol/mol"}
+	Annotate(result)
+	if result.TestName != "HbA1c" || result.ReferenceHigh == nil || *result.ReferenceHigh != 37.71 {
+		t.Fatalf("annotated result = %+v", result)
+	}
+	if result.Flag != types.LabFlagHigh {
+		t.Errorf("flag = %q, want high", result.Flag)
+	}
+
+	// The lab's own range takes precedence over the default
+	low := 50.0
+	hdl := &types.LabResult{TestCode: "hdl", Value: 45, Unit: "mg/dL", ReferenceLow: &low}
+	Annotate(hdl)
+	if hdl.Flag != types.LabFlagLow {
+		t.Errorf("HDL flag with lab range = %q, want low", hdl.Flag)
+	}
+
+	egfr := &types.LabResult{TestCode: "egfr", Value: 85, Unit: "mL/min/1.73m2"}
+	Annotate(egfr)
+	if egfr.Flag != types.LabFlagNormal {
+		t.Errorf("eGFR flag = %q, want normal", egfr.Flag)
+	}
+}
+
+func TestTrend(t *testing.T) {
+	test, _ := LookupTest("hba1c")
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	results := []*types.LabResult{
+		{ID: 1, TestCode: "hba1c", Value: 8.0, Unit: "%", CollectedAt: start},
+		{ID: 2, TestCode: "hba1c", Value: 64, Unit: "mmol/mol", CollectedAt: start.AddDate(0, 6, 0)},
+		{ID: 3, TestCode: "ldl", Value: 120, Unit: "mg/dL", CollectedAt: start.AddDate(0, 7, 0)},
+		{ID: 4, TestCode: "hba1c", Value: 7.0, Unit: "%", CollectedAt: start.AddDate(1, 0, 0)},
+	}
+
+	trend, err := Trend(test, results, "%")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trend.Points) != 3 {
+		t.Fatalf("got %d points, want 3", len(trend.Points))
+	}
+	if trend.Points[1].Value != 8.01 {
+		t.Errorf("converted point = %v, want 8.01", trend.Points[1].Value)
+	}
+	if trend.Points[0].ChangeFromPrevious != nil || *trend.Points[2].ChangeFromPrevious != -1.01 {
+		t.Errorf("changes from previous = %v, %v", trend.Points[0].ChangeFromPrevious, trend.Points[2].ChangeFromPrevious)
+	}
+	if trend.Latest.ResultID != 4 || *trend.Change != -1 {
+		t.Errorf("latest = %d, change = %v, want 4 and -1", trend.Latest.ResultID, *trend.Change)
+	}
+	if trend.ChangePerYear == nil || *trend.ChangePerYear > -0.9 || *trend.ChangePerYear < -1.1 {
+		t.Errorf("change per year = %v, want about -1", trend.ChangePerYear)
+	}
+
+	single, _ := Trend(test, results[:1], "%")
+	if single.Change != nil || single.ChangePerYear != nil || single.Latest == nil {
+		t.Errorf("single result trend = %+v", single)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	high := 99.0
+	results := []*types.LabResult{
+		{TestCode: "ldl", TestName: "LDL cholesterol", Value: 131.5, Unit: "mg/dL", ReferenceHigh: &high, Flag: "high",
+			CollectedAt: time.Date(2025, 1, 10, 8, 30, 0, 0, time.UTC), LabName: "City Lab", Notes: "fasting, 12h"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, results, time.UTC); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	want := `2025-01-10T08:30:00Z,ldl,LDL cholesterol,131.5,mg/dL,,99,high,City Lab,"fasting, 12h"`
+	if lines[1] != want {
+		t.Errorf("row = %s, want %s", lines[1], want)
+	}
+}
+
+func TestWriteCSVEscapesFormulas(t *testing.T) {
+	results := []*types.LabResult{
+		{TestCode: "ldl", TestName: "=HYPERLINK(\"https://evil.example.com\")", Value: 131.5, Unit: "mg/dL",
+			CollectedAt: time.Date(2025, 1, 10, 8, 30, 0, 0, time.UTC), LabName: "@SUM(A1)", Notes: "-2+3"},
+		{TestCode: "hba1c", TestName: "HbA1c", Value: 5.4, Unit: "%",
+			CollectedAt: time.Date(2025, 1, 11, 8, 30, 0, 0, time.UTC), LabName: "+City Lab", Notes: "\tnote"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, results, time.UTC); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cells := []string{records[1][2], records[1][8], records[1][9], records[2][8], records[2][9]}
+	for _, cell := range cells {
+		if !strings.HasPrefix(cell, "'") {
+			t.Errorf("cell %q is not escaped", cell)
+		}
+	}
+	if records[2][2] != "HbA1c" {
+		t.Errorf("plain cell = %q, want it unchanged", records[2][2])
+	}
+}
@@ -0,0 +1,302 @@
+package lab
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+type Handler struct {
+	store        types.LabResultStore
+	profileStore types.ProfileStore
+	userStore    types.UserStore
+}
+
+func NewHandler(store types.LabResultStore, profileStore types.ProfileStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, profileStore: profileStore, userStore: userStore}
+}
+
+// Lab results are infrequent, so ranges default to the last two years and can span ten
+const (
+	defaultRange = 2 * 365 * 24 * time.Hour
+	maxRange     = 10 * 366 * 24 * time.Hour
+)
+
+// RegisterRoutes for Fiber
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/labs/tests", auth.WithJWTAuth(h.handleGetTests, h.userStore))
+	router.Get("/labs/trends", auth.WithSharedAccess(h.handleGetTrend, h.userStore, types.ShareScopeLabs))
+	router.Get("/labs/results", auth.WithSharedAccess(h.handleGetResults, h.userStore, types.ShareScopeLabs))
+	router.Post("/labs/results", auth.WithJWTAuth(h.handleCreateResult, h.userStore))
+	router.Get("/labs/results/export", auth.WithSharedAccess(h.handleExportResults, h.userStore, types.ShareScopeLabs))
+	router.Get("/labs/results/:id", auth.WithSharedAccess(h.handleGetResultByID, h.userStore, types.ShareScopeLabs))
+	router.Put("/labs/results/:id", auth.WithJWTAuth(h.handleUpdateResult, h.userStore))
+	router.Delete("/labs/results/:id", auth.WithJWTAuth(h.handleDeleteResult, h.userStore))
+}
+
+// Handler for listing the supported lab tests with their units and reference ranges
+func (h *Handler) handleGetTests(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(Tests())
+}
+
+// Handler for listing the user's lab results, optionally for one test
+func (h *Handler) handleGetResults(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	testCode, from, to, err := parseQuery(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	results, err := h.store.GetLabResultsByTimeRange(userID, testCode, from, to)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting lab results: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"from":    from,
+		"to":      to,
+		"results": results,
+	})
+}
+
+// Handler for recording a lab result
+func (h *Handler) handleCreateResult(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.LabResultPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	result, invalidFields, err := toLabResultFromPayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+	result.UserID = userID
+
+	id, err := h.store.CreateLabResult(c.Context(), result)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error creating lab result: %v", err)})
+	}
+
+	created, err := h.store.GetLabResultByID(id, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting lab result: %v", err)})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(created)
+}
+
+// Handler for getting a single lab result
+func (h *Handler) handleGetResultByID(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+
+	result, err := h.store.GetLabResultByID(int32(intID), userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("%v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(result)
+}
+
+// Handler for replacing a lab result
+func (h *Handler) handleUpdateResult(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+	id := int32(intID)
+
+	// Parse JSON payload
+	var payload types.LabResultPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	result, invalidFields, err := toLabResultFromPayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+	result.ID = id
+	result.UserID = userID
+
+	found, err := h.store.UpdateLabResult(c.Context(), result)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error updating lab result: %v", err)})
+	}
+	if !found {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Lab result not found"})
+	}
+
+	updated, err := h.store.GetLabResultByID(id, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting lab result: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(updated)
+}
+
+// Handler for deleting a lab result
+func (h *Handler) handleDeleteResult(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+
+	deleted, err := h.store.DeleteLabResult(c.Context(), int32(intID), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error deleting lab result: %v", err)})
+	}
+	if !deleted {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Lab result not found"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Lab result deleted successfully"})
+}
+
+// Handler for the trend of one test, converted to the requested unit or the test's unit
+func (h *Handler) handleGetTrend(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	testCode, from, to, err := parseQuery(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if testCode == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "test is required"})
+	}
+	test, _ := LookupTest(testCode)
+
+	unit := c.Query("unit", test.Unit)
+	if _, err := FromTestUnit(test.Code, 0, unit); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	results, err := h.store.GetLabResultsByTimeRange(userID, testCode, from, to)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting lab results: %v", err)})
+	}
+
+	trend, err := Trend(test, results, unit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error computing trend: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(trend)
+}
+
+// Handler for downloading the user's lab results as CSV, in their time zone
+func (h *Handler) handleExportResults(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	testCode, from, to, err := parseQuery(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	profile, err := h.profileStore.GetProfileByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting profile: %v", err)})
+	}
+
+	results, err := h.store.GetLabResultsByTimeRange(userID, testCode, from, to)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting lab results: %v", err)})
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, results, profile.Location()); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error exporting lab results: %v", err)})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Attachment("lab-results.csv")
+	return c.Status(fiber.StatusOK).Send(buf.Bytes())
+}
+
+// parseQuery reads the optional test code and the from and to query parameters
+func parseQuery(c *fiber.Ctx) (string, time.Time, time.Time, error) {
+	testCode := strings.ToLower(strings.TrimSpace(c.Query("test")))
+	if testCode != "" {
+		if _, ok := LookupTest(testCode); !ok {
+			return "", time.Time{}, time.Time{}, fmt.Errorf("unknown lab test %q", testCode)
+		}
+	}
+
+	from, to, err := utils.ParseTimeRange(c.Query("from"), c.Query("to"), defaultRange)
+	if err != nil {
+		return "", time.Time{}, time.Time{}, err
+	}
+	if to.Sub(from) > maxRange {
+		return "", time.Time{}, time.Time{}, fmt.Errorf("range cannot be longer than ten years")
+	}
+
+	return testCode, from, to, nil
+}
+
+// toLabResultFromPayload validates the payload against the test it names and converts it.
+// On failure it returns the invalid fields.
+func toLabResultFromPayload(payload types.LabResultPayload) (*types.LabResult, map[string]string, error) {
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return nil, invalidFields, err
+	}
+
+	testCode := strings.ToLower(strings.TrimSpace(payload.TestCode))
+	test, ok := LookupTest(testCode)
+	if !ok {
+		return nil, map[string]string{"test_code": "unknown lab test"}, fmt.Errorf("unknown lab test %q", testCode)
+	}
+
+	unit := strings.TrimSpace(payload.Unit)
+	if unit == "" {
+		unit = test.Unit
+	}
+	if _, err := ToTestUnit(test.Code, payload.Value, unit); err != nil {
+		return nil, map[string]string{"unit": fmt.Sprintf("must be one of %s", strings.Join(test.Units, ", "))}, err
+	}
+
+	if payload.ReferenceLow != nil && payload.ReferenceHigh != nil && *payload.ReferenceLow > *payload.ReferenceHigh {
+		return nil, map[string]string{"reference_low": "must not be greater than reference_high"}, fmt.Errorf("invalid reference range")
+	}
+
+	return &types.LabResult{
+		TestCode:      test.Code,
+		Value:         payload.Value,
+		Unit:          unit,
+		ReferenceLow:  payload.ReferenceLow,
+		ReferenceHigh: payload.ReferenceHigh,
+		CollectedAt:   payload.CollectedAt,
+		LabName:       strings.TrimSpace(payload.LabName),
+		Notes:         strings.TrimSpace(payload.Notes),
+	}, nil, nil
+}
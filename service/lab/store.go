@@ -0,0 +1,162 @@
+package lab
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// GetLabResultByID fetches a single lab result of the user
+func (s *Store) GetLabResultByID(id int32, userID int32) (*types.LabResult, error) {
+	row, err := s.db.GetLabResultByID(context.Background(), database.GetLabResultByIDParams{
+		LabResultID: id,
+		UserID:      userID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("lab result not found")
+		}
+		return nil, err
+	}
+
+	return toLabResult(row), nil
+}
+
+// GetLabResultsByTimeRange fetches the user's results collected in [start, end) ordered by time.
+// An empty test code returns the results of every test.
+func (s *Store) GetLabResultsByTimeRange(userID int32, testCode string, start time.Time, end time.Time) ([]*types.LabResult, error) {
+	var rows []database.LabResult
+	var err error
+
+	if testCode == "" {
+		rows, err = s.db.GetLabResultsByTimeRange(context.Background(), database.GetLabResultsByTimeRangeParams{
+			UserID:    userID,
+			StartTime: start.UTC(),
+			EndTime:   end.UTC(),
+		})
+	} else {
+		rows, err = s.db.GetLabResultsByTestAndTimeRange(context.Background(), database.GetLabResultsByTestAndTimeRangeParams{
+			UserID:    userID,
+			TestCode:  testCode,
+			StartTime: start.UTC(),
+			EndTime:   end.UTC(),
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*types.LabResult, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, toLabResult(row))
+	}
+
+	return results, nil
+}
+
+// CreateLabResult stores a lab result and returns its ID
+func (s *Store) CreateLabResult(ctx context.Context, result *types.LabResult) (int32, error) {
+	id, err := s.db.CreateLabResult(ctx, database.CreateLabResultParams{
+		UserID:        result.UserID,
+		TestCode:      result.TestCode,
+		Value:         result.Value,
+		Unit:          result.Unit,
+		ReferenceLow:  toNullFloat64(result.ReferenceLow),
+		ReferenceHigh: toNullFloat64(result.ReferenceHigh),
+		CollectedAt:   result.CollectedAt.UTC(),
+		LabName:       result.LabName,
+		Notes:         result.Notes,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(id), nil
+}
+
+// UpdateLabResult replaces a lab result. It returns false if the result does not exist.
+func (s *Store) UpdateLabResult(ctx context.Context, result *types.LabResult) (bool, error) {
+	if _, err := s.GetLabResultByID(result.ID, result.UserID); err != nil {
+		if err.Error() == "lab result not found" {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// MySQL reports unchanged rows as not affected, so existence is checked above
+	_, err := s.db.UpdateLabResult(ctx, database.UpdateLabResultParams{
+		TestCode:      result.TestCode,
+		Value:         result.Value,
+		Unit:          result.Unit,
+		ReferenceLow:  toNullFloat64(result.ReferenceLow),
+		ReferenceHigh: toNullFloat64(result.ReferenceHigh),
+		CollectedAt:   result.CollectedAt.UTC(),
+		LabName:       result.LabName,
+		Notes:         result.Notes,
+		LabResultID:   result.ID,
+		UserID:        result.UserID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// DeleteLabResult deletes a lab result of the user
+func (s *Store) DeleteLabResult(ctx context.Context, id int32, userID int32) (bool, error) {
+	rows, err := s.db.DeleteLabResult(ctx, database.DeleteLabResultParams{
+		LabResultID: id,
+		UserID:      userID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func toLabResult(row database.LabResult) *types.LabResult {
+	result := &types.LabResult{
+		ID:          row.LabResultID,
+		UserID:      row.UserID,
+		TestCode:    row.TestCode,
+		Value:       row.Value,
+		Unit:        row.Unit,
+		CollectedAt: row.CollectedAt,
+		LabName:     row.LabName,
+		Notes:       row.Notes,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	}
+	if row.ReferenceLow.Valid {
+		low := row.ReferenceLow.Float64
+		result.ReferenceLow = &low
+	}
+	if row.ReferenceHigh.Valid {
+		high := row.ReferenceHigh.Float64
+		result.ReferenceHigh = &high
+	}
+	Annotate(result)
+	return result
+}
+
+func toNullFloat64(f *float64) sql.NullFloat64 {
+	if f == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *f, Valid: true}
+}
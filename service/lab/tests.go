@@ -0,0 +1,188 @@
+package lab
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+// conversion converts a value in another unit to the test's unit as value*scale + offset
+type conversion struct {
+	scale  float64
+	offset float64
+}
+
+type definition struct {
+	test        types.LabTest
+	conversions map[string]conversion
+}
+
+func limit(v float64) *float64 { return &v }
+
+// Reference ranges are the usual adult ranges for people without diabetes, as
+// printed by most labs. A lab's own range on the report takes precedence.
+var definitions = []definition{
+	{
+		// IFCC mmol/mol to NGSP %: % = mmol/mol / 10.929 + 2.15
+		test: types.LabTest{Code: "hba1c", Name: "HbA1c", Category: types.LabCategoryGlycemic, Unit: "%", ReferenceHigh: limit(5.6)},
+		conversions: map[string]conversion{
+			"mmol/mol": {scale: 1 / 10.929, offset: 2.15},
+		},
+	},
+	{
+		test: types.LabTest{Code: "fasting_glucose", Name: "Fasting plasma glucose", Category: types.LabCategoryGlycemic, Unit: "mg/dL", ReferenceLow: limit(70), ReferenceHigh: limit(99)},
+		conversions: map[string]conversion{
+			"mmol/L": {scale: 18.0182},
+		},
+	},
+	{
+		test: types.LabTest{Code: "total_cholesterol", Name: "Total cholesterol", Category: types.LabCategoryLipid, Unit: "mg/dL", ReferenceHigh: limit(199)},
+		conversions: map[string]conversion{
+			"mmol/L": {scale: 38.67},
+		},
+	},
+	{
+		test: types.LabTest{Code: "ldl", Name: "LDL cholesterol", Category: types.LabCategoryLipid, Unit: "mg/dL", ReferenceHigh: limit(99)},
+		conversions: map[string]conversion{
+			"mmol/L": {scale: 38.67},
+		},
+	},
+	{
+		test: types.LabTest{Code: "hdl", Name: "HDL cholesterol", Category: types.LabCategoryLipid, Unit: "mg/dL", ReferenceLow: limit(40)},
+		conversions: map[string]conversion{
+			"mmol/L": {scale: 38.67},
+		},
+	},
+	{
+		test: types.LabTest{Code: "triglycerides", Name: "Triglycerides", Category: types.LabCategoryLipid, Unit: "mg/dL", ReferenceHigh: limit(149)},
+		conversions: map[string]conversion{
+			"mmol/L": {scale: 88.57},
+		},
+	},
+	{
+		test: types.LabTest{Code: "egfr", Name: "eGFR", Category: types.LabCategoryKidney, Unit: "mL/min/1.73m2", ReferenceLow: limit(60)},
+	},
+	{
+		test: types.LabTest{Code: "creatinine", Name: "Serum creatinine", Category: types.LabCategoryKidney, Unit: "mg/dL", ReferenceLow: limit(0.6), ReferenceHigh: limit(1.3)},
+		conversions: map[string]conversion{
+			"umol/L": {scale: 1 / 88.42},
+		},
+	},
+	{
+		test: types.LabTest{Code: "uacr", Name: "Urine albumin-to-creatinine ratio", Category: types.LabCategoryKidney, Unit: "mg/g", ReferenceHigh: limit(29)},
+		conversions: map[string]conversion{
+			"mg/mmol": {scale: 8.84},
+		},
+	},
+}
+
+var definitionsByCode = func() map[string]*definition {
+	m := make(map[string]*definition, len(definitions))
+	for i := range definitions {
+		d := &definitions[i]
+		d.test.Units = []string{d.test.Unit}
+		for unit := range d.conversions {
+			d.test.Units = append(d.test.Units, unit)
+		}
+		sort.Strings(d.test.Units[1:])
+		m[d.test.Code] = d
+	}
+	return m
+}()
+
+// Tests returns the supported lab tests
+func Tests() []types.LabTest {
+	tests := make([]types.LabTest, 0, len(definitions))
+	for _, d := range definitions {
+		tests = append(tests, d.test)
+	}
+	return tests
+}
+
+// LookupTest returns the test with the code
+func LookupTest(code string) (types.LabTest, bool) {
+	d, ok := definitionsByCode[code]
+	if !ok {
+		return types.LabTest{}, false
+	}
+	return d.test, true
+}
+
+// ToTestUnit converts a value in unit to the unit of the test
+func ToTestUnit(code string, value float64, unit string) (float64, error) {
+	c, err := conversionFor(code, unit)
+	if err != nil {
+		return 0, err
+	}
+	return value*c.scale + c.offset, nil
+}
+
+// FromTestUnit converts a value in the unit of the test to unit
+func FromTestUnit(code string, value float64, unit string) (float64, error) {
+	c, err := conversionFor(code, unit)
+	if err != nil {
+		return 0, err
+	}
+	return (value - c.offset) / c.scale, nil
+}
+
+func conversionFor(code string, unit string) (conversion, error) {
+	d, ok := definitionsByCode[code]
+	if !ok {
+		return conversion{}, fmt.Errorf("unknown lab test %q", code)
+	}
+	if unit == d.test.Unit {
+		return conversion{scale: 1}, nil
+	}
+	c, ok := d.conversions[unit]
+	if !ok {
+		return conversion{}, fmt.Errorf("%s cannot be reported in %q", d.test.Name, unit)
+	}
+	return c, nil
+}
+
+// Flag compares a value to a reference range, either end of which can be open
+func Flag(value float64, low *float64, high *float64) string {
+	if low != nil && value < *low {
+		return types.LabFlagLow
+	}
+	if high != nil && value > *high {
+		return types.LabFlagHigh
+	}
+	return types.LabFlagNormal
+}
+
+// Annotate fills in the test name, the default reference range converted to the
+// unit of the result when the report did not give one, and the flag
+func Annotate(result *types.LabResult) {
+	d, ok := definitionsByCode[result.TestCode]
+	if !ok {
+		result.Flag = Flag(result.Value, result.ReferenceLow, result.ReferenceHigh)
+		return
+	}
+	result.TestName = d.test.Name
+
+	if result.ReferenceLow == nil && result.ReferenceHigh == nil {
+		result.ReferenceLow = convertLimit(d.test.Code, d.test.ReferenceLow, result.Unit)
+		result.ReferenceHigh = convertLimit(d.test.Code, d.test.ReferenceHigh, result.Unit)
+	}
+	result.Flag = Flag(result.Value, result.ReferenceLow, result.ReferenceHigh)
+}
+
+func convertLimit(code string, value *float64, unit string) *float64 {
+	if value == nil {
+		return nil
+	}
+	converted, err := FromTestUnit(code, *value, unit)
+	if err != nil {
+		return nil
+	}
+	return limit(round(converted))
+}
+
+// round rounds to two decimal places
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
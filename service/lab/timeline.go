@@ -0,0 +1,44 @@
+package lab
+
+import (
+	"context"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+)
+
+// TimelineTypes lists the event types of the store
+func (s *Store) TimelineTypes() []string {
+	return []string{types.TimelineLabResult}
+}
+
+// TimelineEvents returns up to the query's limit of the user's lab results on the
+// page, in the order they were collected
+func (s *Store) TimelineEvents(userID int32, query types.TimelineQuery) ([]*types.TimelineEvent, error) {
+	start, afterID := query.Bound(types.TimelineLabResult)
+
+	rows, err := s.db.GetLabResultsForTimeline(context.Background(), database.GetLabResultsForTimelineParams{
+		UserID:        userID,
+		CollectedAt:   start.UTC(),
+		CollectedAt_2: start.UTC(),
+		LabResultID:   afterID,
+		CollectedAt_3: query.End.UTC(),
+		Limit:         int32(query.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*types.TimelineEvent, 0, len(rows))
+	for _, row := range rows {
+		result := toLabResult(row)
+		events = append(events, &types.TimelineEvent{
+			Type:       types.TimelineLabResult,
+			ID:         result.ID,
+			OccurredAt: result.CollectedAt,
+			Data:       result,
+		})
+	}
+
+	return events, nil
+}
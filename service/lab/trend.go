@@ -0,0 +1,90 @@
+package lab
+
+import (
+	"github.com/jayden1905/abundance/types"
+)
+
+const hoursPerYear = 365.25 * 24
+
+// Trend converts the results of a test, sorted by collection time, to unit and
+// measures how they changed. Results of other tests are skipped.
+func Trend(test types.LabTest, results []*types.LabResult, unit string) (*types.LabTrend, error) {
+	trend := &types.LabTrend{
+		TestCode: test.Code,
+		TestName: test.Name,
+		Unit:     unit,
+		Points:   []*types.LabTrendPoint{},
+	}
+
+	for _, result := range results {
+		if result.TestCode != test.Code {
+			continue
+		}
+
+		value, err := ToTestUnit(test.Code, result.Value, result.Unit)
+		if err != nil {
+			return nil, err
+		}
+		if value, err = FromTestUnit(test.Code, value, unit); err != nil {
+			return nil, err
+		}
+
+		point := &types.LabTrendPoint{
+			ResultID:    result.ID,
+			CollectedAt: result.CollectedAt,
+			Value:       round(value),
+			Flag:        result.Flag,
+		}
+		if n := len(trend.Points); n > 0 {
+			change := round(point.Value - trend.Points[n-1].Value)
+			point.ChangeFromPrevious = &change
+		}
+		trend.Points = append(trend.Points, point)
+	}
+
+	if len(trend.Points) == 0 {
+		return trend, nil
+	}
+
+	first := trend.Points[0]
+	trend.Latest = trend.Points[len(trend.Points)-1]
+	if len(trend.Points) < 2 {
+		return trend, nil
+	}
+
+	change := round(trend.Latest.Value - first.Value)
+	trend.Change = &change
+
+	if slope, ok := slopePerYear(trend.Points); ok {
+		slope = round(slope)
+		trend.ChangePerYear = &slope
+	}
+
+	return trend, nil
+}
+
+// slopePerYear fits a least-squares line through the points. It fails when every
+// point was collected at the same time.
+func slopePerYear(points []*types.LabTrendPoint) (float64, bool) {
+	origin := points[0].CollectedAt
+	n := float64(len(points))
+
+	var sumX, sumY float64
+	for _, p := range points {
+		sumX += p.CollectedAt.Sub(origin).Hours() / hoursPerYear
+		sumY += p.Value
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var sxx, sxy float64
+	for _, p := range points {
+		dx := p.CollectedAt.Sub(origin).Hours()/hoursPerYear - meanX
+		sxx += dx * dx
+		sxy += dx * (p.Value - meanY)
+	}
+	if sxx == 0 {
+		return 0, false
+	}
+
+	return sxy / sxx, true
+}
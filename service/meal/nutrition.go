@@ -0,0 +1,100 @@
+package meal
+
+import (
+	"math"
+	"time"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+// Nutrient totals can be grouped by day or by week
+const (
+	PeriodDaily  = "daily"
+	PeriodWeekly = "weekly"
+)
+
+// EstimateCalories estimates energy in kcal from macronutrients using the Atwater factors
+func EstimateCalories(carbsG float64, proteinG float64, fatG float64) float64 {
+	return round(4*carbsG + 4*proteinG + 9*fatG)
+}
+
+// Totals sums the nutrients of the items
+func Totals(items []*types.MealItem) types.NutrientTotals {
+	var totals types.NutrientTotals
+	for _, item := range items {
+		totals.CarbsG += item.CarbsG
+		totals.ProteinG += item.ProteinG
+		totals.FatG += item.FatG
+		totals.FibreG += item.FibreG
+		totals.Calories += item.Calories
+	}
+	return roundTotals(totals)
+}
+
+// PeriodTotals groups the meals into consecutive days or ISO weeks in loc covering
+// from to to. Periods without meals are included so the result can be charted directly.
+func PeriodTotals(meals []*types.Meal, period string, loc *time.Location, from time.Time, to time.Time) []*types.NutrientPeriod {
+	start := periodStart(from.In(loc), period)
+	end := periodStart(to.In(loc), period)
+
+	var periods []*types.NutrientPeriod
+	index := make(map[string]*types.NutrientPeriod)
+	for day := start; !day.After(end); day = nextPeriod(day, period) {
+		p := &types.NutrientPeriod{Start: day.Format("2006-01-02")}
+		periods = append(periods, p)
+		index[p.Start] = p
+	}
+
+	for _, meal := range meals {
+		key := periodStart(meal.EatenAt.In(loc), period).Format("2006-01-02")
+		p, ok := index[key]
+		if !ok {
+			continue
+		}
+
+		p.Meals++
+		p.Totals.CarbsG += meal.Totals.CarbsG
+		p.Totals.ProteinG += meal.Totals.ProteinG
+		p.Totals.FatG += meal.Totals.FatG
+		p.Totals.FibreG += meal.Totals.FibreG
+		p.Totals.Calories += meal.Totals.Calories
+	}
+
+	for _, p := range periods {
+		p.Totals = roundTotals(p.Totals)
+	}
+
+	return periods
+}
+
+// periodStart returns local midnight of the day, or of the Monday of its week
+func periodStart(t time.Time, period string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if period == PeriodWeekly {
+		offset := (int(day.Weekday()) + 6) % 7
+		day = day.AddDate(0, 0, -offset)
+	}
+	return day
+}
+
+func nextPeriod(day time.Time, period string) time.Time {
+	if period == PeriodWeekly {
+		return day.AddDate(0, 0, 7)
+	}
+	return day.AddDate(0, 0, 1)
+}
+
+func roundTotals(totals types.NutrientTotals) types.NutrientTotals {
+	return types.NutrientTotals{
+		CarbsG:   round(totals.CarbsG),
+		ProteinG: round(totals.ProteinG),
+		FatG:     round(totals.FatG),
+		FibreG:   round(totals.FibreG),
+		Calories: round(totals.Calories),
+	}
+}
+
+// round rounds to one decimal place
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,82 @@
+package meal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+func TestEstimateCalories(t *testing.T) {
+	if got := EstimateCalories(50, 20, 10); got != 370 {
+		t.Errorf("expected 370 kcal, got %v", got)
+	}
+}
+
+func TestTotals(t *testing.T) {
+	totals := Totals([]*types.MealItem{
+		{CarbsG: 45.25, ProteinG: 5, FatG: 1, FibreG: 3, Calories: 210},
+		{CarbsG: 0.1, ProteinG: 25, FatG: 12.5, Calories: 220},
+	})
+
+	expected := types.NutrientTotals{CarbsG: 45.4, ProteinG: 30, FatG: 13.5, FibreG: 3, Calories: 430}
+	if totals != expected {
+		t.Errorf("expected %+v, got %+v", expected, totals)
+	}
+}
+
+func TestPeriodTotalsDaily(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*60*60)
+	from := time.Date(2025, 2, 10, 0, 0, 0, 0, loc)
+	to := time.Date(2025, 2, 12, 23, 0, 0, 0, loc)
+
+	meals := []*types.Meal{
+		// 20:00 UTC on the 10th is breakfast on the 11th locally
+		{EatenAt: time.Date(2025, 2, 10, 20, 0, 0, 0, time.UTC), Totals: types.NutrientTotals{CarbsG: 40}},
+		{EatenAt: time.Date(2025, 2, 11, 4, 0, 0, 0, time.UTC), Totals: types.NutrientTotals{CarbsG: 60}},
+		{EatenAt: time.Date(2025, 2, 10, 1, 0, 0, 0, time.UTC), Totals: types.NutrientTotals{CarbsG: 30}},
+	}
+
+	periods := PeriodTotals(meals, PeriodDaily, loc, from, to)
+	if len(periods) != 3 {
+		t.Fatalf("expected 3 days, got %d", len(periods))
+	}
+
+	expected := []struct {
+		start string
+		meals int
+		carbs float64
+	}{
+		{"2025-02-10", 1, 30},
+		{"2025-02-11", 2, 100},
+		{"2025-02-12", 0, 0},
+	}
+	for i, e := range expected {
+		p := periods[i]
+		if p.Start != e.start || p.Meals != e.meals || p.Totals.CarbsG != e.carbs {
+			t.Errorf("expected %s with %d meals and %vg carbs, got %s with %d and %v", e.start, e.meals, e.carbs, p.Start, p.Meals, p.Totals.CarbsG)
+		}
+	}
+}
+
+func TestPeriodTotalsWeekly(t *testing.T) {
+	// Wednesday 12 February to Tuesday 18 February spans two ISO weeks
+	from := time.Date(2025, 2, 12, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 2, 18, 12, 0, 0, 0, time.UTC)
+
+	meals := []*types.Meal{
+		{EatenAt: time.Date(2025, 2, 16, 12, 0, 0, 0, time.UTC), Totals: types.NutrientTotals{Calories: 500}},
+		{EatenAt: time.Date(2025, 2, 17, 12, 0, 0, 0, time.UTC), Totals: types.NutrientTotals{Calories: 700}},
+	}
+
+	periods := PeriodTotals(meals, PeriodWeekly, time.UTC, from, to)
+	if len(periods) != 2 {
+		t.Fatalf("expected 2 weeks, got %d", len(periods))
+	}
+	if periods[0].Start != "2025-02-10" || periods[0].Totals.Calories != 500 {
+		t.Errorf("expected week of 2025-02-10 with 500 kcal, got %s with %v", periods[0].Start, periods[0].Totals.Calories)
+	}
+	if periods[1].Start != "2025-02-17" || periods[1].Totals.Calories != 700 {
+		t.Errorf("expected week of 2025-02-17 with 700 kcal, got %s with %v", periods[1].Start, periods[1].Totals.Calories)
+	}
+}
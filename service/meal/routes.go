@@ -0,0 +1,271 @@
+package meal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+type Handler struct {
+	store        types.MealStore
+	profileStore types.ProfileStore
+	userStore    types.UserStore
+}
+
+func NewHandler(store types.MealStore, profileStore types.ProfileStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, profileStore: profileStore, userStore: userStore}
+}
+
+// RegisterRoutes for Fiber
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/meals", auth.WithSharedAccess(h.handleGetMeals, h.userStore, types.ShareScopeMeals))
+	router.Post("/meals", auth.WithJWTAuth(h.handleCreateMeal, h.userStore))
+	router.Get("/meals/totals", auth.WithSharedAccess(h.handleGetTotals, h.userStore, types.ShareScopeMeals))
+	router.Get("/meals/:id", auth.WithSharedAccess(h.handleGetMealByID, h.userStore, types.ShareScopeMeals))
+	router.Put("/meals/:id", auth.WithJWTAuth(h.handleUpdateMeal, h.userStore))
+	router.Delete("/meals/:id", auth.WithJWTAuth(h.handleDeleteMeal, h.userStore))
+}
+
+// Handler for listing the user's meals in a time range
+func (h *Handler) handleGetMeals(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Default to the last seven days when no range is given
+	from, to, err := utils.ParseTimeRange(c.Query("from"), c.Query("to"), 7*24*time.Hour)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	meals, err := h.store.GetMealsByTimeRange(userID, from, to)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting meals: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"from":   from,
+		"to":     to,
+		"meals":  meals,
+		"totals": sumMeals(meals),
+	})
+}
+
+// Handler for logging a meal
+func (h *Handler) handleCreateMeal(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.CreateMealPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	if payload.MealType == "" {
+		payload.MealType = "other"
+	}
+
+	meal := &types.Meal{
+		UserID:   userID,
+		MealType: payload.MealType,
+		EatenAt:  payload.EatenAt,
+		Notes:    strings.TrimSpace(payload.Notes),
+		Items:    toMealItems(payload.Items),
+	}
+
+	id, err := h.store.CreateMeal(c.Context(), meal)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error creating meal: %v", err)})
+	}
+
+	created, err := h.store.GetMealByID(id, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting meal: %v", err)})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(created)
+}
+
+// Handler for getting a single meal
+func (h *Handler) handleGetMealByID(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+
+	meal, err := h.store.GetMealByID(int32(intID), userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("%v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(meal)
+}
+
+// Handler for replacing a meal and its items
+func (h *Handler) handleUpdateMeal(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+	id := int32(intID)
+
+	// Parse JSON payload
+	var payload types.UpdateMealPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	found, err := h.store.UpdateMeal(c.Context(), &types.Meal{
+		ID:       id,
+		UserID:   userID,
+		MealType: payload.MealType,
+		EatenAt:  payload.EatenAt,
+		Notes:    strings.TrimSpace(payload.Notes),
+		Items:    toMealItems(payload.Items),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error updating meal: %v", err)})
+	}
+	if !found {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Meal not found"})
+	}
+
+	updated, err := h.store.GetMealByID(id, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting meal: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(updated)
+}
+
+// Handler for deleting a meal
+func (h *Handler) handleDeleteMeal(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+
+	deleted, err := h.store.DeleteMeal(c.Context(), int32(intID), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error deleting meal: %v", err)})
+	}
+	if !deleted {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Meal not found"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Meal deleted successfully"})
+}
+
+// Handler for daily or weekly nutrient totals in the user's time zone
+func (h *Handler) handleGetTotals(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	const maxRange = 366 * 24 * time.Hour
+
+	period := c.Query("period", PeriodDaily)
+	if period != PeriodDaily && period != PeriodWeekly {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "period must be daily or weekly"})
+	}
+
+	// Default to the last week of days or the last four weeks
+	defaultWindow := 7 * 24 * time.Hour
+	if period == PeriodWeekly {
+		defaultWindow = 28 * 24 * time.Hour
+	}
+
+	from, to, err := utils.ParseTimeRange(c.Query("from"), c.Query("to"), defaultWindow)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if to.Sub(from) > maxRange {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Range cannot be longer than a year"})
+	}
+
+	profile, err := h.profileStore.GetProfileByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting profile: %v", err)})
+	}
+	loc := profile.Location()
+
+	// Widen the range to whole periods so the first and last totals are complete
+	start := periodStart(from.In(loc), period)
+	end := nextPeriod(periodStart(to.In(loc), period), period)
+
+	meals, err := h.store.GetMealsByTimeRange(userID, start, end)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting meals: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"period":   period,
+		"timezone": loc.String(),
+		"totals":   PeriodTotals(meals, period, loc, from, to),
+	})
+}
+
+// toMealItems converts the payload items, estimating calories where they were left out
+func toMealItems(payload []types.MealItemPayload) []*types.MealItem {
+	items := make([]*types.MealItem, 0, len(payload))
+	for _, p := range payload {
+		item := &types.MealItem{
+			Name:        strings.TrimSpace(p.Name),
+			Portion:     p.Portion,
+			PortionUnit: p.PortionUnit,
+			CarbsG:      p.CarbsG,
+			ProteinG:    p.ProteinG,
+			FatG:        p.FatG,
+			FibreG:      p.FibreG,
+		}
+		if item.PortionUnit == "" {
+			item.PortionUnit = "g"
+		}
+		if p.Calories != nil {
+			item.Calories = *p.Calories
+		} else {
+			item.Calories = EstimateCalories(p.CarbsG, p.ProteinG, p.FatG)
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// sumMeals adds up the totals of the meals
+func sumMeals(meals []*types.Meal) types.NutrientTotals {
+	var items []*types.MealItem
+	for _, meal := range meals {
+		items = append(items, meal.Items...)
+	}
+	return Totals(items)
+}
@@ -0,0 +1,218 @@
+package meal
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries. A meal and its items
+// are always written together in a transaction.
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// GetMealByID fetches a single meal owned by the user, with its items
+func (s *Store) GetMealByID(id int32, userID int32) (*types.Meal, error) {
+	row, err := s.db.GetMealByID(context.Background(), database.GetMealByIDParams{
+		MealID: id,
+		UserID: userID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("meal not found")
+		}
+		return nil, err
+	}
+
+	items, err := s.db.GetMealItemsByMealID(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	meal := toMeal(row)
+	for _, item := range items {
+		meal.Items = append(meal.Items, toMealItem(item))
+	}
+	meal.Totals = Totals(meal.Items)
+
+	return meal, nil
+}
+
+// GetMealsByTimeRange fetches the user's meals in [start, end) ordered by time, with their items
+func (s *Store) GetMealsByTimeRange(userID int32, start time.Time, end time.Time) ([]*types.Meal, error) {
+	rows, err := s.db.GetMealsByTimeRange(context.Background(), database.GetMealsByTimeRangeParams{
+		UserID:    userID,
+		StartTime: start.UTC(),
+		EndTime:   end.UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.db.GetMealItemsByTimeRange(context.Background(), database.GetMealItemsByTimeRangeParams{
+		UserID:    userID,
+		StartTime: start.UTC(),
+		EndTime:   end.UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	meals := make([]*types.Meal, 0, len(rows))
+	byID := make(map[int32]*types.Meal, len(rows))
+	for _, row := range rows {
+		meal := toMeal(row)
+		meals = append(meals, meal)
+		byID[meal.ID] = meal
+	}
+
+	for _, item := range items {
+		if meal, ok := byID[item.MealID]; ok {
+			meal.Items = append(meal.Items, toMealItem(item))
+		}
+	}
+
+	for _, meal := range meals {
+		meal.Totals = Totals(meal.Items)
+	}
+
+	return meals, nil
+}
+
+// CreateMeal stores a meal and its items in a single transaction and returns its ID
+func (s *Store) CreateMeal(ctx context.Context, meal *types.Meal) (int32, error) {
+	var id int32
+
+	err := s.db.RunInTx(ctx, func(q *database.Queries) error {
+		mealID, err := q.CreateMeal(ctx, database.CreateMealParams{
+			UserID:   meal.UserID,
+			MealType: database.MealsMealType(meal.MealType),
+			EatenAt:  meal.EatenAt.UTC(),
+			Notes:    toNullString(meal.Notes),
+		})
+		if err != nil {
+			return err
+		}
+		id = int32(mealID)
+
+		return createMealItems(ctx, q, id, meal.Items)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// UpdateMeal replaces a meal and its items. It returns false if the meal does not exist.
+func (s *Store) UpdateMeal(ctx context.Context, meal *types.Meal) (bool, error) {
+	found := false
+
+	err := s.db.RunInTx(ctx, func(q *database.Queries) error {
+		// Make sure the meal belongs to the user before touching its items
+		if _, err := q.GetMealByID(ctx, database.GetMealByIDParams{MealID: meal.ID, UserID: meal.UserID}); err != nil {
+			if err == sql.ErrNoRows {
+				return nil
+			}
+			return err
+		}
+		found = true
+
+		_, err := q.UpdateMeal(ctx, database.UpdateMealParams{
+			MealType: database.MealsMealType(meal.MealType),
+			EatenAt:  meal.EatenAt.UTC(),
+			Notes:    toNullString(meal.Notes),
+			MealID:   meal.ID,
+			UserID:   meal.UserID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := q.DeleteMealItemsByMealID(ctx, meal.ID); err != nil {
+			return err
+		}
+
+		return createMealItems(ctx, q, meal.ID, meal.Items)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return found, nil
+}
+
+// DeleteMeal deletes a meal and its items. It returns false if it did not exist.
+func (s *Store) DeleteMeal(ctx context.Context, id int32, userID int32) (bool, error) {
+	rows, err := s.db.DeleteMeal(ctx, database.DeleteMealParams{
+		MealID: id,
+		UserID: userID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func createMealItems(ctx context.Context, q *database.Queries, mealID int32, items []*types.MealItem) error {
+	for _, item := range items {
+		err := q.CreateMealItem(ctx, database.CreateMealItemParams{
+			MealID:      mealID,
+			Name:        item.Name,
+			Portion:     item.Portion,
+			PortionUnit: database.MealItemsPortionUnit(item.PortionUnit),
+			CarbsG:      item.CarbsG,
+			ProteinG:    item.ProteinG,
+			FatG:        item.FatG,
+			FibreG:      item.FibreG,
+			Calories:    item.Calories,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func toMeal(row database.Meal) *types.Meal {
+	return &types.Meal{
+		ID:        row.MealID,
+		UserID:    row.UserID,
+		MealType:  string(row.MealType),
+		EatenAt:   row.EatenAt,
+		Notes:     row.Notes.String,
+		Items:     []*types.MealItem{},
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+}
+
+func toMealItem(row database.MealItem) *types.MealItem {
+	return &types.MealItem{
+		ID:          row.MealItemID,
+		MealID:      row.MealID,
+		Name:        row.Name,
+		Portion:     row.Portion,
+		PortionUnit: string(row.PortionUnit),
+		CarbsG:      row.CarbsG,
+		ProteinG:    row.ProteinG,
+		FatG:        row.FatG,
+		FibreG:      row.FibreG,
+		Calories:    row.Calories,
+	}
+}
+
+func toNullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
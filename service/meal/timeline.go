@@ -0,0 +1,71 @@
+package meal
+
+import (
+	"context"
+	"time"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+)
+
+// TimelineTypes lists the event types of the store
+func (s *Store) TimelineTypes() []string {
+	return []string{types.TimelineMeal}
+}
+
+// TimelineEvents returns up to the query's limit of the user's meals on the page, in
+// the order they were eaten, with their items
+func (s *Store) TimelineEvents(userID int32, query types.TimelineQuery) ([]*types.TimelineEvent, error) {
+	start, afterID := query.Bound(types.TimelineMeal)
+
+	rows, err := s.db.GetMealsForTimeline(context.Background(), database.GetMealsForTimelineParams{
+		UserID:    userID,
+		EatenAt:   start.UTC(),
+		EatenAt_2: start.UTC(),
+		MealID:    afterID,
+		EatenAt_3: query.End.UTC(),
+		Limit:     int32(query.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*types.TimelineEvent, 0, len(rows))
+	if len(rows) == 0 {
+		return events, nil
+	}
+
+	// The items of the page's meals are the items of meals eaten between its first and last
+	items, err := s.db.GetMealItemsByTimeRange(context.Background(), database.GetMealItemsByTimeRangeParams{
+		UserID:    userID,
+		StartTime: rows[0].EatenAt,
+		EndTime:   rows[len(rows)-1].EatenAt.Add(time.Second),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int32]*types.Meal, len(rows))
+	for _, row := range rows {
+		meal := toMeal(row)
+		byID[meal.ID] = meal
+		events = append(events, &types.TimelineEvent{
+			Type:       types.TimelineMeal,
+			ID:         meal.ID,
+			OccurredAt: meal.EatenAt,
+			Data:       meal,
+		})
+	}
+
+	for _, item := range items {
+		if meal, ok := byID[item.MealID]; ok {
+			meal.Items = append(meal.Items, toMealItem(item))
+		}
+	}
+
+	for _, meal := range byID {
+		meal.Totals = Totals(meal.Items)
+	}
+
+	return events, nil
+}
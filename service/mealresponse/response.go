@@ -0,0 +1,180 @@
+package mealresponse
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jayden1905/abundance/service/glucose"
+	"github.com/jayden1905/abundance/types"
+)
+
+const (
+	// BaselineWindow is how long before a meal a reading still counts as its baseline
+	BaselineWindow = 30 * time.Minute
+	// baselineGrace allows for a meal logged a few minutes after the first bite
+	baselineGrace = 5 * time.Minute
+	// AnalysisWindow is the period after the meal used for the peak and the area under the curve
+	AnalysisWindow = 2 * time.Hour
+	// FollowUpWindow is how long after the meal a return to baseline is looked for
+	FollowUpWindow = 4 * time.Hour
+	// minCoverage is how much of the analysis window the readings must span
+	minCoverage = 90 * time.Minute
+	// minReadings is the fewest post-meal readings needed for an analysis
+	minReadings = 3
+)
+
+// Analyze computes the glucose response to the meal from readings sorted by time,
+// which should cover BaselineWindow before to FollowUpWindow after the meal.
+// Results are converted to unit.
+func Analyze(meal *types.Meal, readings []*types.GlucoseReading, unit string) *types.MealResponse {
+	response := &types.MealResponse{
+		MealID:   meal.ID,
+		MealType: meal.MealType,
+		EatenAt:  meal.EatenAt,
+		Foods:    []string{},
+		CarbsG:   meal.Totals.CarbsG,
+		Unit:     unit,
+		Status:   types.MealResponseInsufficientData,
+	}
+	for _, item := range meal.Items {
+		response.Foods = append(response.Foods, item.Name)
+	}
+
+	// The baseline is the last reading shortly before the meal
+	var baseline *types.GlucoseReading
+	for _, reading := range readings {
+		offset := reading.MeasuredAt.Sub(meal.EatenAt)
+		if offset >= -BaselineWindow && offset <= baselineGrace {
+			baseline = reading
+		}
+	}
+	if baseline == nil {
+		return response
+	}
+	base := glucose.ToMgdL(baseline.Value, baseline.Unit)
+
+	// Points are minutes after the meal and mg/dL, starting at the baseline
+	type point struct {
+		minutes float64
+		value   float64
+	}
+	window := []point{{0, base}}
+	var followUp []point
+	for _, reading := range readings {
+		offset := reading.MeasuredAt.Sub(meal.EatenAt)
+		if !reading.MeasuredAt.After(baseline.MeasuredAt) || offset <= 0 || offset > FollowUpWindow {
+			continue
+		}
+
+		p := point{offset.Minutes(), glucose.ToMgdL(reading.Value, reading.Unit)}
+		followUp = append(followUp, p)
+		if offset <= AnalysisWindow {
+			window = append(window, p)
+		}
+	}
+
+	response.Readings = len(window) - 1
+	if response.Readings < minReadings || window[len(window)-1].minutes < minCoverage.Minutes() {
+		return response
+	}
+
+	peak := window[0]
+	var area float64
+	for i := 1; i < len(window); i++ {
+		if window[i].value > peak.value {
+			peak = window[i]
+		}
+		area += incrementalArea(window[i-1].value-base, window[i].value-base, window[i].minutes-window[i-1].minutes)
+	}
+
+	for _, p := range followUp {
+		if p.minutes > peak.minutes && p.value <= base {
+			minutes := int(math.Round(p.minutes))
+			response.ReturnToBaselineMinutes = &minutes
+			break
+		}
+	}
+
+	response.Status = types.MealResponseOK
+	response.Baseline = round(glucose.FromMgdL(base, unit))
+	response.Peak = round(glucose.FromMgdL(peak.value, unit))
+	response.Rise = round(glucose.FromMgdL(peak.value-base, unit))
+	response.TimeToPeakMinutes = int(math.Round(peak.minutes))
+	response.IncrementalAUC = round(glucose.FromMgdL(area, unit))
+
+	return response
+}
+
+// incrementalArea is the area above the baseline of one trapezoid with
+// increments d1 and d2 over dt minutes. Area below the baseline is ignored.
+func incrementalArea(d1 float64, d2 float64, dt float64) float64 {
+	switch {
+	case d1 >= 0 && d2 >= 0:
+		return (d1 + d2) / 2 * dt
+	case d1 <= 0 && d2 <= 0:
+		return 0
+	}
+
+	// The curve crosses the baseline, so only the triangle above it counts
+	positive := math.Max(d1, d2)
+	return positive * positive / (math.Abs(d1) + math.Abs(d2)) * dt / 2
+}
+
+// RankFoods summarises the responses by food and orders the foods from the
+// largest to the smallest average rise. Only foods eaten in at least minMeals
+// analysed meals are included.
+func RankFoods(responses []*types.MealResponse, unit string, minMeals int) []*types.FoodResponse {
+	byName := make(map[string]*types.FoodResponse)
+	var foods []*types.FoodResponse
+
+	for _, response := range responses {
+		if response.Status != types.MealResponseOK {
+			continue
+		}
+
+		// Count each food once per meal
+		seen := make(map[string]bool)
+		for _, name := range response.Foods {
+			key := strings.ToLower(strings.TrimSpace(name))
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			food, ok := byName[key]
+			if !ok {
+				food = &types.FoodResponse{Name: strings.TrimSpace(name), Unit: unit}
+				byName[key] = food
+				foods = append(foods, food)
+			}
+
+			food.Meals++
+			food.AverageRise += response.Rise
+			food.AverageIncrementalAUC += response.IncrementalAUC
+			food.MaxPeak = math.Max(food.MaxPeak, response.Peak)
+		}
+	}
+
+	ranked := []*types.FoodResponse{}
+	for _, food := range foods {
+		if food.Meals < minMeals {
+			continue
+		}
+		food.AverageRise = round(food.AverageRise / float64(food.Meals))
+		food.AverageIncrementalAUC = round(food.AverageIncrementalAUC / float64(food.Meals))
+		ranked = append(ranked, food)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].AverageRise > ranked[j].AverageRise
+	})
+
+	return ranked
+}
+
+// round rounds to one decimal place
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,100 @@
+package mealresponse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+var mealTime = time.Date(2025, 2, 20, 12, 0, 0, 0, time.UTC)
+
+// curve returns readings in mg/dL every step minutes, starting at the given offset from the meal
+func curve(startMinutes int, step int, values ...float64) []*types.GlucoseReading {
+	readings := make([]*types.GlucoseReading, 0, len(values))
+	for i, v := range values {
+		readings = append(readings, &types.GlucoseReading{
+			Value:      v,
+			Unit:       types.GlucoseUnitMgdL,
+			MeasuredAt: mealTime.Add(time.Duration(startMinutes+i*step) * time.Minute),
+		})
+	}
+	return readings
+}
+
+func testMeal(foods ...string) *types.Meal {
+	meal := &types.Meal{ID: 1, MealType: "lunch", EatenAt: mealTime}
+	for _, food := range foods {
+		meal.Items = append(meal.Items, &types.MealItem{Name: food})
+	}
+	return meal
+}
+
+func TestAnalyze(t *testing.T) {
+	// Baseline 100 just before the meal, peak 160 at 60 minutes, back to 100 at 180
+	readings := append(curve(-5, 0, 100), curve(30, 30, 130, 160, 140, 120, 110, 100)...)
+
+	response := Analyze(testMeal("Rice"), readings, types.GlucoseUnitMgdL)
+
+	if response.Status != types.MealResponseOK {
+		t.Fatalf("expected an analysis, got %s", response.Status)
+	}
+	if response.Baseline != 100 || response.Peak != 160 || response.Rise != 60 || response.TimeToPeakMinutes != 60 {
+		t.Errorf("unexpected response: %+v", response)
+	}
+	// Trapezoids over 0-120 minutes: 450 + 1350 + 1500 + 900
+	if response.IncrementalAUC != 4200 {
+		t.Errorf("expected an iAUC of 4200, got %v", response.IncrementalAUC)
+	}
+	if response.ReturnToBaselineMinutes == nil || *response.ReturnToBaselineMinutes != 180 {
+		t.Errorf("expected a return to baseline after 180 minutes, got %v", response.ReturnToBaselineMinutes)
+	}
+}
+
+func TestAnalyzeRequiresBaselineAndCoverage(t *testing.T) {
+	// No reading before the meal
+	response := Analyze(testMeal(), curve(15, 15, 120, 140, 150, 130, 120, 110), types.GlucoseUnitMgdL)
+	if response.Status != types.MealResponseInsufficientData {
+		t.Errorf("expected insufficient data without a baseline, got %s", response.Status)
+	}
+
+	// Readings stop after 45 minutes
+	response = Analyze(testMeal(), curve(-10, 15, 100, 120, 140, 150), types.GlucoseUnitMgdL)
+	if response.Status != types.MealResponseInsufficientData {
+		t.Errorf("expected insufficient data with short coverage, got %s", response.Status)
+	}
+}
+
+func TestIncrementalAreaIgnoresDips(t *testing.T) {
+	if a := incrementalArea(-10, -20, 15); a != 0 {
+		t.Errorf("expected no area below baseline, got %v", a)
+	}
+	// Crossing from -10 to +30 over 20 minutes leaves a triangle of 15 minutes × 30 / 2
+	if a := incrementalArea(-10, 30, 20); a != 225 {
+		t.Errorf("expected 225, got %v", a)
+	}
+}
+
+func TestRankFoods(t *testing.T) {
+	responses := []*types.MealResponse{
+		{Status: types.MealResponseOK, Foods: []string{"White rice", "Chicken"}, Rise: 80, Peak: 190},
+		{Status: types.MealResponseOK, Foods: []string{"white rice "}, Rise: 60, Peak: 170},
+		{Status: types.MealResponseOK, Foods: []string{"Salad", "Chicken"}, Rise: 20, Peak: 120},
+		{Status: types.MealResponseInsufficientData, Foods: []string{"Cake"}},
+	}
+
+	ranked := RankFoods(responses, types.GlucoseUnitMgdL, 1)
+	if len(ranked) != 3 {
+		t.Fatalf("expected 3 foods, got %d", len(ranked))
+	}
+	if ranked[0].Name != "White rice" || ranked[0].Meals != 2 || ranked[0].AverageRise != 70 || ranked[0].MaxPeak != 190 {
+		t.Errorf("expected white rice first with 2 meals and an average rise of 70, got %+v", ranked[0])
+	}
+	if ranked[1].Name != "Chicken" || ranked[1].AverageRise != 50 {
+		t.Errorf("expected chicken second with an average rise of 50, got %+v", ranked[1])
+	}
+
+	if ranked := RankFoods(responses, types.GlucoseUnitMgdL, 2); len(ranked) != 2 {
+		t.Errorf("expected 2 foods eaten at least twice, got %d", len(ranked))
+	}
+}
@@ -0,0 +1,179 @@
+package mealresponse
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+type Handler struct {
+	mealStore    types.MealStore
+	glucoseStore types.GlucoseStore
+	profileStore types.ProfileStore
+	userStore    types.UserStore
+}
+
+func NewHandler(mealStore types.MealStore, glucoseStore types.GlucoseStore, profileStore types.ProfileStore, userStore types.UserStore) *Handler {
+	return &Handler{mealStore: mealStore, glucoseStore: glucoseStore, profileStore: profileStore, userStore: userStore}
+}
+
+// RegisterRoutes for Fiber
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/glucose/meal-responses", auth.WithSharedAccess(h.handleGetMealResponses, h.userStore, types.ShareScopeGlucose, types.ShareScopeMeals))
+	router.Get("/glucose/meal-responses/foods", auth.WithSharedAccess(h.handleGetFoodRanking, h.userStore, types.ShareScopeGlucose, types.ShareScopeMeals))
+	router.Get("/glucose/meal-responses/:id", auth.WithSharedAccess(h.handleGetMealResponse, h.userStore, types.ShareScopeGlucose, types.ShareScopeMeals))
+}
+
+// Handler for the glucose response to each of the user's meals in a time range
+func (h *Handler) handleGetMealResponses(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	from, to, err := parseRange(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	unit, err := h.unit(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting profile: %v", err)})
+	}
+
+	responses, err := h.analyzeRange(userID, from, to, unit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error analysing meals: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"from":      from,
+		"to":        to,
+		"responses": responses,
+	})
+}
+
+// Handler for ranking foods by how much they raise the user's glucose
+func (h *Handler) handleGetFoodRanking(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	from, to, err := parseRange(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	minMeals := 1
+	if m, err := strconv.Atoi(c.Query("min_meals")); err == nil && m > 0 {
+		minMeals = m
+	}
+
+	limit := 20
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+
+	unit, err := h.unit(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting profile: %v", err)})
+	}
+
+	responses, err := h.analyzeRange(userID, from, to, unit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error analysing meals: %v", err)})
+	}
+
+	foods := RankFoods(responses, unit, minMeals)
+	if len(foods) > limit {
+		foods = foods[:limit]
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"from":  from,
+		"to":    to,
+		"foods": foods,
+	})
+}
+
+// Handler for the glucose response to a single meal
+func (h *Handler) handleGetMealResponse(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+
+	meal, err := h.mealStore.GetMealByID(int32(intID), userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("%v", err)})
+	}
+
+	unit, err := h.unit(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting profile: %v", err)})
+	}
+
+	readings, err := h.glucoseStore.GetGlucoseReadingsByTimeRange(userID, meal.EatenAt.Add(-BaselineWindow), meal.EatenAt.Add(FollowUpWindow+time.Second))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting glucose readings: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(Analyze(meal, readings, unit))
+}
+
+// analyzeRange analyses every meal eaten in [from, to) against one batch of readings
+func (h *Handler) analyzeRange(userID int32, from time.Time, to time.Time, unit string) ([]*types.MealResponse, error) {
+	meals, err := h.mealStore.GetMealsByTimeRange(userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	readings, err := h.glucoseStore.GetGlucoseReadingsByTimeRange(userID, from.Add(-BaselineWindow), to.Add(FollowUpWindow+time.Second))
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*types.MealResponse, 0, len(meals))
+	for _, meal := range meals {
+		// Readings are sorted by time, so find each meal's slice by binary search
+		start := sort.Search(len(readings), func(i int) bool {
+			return !readings[i].MeasuredAt.Before(meal.EatenAt.Add(-BaselineWindow))
+		})
+		end := sort.Search(len(readings), func(i int) bool {
+			return readings[i].MeasuredAt.After(meal.EatenAt.Add(FollowUpWindow))
+		})
+
+		responses = append(responses, Analyze(meal, readings[start:end], unit))
+	}
+
+	return responses, nil
+}
+
+// unit returns the user's preferred glucose unit
+func (h *Handler) unit(userID int32) (string, error) {
+	profile, err := h.profileStore.GetProfileByUserID(userID)
+	if err != nil {
+		return "", err
+	}
+	return profile.GlucoseUnit, nil
+}
+
+// parseRange reads the from and to query parameters, defaulting to the last 30 days
+func parseRange(c *fiber.Ctx) (time.Time, time.Time, error) {
+	const maxRange = 90 * 24 * time.Hour
+
+	from, to, err := utils.ParseTimeRange(c.Query("from"), c.Query("to"), 30*24*time.Hour)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if to.Sub(from) > maxRange {
+		return time.Time{}, time.Time{}, fmt.Errorf("range cannot be longer than 90 days")
+	}
+
+	return from, to, nil
+}
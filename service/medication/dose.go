@@ -0,0 +1,79 @@
+package medication
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+// Largest single dose accepted for each dose unit. These only catch obvious
+// typos such as an extra zero and are not clinical limits.
+var maxDoseByUnit = map[string]float64{
+	"units": 100,
+	"mg":    3000,
+	"mcg":   5000,
+	"ml":    20,
+}
+
+// How far in the future a dose can be logged, to allow for clock drift between devices
+const maxFutureDose = 10 * time.Minute
+
+// ValidateDose checks an amount against the dose unit of the medication
+func ValidateDose(amount float64, unit string) error {
+	max, ok := maxDoseByUnit[unit]
+	if !ok {
+		return fmt.Errorf("unknown dose unit %q", unit)
+	}
+	if amount <= 0 {
+		return fmt.Errorf("dose must be greater than 0 %s", unit)
+	}
+	if amount > max {
+		return fmt.Errorf("dose cannot be more than %g %s", max, unit)
+	}
+	return nil
+}
+
+// ValidateTakenAt rejects doses logged in the future
+func ValidateTakenAt(takenAt time.Time, now time.Time) error {
+	if takenAt.After(now.Add(maxFutureDose)) {
+		return fmt.Errorf("taken_at cannot be in the future")
+	}
+	return nil
+}
+
+// FilterMedications returns the catalogue entries whose name, brand names or class contain the query
+func FilterMedications(medications []*types.Medication, query string) []*types.Medication {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return medications
+	}
+
+	matches := make([]*types.Medication, 0)
+	for _, m := range medications {
+		if strings.Contains(strings.ToLower(m.Name), query) ||
+			strings.Contains(strings.ToLower(m.BrandNames), query) ||
+			strings.Contains(strings.ReplaceAll(m.Class, "_", " "), query) {
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// DoseTotals sums the doses per medication
+func DoseTotals(doses []*types.MedicationDose) []*types.MedicationDoseTotal {
+	totals := make([]*types.MedicationDoseTotal, 0)
+	byID := make(map[int32]*types.MedicationDoseTotal)
+	for _, d := range doses {
+		total, ok := byID[d.MedicationID]
+		if !ok {
+			total = &types.MedicationDoseTotal{MedicationID: d.MedicationID, Name: d.Name, Unit: d.Unit}
+			byID[d.MedicationID] = total
+			totals = append(totals, total)
+		}
+		total.Doses++
+		total.Amount += d.Amount
+	}
+	return totals
+}
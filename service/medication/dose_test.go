@@ -0,0 +1,85 @@
+package medication
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+func TestValidateDose(t *testing.T) {
+	tests := []struct {
+		amount  float64
+		unit    string
+		wantErr bool
+	}{
+		{8, "units", false},
+		{100, "units", false},
+		{120, "units", true},
+		{500, "mg", false},
+		{5000, "mg", true},
+		{0, "mg", true},
+		{1, "drops", true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateDose(tt.amount, tt.unit)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateDose(%v, %q) error = %v, want error %v", tt.amount, tt.unit, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateTakenAt(t *testing.T) {
+	now := time.Date(2025, 2, 22, 12, 0, 0, 0, time.UTC)
+
+	if err := ValidateTakenAt(now.Add(-time.Hour), now); err != nil {
+		t.Errorf("past dose rejected: %v", err)
+	}
+	if err := ValidateTakenAt(now.Add(5*time.Minute), now); err != nil {
+		t.Errorf("dose within clock drift rejected: %v", err)
+	}
+	if err := ValidateTakenAt(now.Add(time.Hour), now); err == nil {
+		t.Error("future dose accepted")
+	}
+}
+
+func TestFilterMedications(t *testing.T) {
+	catalogue := []*types.Medication{
+		{ID: 1, Name: "Insulin glargine", BrandNames: "Lantus, Basaglar", Class: "long_acting_insulin"},
+		{ID: 2, Name: "Metformin", BrandNames: "Glucophage", Class: "biguanide"},
+		{ID: 3, Name: "Semaglutide", BrandNames: "Ozempic", Class: "glp1_agonist"},
+	}
+
+	if got := FilterMedications(catalogue, ""); len(got) != 3 {
+		t.Errorf("empty query returned %d medications, want 3", len(got))
+	}
+	if got := FilterMedications(catalogue, "lantus"); len(got) != 1 || got[0].ID != 1 {
+		t.Errorf("brand name search = %v, want glargine", got)
+	}
+	if got := FilterMedications(catalogue, "Long Acting"); len(got) != 1 || got[0].ID != 1 {
+		t.Errorf("class search = %v, want glargine", got)
+	}
+	if got := FilterMedications(catalogue, "insulin"); len(got) != 1 {
+		t.Errorf("insulin search returned %d medications, want 1", len(got))
+	}
+}
+
+func TestDoseTotals(t *testing.T) {
+	doses := []*types.MedicationDose{
+		{MedicationID: 1, Name: "Insulin aspart", Unit: "units", Amount: 6},
+		{MedicationID: 2, Name: "Metformin", Unit: "mg", Amount: 500},
+		{MedicationID: 1, Name: "Insulin aspart", Unit: "units", Amount: 4.5},
+	}
+
+	totals := DoseTotals(doses)
+	if len(totals) != 2 {
+		t.Fatalf("got %d totals, want 2", len(totals))
+	}
+	if totals[0].MedicationID != 1 || totals[0].Doses != 2 || totals[0].Amount != 10.5 {
+		t.Errorf("aspart total = %+v, want 2 doses of 10.5 units", totals[0])
+	}
+	if totals[1].Amount != 500 {
+		t.Errorf("metformin total = %v, want 500", totals[1].Amount)
+	}
+}
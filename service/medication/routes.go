@@ -0,0 +1,333 @@
+package medication
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+type Handler struct {
+	store     types.MedicationStore
+	userStore types.UserStore
+}
+
+func NewHandler(store types.MedicationStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, userStore: userStore}
+}
+
+// RegisterRoutes for Fiber
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/medications", auth.WithJWTAuth(h.handleGetMedications, h.userStore))
+	router.Get("/medications/doses", auth.WithSharedAccess(h.handleGetDoses, h.userStore, types.ShareScopeMedications))
+	router.Post("/medications/doses", auth.WithJWTAuth(h.handleCreateDose, h.userStore))
+	router.Get("/medications/doses/:id", auth.WithSharedAccess(h.handleGetDoseByID, h.userStore, types.ShareScopeMedications))
+	router.Delete("/medications/doses/:id", auth.WithJWTAuth(h.handleDeleteDose, h.userStore))
+	router.Get("/user/me/medications", auth.WithSharedAccess(h.handleGetUserMedications, h.userStore, types.ShareScopeMedications))
+	router.Post("/user/me/medications", auth.WithJWTAuth(h.handleCreateUserMedication, h.userStore))
+	router.Put("/user/me/medications/:id", auth.WithJWTAuth(h.handleUpdateUserMedication, h.userStore))
+	router.Delete("/user/me/medications/:id", auth.WithJWTAuth(h.handleDeleteUserMedication, h.userStore))
+}
+
+// Handler for searching the medication catalogue
+func (h *Handler) handleGetMedications(c *fiber.Ctx) error {
+	medications, err := h.store.GetMedications()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting medications: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(FilterMedications(medications, c.Query("q")))
+}
+
+// Handler for the user's medication list. Stopped medications are left out unless include_inactive=true.
+func (h *Handler) handleGetUserMedications(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	medications, err := h.store.GetUserMedications(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting medications: %v", err)})
+	}
+
+	if !c.QueryBool("include_inactive", false) {
+		active := make([]*types.UserMedication, 0, len(medications))
+		for _, m := range medications {
+			if m.IsActive {
+				active = append(active, m)
+			}
+		}
+		medications = active
+	}
+
+	return c.Status(fiber.StatusOK).JSON(medications)
+}
+
+// Handler for adding a medication to the user's list
+func (h *Handler) handleCreateUserMedication(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.CreateUserMedicationPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	medication, err := h.store.GetMedicationByID(payload.MedicationID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("%v", err)})
+	}
+
+	if payload.Dose != nil {
+		if err := ValidateDose(*payload.Dose, medication.DoseUnit); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+	}
+
+	existing, err := h.store.GetUserMedications(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting medications: %v", err)})
+	}
+	for _, m := range existing {
+		if m.MedicationID == medication.ID {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Medication is already on your list"})
+		}
+	}
+
+	id, err := h.store.CreateUserMedication(c.Context(), &types.UserMedication{
+		UserID:       userID,
+		MedicationID: medication.ID,
+		Dose:         payload.Dose,
+		Schedule:     strings.TrimSpace(payload.Schedule),
+		StartedOn:    parseDate(payload.StartedOn),
+		IsActive:     true,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error adding medication: %v", err)})
+	}
+
+	created, err := h.store.GetUserMedicationByID(id, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting medication: %v", err)})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(created)
+}
+
+// Handler for updating the dose, schedule or status of a medication on the user's list
+func (h *Handler) handleUpdateUserMedication(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+	id := int32(intID)
+
+	// Parse JSON payload
+	var payload types.UpdateUserMedicationPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	current, err := h.store.GetUserMedicationByID(id, userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("%v", err)})
+	}
+
+	if payload.Dose != nil {
+		if err := ValidateDose(*payload.Dose, current.DoseUnit); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+	}
+
+	found, err := h.store.UpdateUserMedication(c.Context(), &types.UserMedication{
+		ID:        id,
+		UserID:    userID,
+		Dose:      payload.Dose,
+		Schedule:  strings.TrimSpace(payload.Schedule),
+		StartedOn: parseDate(payload.StartedOn),
+		IsActive:  *payload.IsActive,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error updating medication: %v", err)})
+	}
+	if !found {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User medication not found"})
+	}
+
+	updated, err := h.store.GetUserMedicationByID(id, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting medication: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(updated)
+}
+
+// Handler for removing a medication from the user's list
+func (h *Handler) handleDeleteUserMedication(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+
+	deleted, err := h.store.DeleteUserMedication(c.Context(), int32(intID), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error removing medication: %v", err)})
+	}
+	if !deleted {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User medication not found"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Medication removed successfully"})
+}
+
+// Handler for listing the user's doses in a time range, with totals per medication
+func (h *Handler) handleGetDoses(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Default to the last seven days when no range is given
+	from, to, err := utils.ParseTimeRange(c.Query("from"), c.Query("to"), 7*24*time.Hour)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	doses, err := h.store.GetDosesByTimeRange(userID, from, to)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting doses: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"from":   from,
+		"to":     to,
+		"doses":  doses,
+		"totals": DoseTotals(doses),
+	})
+}
+
+// Handler for logging a dose
+func (h *Handler) handleCreateDose(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.CreateMedicationDosePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	medication, err := h.store.GetMedicationByID(payload.MedicationID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("%v", err)})
+	}
+
+	if err := ValidateDose(payload.Amount, medication.DoseUnit); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err := ValidateTakenAt(payload.TakenAt, time.Now()); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	id, err := h.store.CreateDose(c.Context(), &types.MedicationDose{
+		UserID:       userID,
+		MedicationID: medication.ID,
+		Amount:       payload.Amount,
+		TakenAt:      payload.TakenAt,
+		Notes:        strings.TrimSpace(payload.Notes),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error logging dose: %v", err)})
+	}
+
+	created, err := h.store.GetDoseByID(id, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting dose: %v", err)})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(created)
+}
+
+// Handler for getting a single dose
+func (h *Handler) handleGetDoseByID(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+
+	dose, err := h.store.GetDoseByID(int32(intID), userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": fmt.Sprintf("%v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dose)
+}
+
+// Handler for deleting a dose
+func (h *Handler) handleDeleteDose(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+
+	deleted, err := h.store.DeleteDose(c.Context(), int32(intID), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error deleting dose: %v", err)})
+	}
+	if !deleted {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Dose not found"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Dose deleted successfully"})
+}
+
+// parseDate parses an already validated YYYY-MM-DD date, returning nil when it is empty
+func parseDate(s string) *time.Time {
+	if s == "" {
+		return nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
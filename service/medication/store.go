@@ -0,0 +1,264 @@
+package medication
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// GetMedications fetches the whole medication catalogue
+func (s *Store) GetMedications() ([]*types.Medication, error) {
+	rows, err := s.db.GetMedications(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	medications := make([]*types.Medication, 0, len(rows))
+	for _, row := range rows {
+		medications = append(medications, toMedication(row))
+	}
+
+	return medications, nil
+}
+
+// GetMedicationByID fetches a single catalogue entry
+func (s *Store) GetMedicationByID(id int32) (*types.Medication, error) {
+	row, err := s.db.GetMedicationByID(context.Background(), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("medication not found")
+		}
+		return nil, err
+	}
+
+	return toMedication(row), nil
+}
+
+// GetUserMedications fetches the medications on the user's list, active or not
+func (s *Store) GetUserMedications(userID int32) ([]*types.UserMedication, error) {
+	rows, err := s.db.GetUserMedications(context.Background(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	medications := make([]*types.UserMedication, 0, len(rows))
+	for _, row := range rows {
+		medications = append(medications, toUserMedication(database.GetUserMedicationByIDRow(row)))
+	}
+
+	return medications, nil
+}
+
+// GetUserMedicationByID fetches a single medication on the user's list
+func (s *Store) GetUserMedicationByID(id int32, userID int32) (*types.UserMedication, error) {
+	row, err := s.db.GetUserMedicationByID(context.Background(), database.GetUserMedicationByIDParams{
+		UserMedicationID: id,
+		UserID:           userID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("user medication not found")
+		}
+		return nil, err
+	}
+
+	return toUserMedication(row), nil
+}
+
+// CreateUserMedication adds a medication to the user's list and returns its ID
+func (s *Store) CreateUserMedication(ctx context.Context, medication *types.UserMedication) (int32, error) {
+	id, err := s.db.CreateUserMedication(ctx, database.CreateUserMedicationParams{
+		UserID:       medication.UserID,
+		MedicationID: medication.MedicationID,
+		Dose:         toNullFloat64(medication.Dose),
+		Schedule:     medication.Schedule,
+		StartedOn:    toNullTime(medication.StartedOn),
+		IsActive:     medication.IsActive,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(id), nil
+}
+
+// UpdateUserMedication updates the dose, schedule and status of a medication on the user's list.
+// It returns false if the medication is not on the list.
+func (s *Store) UpdateUserMedication(ctx context.Context, medication *types.UserMedication) (bool, error) {
+	if _, err := s.GetUserMedicationByID(medication.ID, medication.UserID); err != nil {
+		if err.Error() == "user medication not found" {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// MySQL reports unchanged rows as not affected, so existence is checked above
+	_, err := s.db.UpdateUserMedication(ctx, database.UpdateUserMedicationParams{
+		Dose:             toNullFloat64(medication.Dose),
+		Schedule:         medication.Schedule,
+		StartedOn:        toNullTime(medication.StartedOn),
+		IsActive:         medication.IsActive,
+		UserMedicationID: medication.ID,
+		UserID:           medication.UserID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// DeleteUserMedication removes a medication from the user's list. Logged doses are kept.
+func (s *Store) DeleteUserMedication(ctx context.Context, id int32, userID int32) (bool, error) {
+	rows, err := s.db.DeleteUserMedication(ctx, database.DeleteUserMedicationParams{
+		UserMedicationID: id,
+		UserID:           userID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+// GetDoseByID fetches a single dose logged by the user
+func (s *Store) GetDoseByID(id int32, userID int32) (*types.MedicationDose, error) {
+	row, err := s.db.GetMedicationDoseByID(context.Background(), database.GetMedicationDoseByIDParams{
+		DoseID: id,
+		UserID: userID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("dose not found")
+		}
+		return nil, err
+	}
+
+	return toMedicationDose(row), nil
+}
+
+// GetDosesByTimeRange fetches the user's doses in [start, end) ordered by time
+func (s *Store) GetDosesByTimeRange(userID int32, start time.Time, end time.Time) ([]*types.MedicationDose, error) {
+	rows, err := s.db.GetMedicationDosesByTimeRange(context.Background(), database.GetMedicationDosesByTimeRangeParams{
+		UserID:    userID,
+		StartTime: start.UTC(),
+		EndTime:   end.UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	doses := make([]*types.MedicationDose, 0, len(rows))
+	for _, row := range rows {
+		doses = append(doses, toMedicationDose(database.GetMedicationDoseByIDRow(row)))
+	}
+
+	return doses, nil
+}
+
+// CreateDose logs a dose and returns its ID
+func (s *Store) CreateDose(ctx context.Context, dose *types.MedicationDose) (int32, error) {
+	id, err := s.db.CreateMedicationDose(ctx, database.CreateMedicationDoseParams{
+		UserID:       dose.UserID,
+		MedicationID: dose.MedicationID,
+		Amount:       dose.Amount,
+		TakenAt:      dose.TakenAt.UTC(),
+		Notes:        dose.Notes,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(id), nil
+}
+
+// DeleteDose deletes a dose logged by the user
+func (s *Store) DeleteDose(ctx context.Context, id int32, userID int32) (bool, error) {
+	rows, err := s.db.DeleteMedicationDose(ctx, database.DeleteMedicationDoseParams{
+		DoseID: id,
+		UserID: userID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func toMedication(row database.Medication) *types.Medication {
+	return &types.Medication{
+		ID:         row.MedicationID,
+		Name:       row.Name,
+		BrandNames: row.BrandNames,
+		Class:      string(row.Class),
+		DoseUnit:   string(row.DoseUnit),
+		Route:      string(row.Route),
+	}
+}
+
+func toUserMedication(row database.GetUserMedicationByIDRow) *types.UserMedication {
+	medication := &types.UserMedication{
+		ID:           row.UserMedicationID,
+		UserID:       row.UserID,
+		MedicationID: row.MedicationID,
+		Name:         row.Name,
+		Class:        string(row.Class),
+		DoseUnit:     string(row.DoseUnit),
+		Route:        string(row.Route),
+		Schedule:     row.Schedule,
+		IsActive:     row.IsActive,
+		CreatedAt:    row.CreatedAt,
+		UpdatedAt:    row.UpdatedAt,
+	}
+	if row.Dose.Valid {
+		dose := row.Dose.Float64
+		medication.Dose = &dose
+	}
+	if row.StartedOn.Valid {
+		startedOn := row.StartedOn.Time
+		medication.StartedOn = &startedOn
+	}
+	return medication
+}
+
+func toMedicationDose(row database.GetMedicationDoseByIDRow) *types.MedicationDose {
+	return &types.MedicationDose{
+		ID:           row.DoseID,
+		UserID:       row.UserID,
+		MedicationID: row.MedicationID,
+		Name:         row.Name,
+		Class:        string(row.Class),
+		Unit:         string(row.DoseUnit),
+		Amount:       row.Amount,
+		TakenAt:      row.TakenAt,
+		Notes:        row.Notes,
+		CreatedAt:    row.CreatedAt,
+	}
+}
+
+func toNullFloat64(f *float64) sql.NullFloat64 {
+	if f == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *f, Valid: true}
+}
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
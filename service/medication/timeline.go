@@ -0,0 +1,44 @@
+package medication
+
+import (
+	"context"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+)
+
+// TimelineTypes lists the event types of the store
+func (s *Store) TimelineTypes() []string {
+	return []string{types.TimelineMedicationDose}
+}
+
+// TimelineEvents returns up to the query's limit of the user's medication doses on the
+// page, in the order they were taken
+func (s *Store) TimelineEvents(userID int32, query types.TimelineQuery) ([]*types.TimelineEvent, error) {
+	start, afterID := query.Bound(types.TimelineMedicationDose)
+
+	rows, err := s.db.GetMedicationDosesForTimeline(context.Background(), database.GetMedicationDosesForTimelineParams{
+		UserID:    userID,
+		TakenAt:   start.UTC(),
+		TakenAt_2: start.UTC(),
+		DoseID:    afterID,
+		TakenAt_3: query.End.UTC(),
+		Limit:     int32(query.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*types.TimelineEvent, 0, len(rows))
+	for _, row := range rows {
+		dose := toMedicationDose(database.GetMedicationDoseByIDRow(row))
+		events = append(events, &types.TimelineEvent{
+			Type:       types.TimelineMedicationDose,
+			ID:         dose.ID,
+			OccurredAt: dose.TakenAt,
+			Data:       dose,
+		})
+	}
+
+	return events, nil
+}
@@ -0,0 +1,144 @@
+package mfa
+
+import (
+	"context"
+	"time"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+// Issuer is the account issuer shown in authenticator apps
+const Issuer = "Abundance"
+
+// RecoveryCodeCount is how many recovery codes are issued at a time
+const RecoveryCodeCount = 10
+
+// MaxFailedAttempts is how many wrong codes the second login step accepts before it is
+// locked for LockDuration. The count is kept per user, so it holds however the attempts
+// are spread over MFA tokens and clients.
+const MaxFailedAttempts = 5
+
+// LockDuration is how long the second login step stays locked
+const LockDuration = 15 * time.Minute
+
+// RequiredRoles must use two-factor authentication
+var RequiredRoles = []string{"admin", "nutritionist"}
+
+// IsRequired reports whether the user's role must use two-factor authentication
+func IsRequired(userID int32, userStore types.UserStore) (bool, error) {
+	return utils.HasAnyRole(userID, userStore, RequiredRoles...)
+}
+
+// Enrollment is what the user needs to add the account to an authenticator app
+type Enrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+// Enroll starts a new enrollment for the user, replacing any unconfirmed one
+func Enroll(ctx context.Context, store types.MFAStore, user *types.User) (*Enrollment, error) {
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := store.SaveTOTPSecret(ctx, user.ID, secret); err != nil {
+		return nil, err
+	}
+
+	return &Enrollment{Secret: secret, URI: auth.TOTPURI(Issuer, user.Email, secret)}, nil
+}
+
+// Confirm enables an enrollment once the user proves their app produces valid codes
+// and returns the first set of recovery codes. It returns nil codes if the code is wrong.
+func Confirm(ctx context.Context, store types.MFAStore, mfa *types.UserMFA, code string, now time.Time) ([]string, error) {
+	ok, err := CheckCode(ctx, store, mfa, code, now)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	if err := store.EnableMFA(ctx, mfa.UserID); err != nil {
+		return nil, err
+	}
+
+	return IssueRecoveryCodes(ctx, store, mfa.UserID)
+}
+
+// CheckCode validates a TOTP code and records its time step so it cannot be reused
+func CheckCode(ctx context.Context, store types.MFAStore, mfa *types.UserMFA, code string, now time.Time) (bool, error) {
+	step, ok := auth.ValidateTOTP(mfa.Secret, code, now)
+	if !ok || step <= mfa.LastUsedStep {
+		return false, nil
+	}
+
+	return store.UseTOTPStep(ctx, mfa.UserID, step)
+}
+
+// CheckRecoveryCode consumes one of the user's recovery codes
+func CheckRecoveryCode(ctx context.Context, store types.MFAStore, userID int32, code string) (bool, error) {
+	return store.UseRecoveryCode(ctx, userID, auth.HashRecoveryCode(code))
+}
+
+// IsLocked reports whether too many wrong codes have locked the second login step
+func IsLocked(mfa *types.UserMFA, now time.Time) bool {
+	return mfa.LockedUntil != nil && now.Before(*mfa.LockedUntil)
+}
+
+// Verify checks the second factor of a login, which is either a TOTP code or a recovery
+// code. Wrong codes are counted, and after MaxFailedAttempts no code is accepted until
+// the lock expires.
+func Verify(ctx context.Context, store types.MFAStore, mfa *types.UserMFA, code string, recoveryCode string, now time.Time) (bool, error) {
+	if IsLocked(mfa, now) {
+		return false, nil
+	}
+
+	var ok bool
+	var err error
+	if code != "" {
+		ok, err = CheckCode(ctx, store, mfa, code, now)
+	} else {
+		ok, err = CheckRecoveryCode(ctx, store, mfa.UserID, recoveryCode)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if ok {
+		if mfa.FailedAttempts > 0 || mfa.LockedUntil != nil {
+			return true, store.ResetFailedAttempts(ctx, mfa.UserID)
+		}
+		return true, nil
+	}
+
+	attempts, err := store.RecordFailedAttempt(ctx, mfa.UserID)
+	if err != nil {
+		return false, err
+	}
+	if attempts >= MaxFailedAttempts {
+		return false, store.Lock(ctx, mfa.UserID, now.Add(LockDuration))
+	}
+
+	return false, nil
+}
+
+// IssueRecoveryCodes replaces the user's recovery codes with new ones. Only the hashes are
+// stored, so the returned codes can only be shown to the user once.
+func IssueRecoveryCodes(ctx context.Context, store types.MFAStore, userID int32) ([]string, error) {
+	codes, err := auth.GenerateRecoveryCodes(RecoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashes = append(hashes, auth.HashRecoveryCode(code))
+	}
+
+	if err := store.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
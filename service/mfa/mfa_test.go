@@ -0,0 +1,162 @@
+package mfa
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/types"
+)
+
+type mockStore struct {
+	mfa   *types.UserMFA
+	codes map[string]bool
+}
+
+func (m *mockStore) GetUserMFA(userID int32) (*types.UserMFA, error) {
+	return m.mfa, nil
+}
+
+func (m *mockStore) SaveTOTPSecret(ctx context.Context, userID int32, secret string) error {
+	m.mfa = &types.UserMFA{UserID: userID, Secret: secret}
+	return nil
+}
+
+func (m *mockStore) EnableMFA(ctx context.Context, userID int32) error {
+	m.mfa.IsEnabled = true
+	return nil
+}
+
+func (m *mockStore) DisableMFA(ctx context.Context, userID int32) error {
+	m.mfa = nil
+	m.codes = nil
+	return nil
+}
+
+func (m *mockStore) UseTOTPStep(ctx context.Context, userID int32, step int64) (bool, error) {
+	if step <= m.mfa.LastUsedStep {
+		return false, nil
+	}
+	m.mfa.LastUsedStep = step
+	return true, nil
+}
+
+func (m *mockStore) ReplaceRecoveryCodes(ctx context.Context, userID int32, codeHashes []string) error {
+	m.codes = make(map[string]bool)
+	for _, hash := range codeHashes {
+		m.codes[hash] = true
+	}
+	return nil
+}
+
+func (m *mockStore) UseRecoveryCode(ctx context.Context, userID int32, codeHash string) (bool, error) {
+	if !m.codes[codeHash] {
+		return false, nil
+	}
+	m.codes[codeHash] = false
+	return true, nil
+}
+
+func (m *mockStore) RecordFailedAttempt(ctx context.Context, userID int32) (int, error) {
+	m.mfa.FailedAttempts++
+	return m.mfa.FailedAttempts, nil
+}
+
+func (m *mockStore) Lock(ctx context.Context, userID int32, until time.Time) error {
+	m.mfa.FailedAttempts = 0
+	m.mfa.LockedUntil = &until
+	return nil
+}
+
+func (m *mockStore) ResetFailedAttempts(ctx context.Context, userID int32) error {
+	m.mfa.FailedAttempts = 0
+	m.mfa.LockedUntil = nil
+	return nil
+}
+
+func (m *mockStore) CountRecoveryCodes(userID int32) (int, error) {
+	count := 0
+	for _, unused := range m.codes {
+		if unused {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func TestEnrollment(t *testing.T) {
+	ctx := context.Background()
+	store := &mockStore{}
+	now := time.Unix(1700000000, 0)
+
+	enrollment, err := Enroll(ctx, store, &types.User{ID: 1, Email: "jane@example.com"})
+	if err != nil {
+		t.Fatalf("Enroll() error: %v", err)
+	}
+	if enrollment.Secret != store.mfa.Secret || enrollment.URI == "" {
+		t.Fatalf("unexpected enrollment %+v", enrollment)
+	}
+
+	codes, err := Confirm(ctx, store, store.mfa, "000000", now)
+	if err != nil || codes != nil || store.mfa.IsEnabled {
+		t.Fatalf("Confirm() with a wrong code = %v, %v, want no codes", codes, err)
+	}
+
+	code, _ := auth.TOTPCode(store.mfa.Secret, auth.TOTPStep(now))
+	codes, err = Confirm(ctx, store, store.mfa, code, now)
+	if err != nil || len(codes) != RecoveryCodeCount || !store.mfa.IsEnabled {
+		t.Fatalf("Confirm() = %v, %v, want %d recovery codes", codes, err, RecoveryCodeCount)
+	}
+
+	// The code that confirmed enrollment cannot be replayed to log in
+	if ok, _ := Verify(ctx, store, store.mfa, code, "", now); ok {
+		t.Error("expected a used code to be rejected")
+	}
+
+	next, _ := auth.TOTPCode(store.mfa.Secret, auth.TOTPStep(now)+1)
+	if ok, _ := Verify(ctx, store, store.mfa, next, "", now.Add(30*time.Second)); !ok {
+		t.Error("expected the next code to be accepted")
+	}
+
+	if ok, _ := Verify(ctx, store, store.mfa, "", codes[0], now); !ok {
+		t.Error("expected a recovery code to be accepted")
+	}
+	if ok, _ := Verify(ctx, store, store.mfa, "", codes[0], now); ok {
+		t.Error("expected a recovery code to work only once")
+	}
+	if remaining, _ := store.CountRecoveryCodes(1); remaining != RecoveryCodeCount-1 {
+		t.Errorf("remaining recovery codes = %d, want %d", remaining, RecoveryCodeCount-1)
+	}
+}
+
+func TestVerifyLockout(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	secret, _ := auth.GenerateTOTPSecret()
+	store := &mockStore{mfa: &types.UserMFA{UserID: 1, Secret: secret, IsEnabled: true}}
+
+	for i := 0; i < MaxFailedAttempts; i++ {
+		if ok, err := Verify(ctx, store, store.mfa, "000000", "", now); ok || err != nil {
+			t.Fatalf("Verify() with a wrong code = %v, %v", ok, err)
+		}
+	}
+	if !IsLocked(store.mfa, now) {
+		t.Fatalf("expected the second step to be locked after %d wrong codes", MaxFailedAttempts)
+	}
+
+	// Even the right code is refused while locked
+	code, _ := auth.TOTPCode(secret, auth.TOTPStep(now))
+	if ok, _ := Verify(ctx, store, store.mfa, code, "", now); ok {
+		t.Error("expected a valid code to be refused while locked")
+	}
+
+	later := now.Add(LockDuration + time.Minute)
+	code, _ = auth.TOTPCode(secret, auth.TOTPStep(later))
+	if ok, err := Verify(ctx, store, store.mfa, code, "", later); !ok || err != nil {
+		t.Fatalf("Verify() after the lock = %v, %v, want true", ok, err)
+	}
+	if store.mfa.FailedAttempts != 0 || store.mfa.LockedUntil != nil {
+		t.Errorf("expected a successful code to reset the failed attempts, got %+v", store.mfa)
+	}
+}
@@ -0,0 +1,228 @@
+package mfa
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+type Handler struct {
+	store     types.MFAStore
+	userStore types.UserStore
+}
+
+func NewHandler(store types.MFAStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, userStore: userStore}
+}
+
+// RegisterRoutes for Fiber
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	rateLimiterCode := auth.CreateRateLimiter(5, 15*time.Minute, "Too many attempts. Please try again later.")
+
+	router.Get("/user/me/mfa", auth.WithJWTAuth(h.handleGetStatus, h.userStore))
+	router.Post("/user/me/mfa/totp", auth.WithJWTAuth(h.handleEnroll, h.userStore))
+	router.Post("/user/me/mfa/totp/confirm", rateLimiterCode, auth.WithJWTAuth(h.handleConfirm, h.userStore))
+	router.Delete("/user/me/mfa/totp", rateLimiterCode, auth.WithJWTAuth(h.handleDisable, h.userStore))
+	router.Post("/user/me/mfa/recovery-codes", rateLimiterCode, auth.WithJWTAuth(h.handleRegenerateRecoveryCodes, h.userStore))
+}
+
+// Handler for the current user's two-factor authentication status
+func (h *Handler) handleGetStatus(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	mfa, err := h.store.GetUserMFA(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting two-factor authentication: %v", err)})
+	}
+
+	required, err := IsRequired(userID, h.userStore)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	response := fiber.Map{
+		"enabled":                  false,
+		"enabled_at":               nil,
+		"required":                 required,
+		"recovery_codes_remaining": 0,
+	}
+	if mfa != nil && mfa.IsEnabled {
+		remaining, err := h.store.CountRecoveryCodes(userID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error counting recovery codes: %v", err)})
+		}
+
+		response["enabled"] = true
+		response["enabled_at"] = mfa.EnabledAt
+		response["recovery_codes_remaining"] = remaining
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// Handler for starting TOTP enrollment
+func (h *Handler) handleEnroll(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	mfa, err := h.store.GetUserMFA(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting two-factor authentication: %v", err)})
+	}
+	if mfa != nil && mfa.IsEnabled {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Two-factor authentication is already enabled"})
+	}
+
+	user, err := h.userStore.GetUserByID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting user: %v", err)})
+	}
+
+	enrollment, err := Enroll(c.Context(), h.store, user)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error starting enrollment: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(enrollment)
+}
+
+// Handler for confirming TOTP enrollment with a code from the authenticator app
+func (h *Handler) handleConfirm(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.MFACodePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	mfa, err := h.store.GetUserMFA(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting two-factor authentication: %v", err)})
+	}
+	if mfa == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Start enrollment first"})
+	}
+	if mfa.IsEnabled {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Two-factor authentication is already enabled"})
+	}
+
+	codes, err := Confirm(c.Context(), h.store, mfa, payload.Code, time.Now())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error enabling two-factor authentication: %v", err)})
+	}
+	if codes == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid code"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message":        "Two-factor authentication enabled. Store your recovery codes somewhere safe",
+		"recovery_codes": codes,
+	})
+}
+
+// Handler for turning off two-factor authentication
+func (h *Handler) handleDisable(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.MFACodePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	required, err := IsRequired(userID, h.userStore)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	if required {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Two-factor authentication is required for your role"})
+	}
+
+	mfa, ok, err := h.checkEnabledCode(c, userID, payload.Code)
+	if err != nil || !ok {
+		return err
+	}
+
+	if err := h.store.DisableMFA(c.Context(), mfa.UserID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error disabling two-factor authentication: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Two-factor authentication disabled"})
+}
+
+// Handler for replacing the recovery codes, such as after using some of them
+func (h *Handler) handleRegenerateRecoveryCodes(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.MFACodePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	mfa, ok, err := h.checkEnabledCode(c, userID, payload.Code)
+	if err != nil || !ok {
+		return err
+	}
+
+	codes, err := IssueRecoveryCodes(c.Context(), h.store, mfa.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error creating recovery codes: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"recovery_codes": codes})
+}
+
+// checkEnabledCode checks a TOTP code of a user with two-factor authentication enabled.
+// When it returns false, the error response has been written.
+func (h *Handler) checkEnabledCode(c *fiber.Ctx, userID int32, code string) (*types.UserMFA, bool, error) {
+	mfa, err := h.store.GetUserMFA(userID)
+	if err != nil {
+		return nil, false, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting two-factor authentication: %v", err)})
+	}
+	if mfa == nil || !mfa.IsEnabled {
+		return nil, false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Two-factor authentication is not enabled"})
+	}
+
+	ok, err := CheckCode(c.Context(), h.store, mfa, code, time.Now())
+	if err != nil {
+		return nil, false, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error checking code: %v", err)})
+	}
+	if !ok {
+		return nil, false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid code"})
+	}
+
+	return mfa, true, nil
+}
@@ -0,0 +1,151 @@
+package mfa
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// GetUserMFA fetches the user's enrollment, or nil if they never started one
+func (s *Store) GetUserMFA(userID int32) (*types.UserMFA, error) {
+	row, err := s.db.GetUserMFA(context.Background(), userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	mfa := &types.UserMFA{
+		UserID:         row.UserID,
+		Secret:         row.TotpSecret,
+		IsEnabled:      row.IsEnabled,
+		LastUsedStep:   row.LastUsedStep,
+		FailedAttempts: int(row.FailedAttempts),
+	}
+	if row.EnabledAt.Valid {
+		enabled := row.EnabledAt.Time
+		mfa.EnabledAt = &enabled
+	}
+	if row.LockedUntil.Valid {
+		lockedUntil := row.LockedUntil.Time
+		mfa.LockedUntil = &lockedUntil
+	}
+	return mfa, nil
+}
+
+// SaveTOTPSecret starts a new, unconfirmed enrollment with the secret
+func (s *Store) SaveTOTPSecret(ctx context.Context, userID int32, secret string) error {
+	return s.db.UpsertUserMFASecret(ctx, database.UpsertUserMFASecretParams{
+		UserID:     userID,
+		TotpSecret: secret,
+	})
+}
+
+// EnableMFA confirms the user's enrollment
+func (s *Store) EnableMFA(ctx context.Context, userID int32) error {
+	return s.db.EnableUserMFA(ctx, userID)
+}
+
+// DisableMFA removes the user's enrollment and recovery codes
+func (s *Store) DisableMFA(ctx context.Context, userID int32) error {
+	if err := s.db.DeleteRecoveryCodes(ctx, userID); err != nil {
+		return err
+	}
+	return s.db.DeleteUserMFA(ctx, userID)
+}
+
+// UseTOTPStep records the time step of an accepted code. It returns false if a code
+// of this or a later step was already used, so that codes cannot be replayed.
+func (s *Store) UseTOTPStep(ctx context.Context, userID int32, step int64) (bool, error) {
+	affected, err := s.db.UseTOTPStep(ctx, database.UseTOTPStepParams{
+		LastUsedStep:   step,
+		UserID:         userID,
+		LastUsedStep_2: step,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// ReplaceRecoveryCodes replaces all of the user's recovery codes with the given hashes
+func (s *Store) ReplaceRecoveryCodes(ctx context.Context, userID int32, codeHashes []string) error {
+	if err := s.db.DeleteRecoveryCodes(ctx, userID); err != nil {
+		return err
+	}
+
+	for _, hash := range codeHashes {
+		err := s.db.CreateRecoveryCode(ctx, database.CreateRecoveryCodeParams{
+			UserID:   userID,
+			CodeHash: hash,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UseRecoveryCode consumes a recovery code. It returns false if the code does not
+// exist or was already used.
+func (s *Store) UseRecoveryCode(ctx context.Context, userID int32, codeHash string) (bool, error) {
+	affected, err := s.db.UseRecoveryCode(ctx, database.UseRecoveryCodeParams{
+		UserID:   userID,
+		CodeHash: codeHash,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// RecordFailedAttempt counts a wrong login code and returns the user's failed attempts
+// so far. The count is incremented atomically, so concurrent attempts each see their own.
+func (s *Store) RecordFailedAttempt(ctx context.Context, userID int32) (int, error) {
+	attempts, err := s.db.RecordFailedMFAAttempt(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(attempts), nil
+}
+
+// Lock refuses the user's login codes until the given time and starts counting failed
+// attempts again
+func (s *Store) Lock(ctx context.Context, userID int32, until time.Time) error {
+	return s.db.LockUserMFA(ctx, database.LockUserMFAParams{
+		LockedUntil: sql.NullTime{Time: until, Valid: true},
+		UserID:      userID,
+	})
+}
+
+// ResetFailedAttempts clears the user's failed attempts and lock after a successful login
+func (s *Store) ResetFailedAttempts(ctx context.Context, userID int32) error {
+	return s.db.ResetFailedMFAAttempts(ctx, userID)
+}
+
+// CountRecoveryCodes counts the user's unused recovery codes
+func (s *Store) CountRecoveryCodes(userID int32) (int, error) {
+	count, err := s.db.CountUnusedRecoveryCodes(context.Background(), userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
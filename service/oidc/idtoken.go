@@ -0,0 +1,226 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Leeway allows for clock skew between this server and the provider
+const Leeway = time.Minute
+
+// jwksRefreshInterval limits how often an unknown key ID triggers a refetch of the
+// provider's keys, so that forged tokens cannot be used to flood the provider
+var jwksRefreshInterval = time.Minute
+
+// signingMethods are the ID token algorithms accepted. HMAC is excluded, as the keys
+// are public.
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "ES256", "ES384", "EdDSA"}
+
+// flexibleBool decodes a boolean that some providers send as a string
+type flexibleBool bool
+
+func (b *flexibleBool) UnmarshalJSON(data []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	switch v := v.(type) {
+	case bool:
+		*b = flexibleBool(v)
+	case string:
+		*b = v == "true"
+	default:
+		*b = false
+	}
+	return nil
+}
+
+// Claims are the claims of an ID token the login flow uses
+type Claims struct {
+	jwt.RegisteredClaims
+	Nonce           string       `json:"nonce"`
+	AuthorizedParty string       `json:"azp"`
+	Email           string       `json:"email"`
+	EmailVerified   flexibleBool `json:"email_verified"`
+	Name            string       `json:"name"`
+}
+
+// HasVerifiedEmail checks whether the provider vouches for the user's email
+func (c *Claims) HasVerifiedEmail() bool {
+	return c.Email != "" && bool(c.EmailVerified)
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// parseJWK decodes a public signing key. Keys of unsupported types are skipped by
+// returning nil.
+func parseJWK(key jsonWebKey) (interface{}, error) {
+	if key.Use != "" && key.Use != "sig" {
+		return nil, nil
+	}
+
+	decode := base64.RawURLEncoding.DecodeString
+	switch key.Kty {
+	case "RSA":
+		n, err := decode(key.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(key.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch key.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, nil
+		}
+		x, err := decode(key.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(key.Y)
+		if err != nil {
+			return nil, err
+		}
+		pub := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, fmt.Errorf("EC key is not on the curve")
+		}
+		return pub, nil
+	case "OKP":
+		if key.Crv != "Ed25519" {
+			return nil, nil
+		}
+		x, err := decode(key.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, nil
+	}
+}
+
+// key returns the provider's signing key with the ID, refetching the key set when the
+// ID is unknown, as happens after the provider rotates its keys
+func (p *Provider) key(ctx context.Context, kid string) (interface{}, error) {
+	metadata, err := p.Metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+	if p.keys != nil && time.Since(p.keysFetchedAt) < jwksRefreshInterval {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	var set jsonWebKeySet
+	if err := p.getJSON(ctx, metadata.JWKSURI, &set); err != nil {
+		return nil, fmt.Errorf("error fetching signing keys: %v", err)
+	}
+
+	keys := make(map[string]interface{}, len(set.Keys))
+	for _, jwk := range set.Keys {
+		key, err := parseJWK(jwk)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signing key %q: %v", jwk.Kid, err)
+		}
+		if key != nil {
+			keys[jwk.Kid] = key
+		}
+	}
+	p.keys = keys
+	p.keysFetchedAt = time.Now()
+
+	key, ok := p.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+// VerifyIDToken checks the ID token's signature against the provider's keys and its
+// issuer, audience, lifetime and nonce, and returns its claims
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken string, nonce string) (*Claims, error) {
+	parser := jwt.NewParser(jwt.WithValidMethods(signingMethods), jwt.WithoutClaimsValidation())
+
+	var claims Claims
+	_, err := parser.ParseWithClaims(rawIDToken, &claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.key(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ID token is invalid: %v", err)
+	}
+
+	if err := p.validateClaims(&claims, nonce, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return &claims, nil
+}
+
+// validateClaims checks the claims of a signed ID token (OpenID Connect Core 3.1.3.7)
+func (p *Provider) validateClaims(claims *Claims, nonce string, now time.Time) error {
+	if claims.Issuer != p.Issuer {
+		return fmt.Errorf("ID token is from issuer %q", claims.Issuer)
+	}
+	if claims.Subject == "" {
+		return fmt.Errorf("ID token has no subject")
+	}
+	if !claims.VerifyAudience(p.ClientID, true) {
+		return fmt.Errorf("ID token is not for this client")
+	}
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.ClientID {
+		return fmt.Errorf("ID token is not authorized for this client")
+	}
+	if claims.ExpiresAt == nil || !now.Before(claims.ExpiresAt.Add(Leeway)) {
+		return fmt.Errorf("ID token has expired")
+	}
+	if claims.IssuedAt == nil || now.Add(Leeway).Before(claims.IssuedAt.Time) {
+		return fmt.Errorf("ID token is issued in the future")
+	}
+	if claims.NotBefore != nil && now.Add(Leeway).Before(claims.NotBefore.Time) {
+		return fmt.Errorf("ID token is not valid yet")
+	}
+	if claims.Nonce == "" || claims.Nonce != nonce {
+		return fmt.Errorf("ID token nonce does not match")
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StateTTL is how long a user may take to log in at the provider
+const StateTTL = 10 * time.Minute
+
+// maxResponseSize limits how much of a provider response is read
+const maxResponseSize = 1 << 20
+
+// Scopes requested from every provider
+var Scopes = []string{"openid", "email", "profile"}
+
+// Metadata is the part of a provider's discovery document the login flow uses
+type Metadata struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+}
+
+// Provider is an OpenID Connect provider users can log in with. Its discovery document
+// and signing keys are fetched when first needed and cached.
+type Provider struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+
+	client *http.Client
+
+	mu            sync.Mutex
+	metadata      *Metadata
+	keys          map[string]interface{}
+	keysFetchedAt time.Time
+}
+
+// NewProvider creates a provider. A nil client uses a client with a short timeout.
+func NewProvider(name string, issuer string, clientID string, clientSecret string, redirectURL string, client *http.Client) *Provider {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &Provider{
+		Name:         name,
+		Issuer:       issuer,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		client:       client,
+	}
+}
+
+// GenerateCodeVerifier creates a random PKCE code verifier (RFC 7636)
+func GenerateCodeVerifier() (string, error) {
+	return randomString()
+}
+
+// GenerateState creates a random value for the state and nonce parameters
+func GenerateState() (string, error) {
+	return randomString()
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CodeChallenge derives the S256 code challenge of a code verifier
+func CodeChallenge(verifier string) string {
+	hash := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// Metadata fetches the provider's discovery document, or returns the cached one
+func (p *Provider) Metadata(ctx context.Context) (*Metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	var metadata Metadata
+	discoveryURL := strings.TrimSuffix(p.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := p.getJSON(ctx, discoveryURL, &metadata); err != nil {
+		return nil, fmt.Errorf("error fetching discovery document: %v", err)
+	}
+
+	// The document must be the issuer's own, or its tokens would not validate
+	if metadata.Issuer != p.Issuer {
+		return nil, fmt.Errorf("discovery document is for issuer %q, expected %q", metadata.Issuer, p.Issuer)
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, fmt.Errorf("discovery document is missing endpoints")
+	}
+	if len(metadata.CodeChallengeMethodsSupported) > 0 && !contains(metadata.CodeChallengeMethodsSupported, "S256") {
+		return nil, fmt.Errorf("provider does not support PKCE with S256")
+	}
+
+	p.metadata = &metadata
+	return p.metadata, nil
+}
+
+// AuthCodeURL builds the URL that sends the user to the provider to log in
+func (p *Provider) AuthCodeURL(ctx context.Context, state string, nonce string, codeVerifier string) (string, error) {
+	metadata, err := p.Metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(metadata.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %v", err)
+	}
+
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.ClientID)
+	query.Set("redirect_uri", p.RedirectURL)
+	query.Set("scope", strings.Join(Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", CodeChallenge(codeVerifier))
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Exchange redeems an authorization code for the user's ID token
+func (p *Provider) Exchange(ctx context.Context, code string, codeVerifier string) (string, error) {
+	metadata, err := p.Metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+
+	// client_secret_basic is the default when the provider lists no methods
+	basicAuth := p.ClientSecret != "" && (len(metadata.TokenEndpointAuthMethodsSupported) == 0 ||
+		contains(metadata.TokenEndpointAuthMethodsSupported, "client_secret_basic"))
+	if !basicAuth {
+		form.Set("client_id", p.ClientID)
+		if p.ClientSecret != "" {
+			form.Set("client_secret", p.ClientSecret)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if basicAuth {
+		req.SetBasicAuth(url.QueryEscape(p.ClientID), url.QueryEscape(p.ClientSecret))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error requesting token: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var token tokenResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&token); err != nil {
+		return "", fmt.Errorf("error decoding token response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if token.Error != "" {
+			return "", fmt.Errorf("token request failed: %s %s", token.Error, token.ErrorDescription)
+		}
+		return "", fmt.Errorf("token request failed with status %d", resp.StatusCode)
+	}
+	if token.IDToken == "" {
+		return "", fmt.Errorf("token response has no ID token")
+	}
+
+	return token.IDToken, nil
+}
+
+// getJSON fetches and decodes a JSON document
+func (p *Provider) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(v)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,257 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// mockProvider is a minimal OpenID Connect provider. It issues an ID token for any code
+// it handed out, as long as the code verifier matches the code challenge.
+type mockProvider struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu         sync.Mutex
+	key        *rsa.PrivateKey
+	kid        string
+	challenges map[string]string
+	nonces     map[string]string
+	claims     func(claims jwt.MapClaims)
+	jwksHits   int
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	m := &mockProvider{t: t, challenges: map[string]string{}, nonces: map[string]string{}}
+	m.rotate("key-1")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                m.server.URL,
+			"authorization_endpoint":                m.server.URL + "/authorize",
+			"token_endpoint":                        m.server.URL + "/token",
+			"jwks_uri":                              m.server.URL + "/jwks",
+			"code_challenge_methods_supported":      []string{"S256"},
+			"token_endpoint_auth_methods_supported": []string{"client_secret_basic"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.jwksHits++
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": m.kid,
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(m.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(m.key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", m.handleToken)
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+
+	return m
+}
+
+func (m *mockProvider) rotate(kid string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		m.t.Fatal(err)
+	}
+	m.mu.Lock()
+	m.key, m.kid = key, kid
+	m.mu.Unlock()
+}
+
+// authorize plays the user logging in at the provider and returns the code
+func (m *mockProvider) authorize(authURL string) string {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		m.t.Fatal(err)
+	}
+	query := u.Query()
+	if query.Get("code_challenge_method") != "S256" || query.Get("response_type") != "code" {
+		m.t.Fatalf("unexpected authorization request %s", authURL)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	code := "code-" + query.Get("state")
+	m.challenges[code] = query.Get("code_challenge")
+	m.nonces[code] = query.Get("nonce")
+	return code
+}
+
+func (m *mockProvider) handleToken(w http.ResponseWriter, r *http.Request) {
+	id, secret, ok := r.BasicAuth()
+	if !ok || id != "client-id" || secret != "client-secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	code := r.PostFormValue("code")
+	m.mu.Lock()
+	challenge, ok := m.challenges[code]
+	nonce := m.nonces[code]
+	delete(m.challenges, code)
+	m.mu.Unlock()
+
+	if !ok || CodeChallenge(r.PostFormValue("code_verifier")) != challenge {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{"id_token": m.idToken(nonce), "token_type": "Bearer"})
+}
+
+func (m *mockProvider) idToken(nonce string) string {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            m.server.URL,
+		"sub":            "user-123",
+		"aud":            "client-id",
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"nonce":          nonce,
+		"email":          "jane@example.com",
+		"email_verified": "true",
+	}
+	if m.claims != nil {
+		m.claims(claims)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = m.kid
+	signed, err := token.SignedString(m.key)
+	if err != nil {
+		m.t.Fatal(err)
+	}
+	return signed
+}
+
+func (m *mockProvider) login(t *testing.T, p *Provider) (*Claims, error) {
+	ctx := context.Background()
+	state, _ := GenerateState()
+	nonce, _ := GenerateState()
+	verifier, _ := GenerateCodeVerifier()
+
+	authURL, err := p.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		t.Fatalf("unexpected error building the authorization URL: %v", err)
+	}
+
+	idToken, err := p.Exchange(ctx, m.authorize(authURL), verifier)
+	if err != nil {
+		t.Fatalf("unexpected error exchanging the code: %v", err)
+	}
+
+	return p.VerifyIDToken(ctx, idToken, nonce)
+}
+
+func TestLogin(t *testing.T) {
+	m := newMockProvider(t)
+	p := NewProvider("mock", m.server.URL, "client-id", "client-secret", "http://localhost:3000/auth/mock/callback", nil)
+
+	claims, err := m.login(t, p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claims.Subject != "user-123" || !claims.HasVerifiedEmail() {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+
+	// A wrong code verifier is refused by the provider
+	state, _ := GenerateState()
+	verifier, _ := GenerateCodeVerifier()
+	authURL, _ := p.AuthCodeURL(context.Background(), state, "nonce", verifier)
+	if _, err := p.Exchange(context.Background(), m.authorize(authURL), "wrong-verifier"); err == nil {
+		t.Error("expected the exchange with a wrong code verifier to fail")
+	}
+
+	// The nonce must be the one sent with the authorization request
+	idToken := m.idToken("nonce")
+	if _, err := p.VerifyIDToken(context.Background(), idToken, "other-nonce"); err == nil {
+		t.Error("expected a token with another nonce to be rejected")
+	}
+
+	invalid := map[string]func(jwt.MapClaims){
+		"wrong audience": func(c jwt.MapClaims) { c["aud"] = "other-client" },
+		"wrong issuer":   func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" },
+		"expired":        func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-2 * Leeway).Unix() },
+		"future":         func(c jwt.MapClaims) { c["iat"] = time.Now().Add(2 * Leeway).Unix() },
+		"no subject":     func(c jwt.MapClaims) { delete(c, "sub") },
+		"foreign azp": func(c jwt.MapClaims) {
+			c["aud"] = []string{"client-id", "other-client"}
+			c["azp"] = "other-client"
+		},
+	}
+	for name, modify := range invalid {
+		m.claims = modify
+		if _, err := p.VerifyIDToken(context.Background(), m.idToken("nonce"), "nonce"); err == nil {
+			t.Errorf("%s: expected the token to be rejected", name)
+		}
+	}
+	m.claims = nil
+
+	// A token signed with HMAC using the public key must not validate
+	hmacToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"iss": m.server.URL, "nonce": "nonce"})
+	hmacToken.Header["kid"] = "key-1"
+	signed, _ := hmacToken.SignedString([]byte("secret"))
+	if _, err := p.VerifyIDToken(context.Background(), signed, "nonce"); err == nil {
+		t.Error("expected an HMAC token to be rejected")
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	m := newMockProvider(t)
+	p := NewProvider("mock", m.server.URL, "client-id", "client-secret", "http://localhost:3000/auth/mock/callback", nil)
+
+	if _, err := m.login(t, p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// An unknown key ID only refetches the keys after the refresh interval
+	m.rotate("key-2")
+	if _, err := m.login(t, p); err == nil {
+		t.Error("expected the new key to be unknown before the refresh interval")
+	}
+	if m.jwksHits != 1 {
+		t.Errorf("expected one key set fetch, got %d", m.jwksHits)
+	}
+
+	p.keysFetchedAt = time.Now().Add(-jwksRefreshInterval)
+	if _, err := m.login(t, p); err != nil {
+		t.Fatalf("expected the rotated key to be fetched: %v", err)
+	}
+	if m.jwksHits != 2 {
+		t.Errorf("expected two key set fetches, got %d", m.jwksHits)
+	}
+}
+
+func TestDiscoveryIssuerMismatch(t *testing.T) {
+	m := newMockProvider(t)
+	p := NewProvider("mock", m.server.URL+"/", "client-id", "client-secret", "http://localhost:3000/auth/mock/callback", nil)
+
+	if _, err := p.Metadata(context.Background()); err == nil {
+		t.Error("expected a discovery document for another issuer to be rejected")
+	}
+}
@@ -0,0 +1,126 @@
+package oidc
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+func identityFromRow(row database.UserIdentity) *types.UserIdentity {
+	identity := &types.UserIdentity{
+		ID:        row.IdentityID,
+		UserID:    row.UserID,
+		Provider:  row.Provider,
+		Subject:   row.Subject,
+		Email:     row.Email,
+		CreatedAt: row.CreatedAt,
+	}
+	if row.LastLoginAt.Valid {
+		lastLogin := row.LastLoginAt.Time
+		identity.LastLoginAt = &lastLogin
+	}
+	return identity
+}
+
+// GetIdentity fetches the identity of a provider account, or nil if it is not linked
+func (s *Store) GetIdentity(provider string, subject string) (*types.UserIdentity, error) {
+	row, err := s.db.GetUserIdentity(context.Background(), database.GetUserIdentityParams{
+		Provider: provider,
+		Subject:  subject,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return identityFromRow(row), nil
+}
+
+// GetIdentitiesByUserID fetches the provider accounts linked to the user
+func (s *Store) GetIdentitiesByUserID(userID int32) ([]*types.UserIdentity, error) {
+	rows, err := s.db.GetUserIdentitiesByUserID(context.Background(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	identities := make([]*types.UserIdentity, 0, len(rows))
+	for _, row := range rows {
+		identities = append(identities, identityFromRow(row))
+	}
+	return identities, nil
+}
+
+// CreateIdentity links a provider account to a user
+func (s *Store) CreateIdentity(ctx context.Context, identity *types.UserIdentity) error {
+	return s.db.CreateUserIdentity(ctx, database.CreateUserIdentityParams{
+		UserID:   identity.UserID,
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	})
+}
+
+// UpdateIdentityLogin records a login with the identity and the email it reported
+func (s *Store) UpdateIdentityLogin(ctx context.Context, id int32, email string) error {
+	return s.db.UpdateUserIdentityLogin(ctx, database.UpdateUserIdentityLoginParams{
+		Email:      email,
+		IdentityID: id,
+	})
+}
+
+// CreateLoginState stores a login that was sent to a provider and clears expired ones
+func (s *Store) CreateLoginState(ctx context.Context, state *types.OIDCLoginState) error {
+	if err := s.db.DeleteExpiredOIDCLoginStates(ctx); err != nil {
+		return err
+	}
+
+	return s.db.CreateOIDCLoginState(ctx, database.CreateOIDCLoginStateParams{
+		StateHash:    state.StateHash,
+		Provider:     state.Provider,
+		Nonce:        state.Nonce,
+		CodeVerifier: state.CodeVerifier,
+		ExpiresAt:    state.ExpiresAt,
+	})
+}
+
+// ConsumeLoginState fetches an unexpired login state and deletes it so that it can
+// only be used once. It returns nil if the state is unknown, expired or already used.
+func (s *Store) ConsumeLoginState(ctx context.Context, stateHash string) (*types.OIDCLoginState, error) {
+	row, err := s.db.GetOIDCLoginState(ctx, stateHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// Another request may have consumed it in the meantime
+	affected, err := s.db.DeleteOIDCLoginState(ctx, stateHash)
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, nil
+	}
+
+	return &types.OIDCLoginState{
+		StateHash:    row.StateHash,
+		Provider:     row.Provider,
+		Nonce:        row.Nonce,
+		CodeVerifier: row.CodeVerifier,
+		ExpiresAt:    row.ExpiresAt,
+	}, nil
+}
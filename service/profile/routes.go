@@ -0,0 +1,211 @@
+package profile
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/service/glucose"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+type Handler struct {
+	store     types.ProfileStore
+	userStore types.UserStore
+}
+
+func NewHandler(store types.ProfileStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, userStore: userStore}
+}
+
+// RegisterRoutes for Fiber
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/user/me/profile", auth.WithSharedAccess(h.handleGetProfile, h.userStore, types.ShareScopeProfile))
+	router.Put("/user/me/profile", auth.WithJWTAuth(h.handleUpdateProfile, h.userStore))
+	router.Get("/user/me/profile/weights", auth.WithSharedAccess(h.handleGetWeights, h.userStore, types.ShareScopeProfile))
+	router.Post("/user/me/profile/weights", auth.WithJWTAuth(h.handleCreateWeight, h.userStore))
+	router.Delete("/user/me/profile/weights/:id", auth.WithJWTAuth(h.handleDeleteWeight, h.userStore))
+}
+
+// Handler for getting the current user's profile
+func (h *Handler) handleGetProfile(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	profile, err := h.store.GetProfileByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting profile: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(profileResponse(profile))
+}
+
+// Handler for creating or replacing the current user's profile
+func (h *Handler) handleUpdateProfile(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.UpdateProfilePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	// Store the target range in mg/dL regardless of the display unit
+	targetLow := glucose.ToMgdL(payload.TargetLow, payload.GlucoseUnit)
+	targetHigh := glucose.ToMgdL(payload.TargetHigh, payload.GlucoseUnit)
+	if targetLow < 40 || targetHigh > 400 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Target range must be between 40 and 400 mg/dL"})
+	}
+
+	// Fall back to the consensus thresholds when none are given
+	veryLow := types.DefaultVeryLowMgdL
+	if payload.VeryLow > 0 {
+		veryLow = glucose.ToMgdL(payload.VeryLow, payload.GlucoseUnit)
+	}
+	veryHigh := types.DefaultVeryHighMgdL
+	if payload.VeryHigh > 0 {
+		veryHigh = glucose.ToMgdL(payload.VeryHigh, payload.GlucoseUnit)
+	}
+	if veryLow >= targetLow || veryHigh <= targetHigh || veryHigh > 600 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Very low and very high thresholds must lie outside the target range"})
+	}
+
+	profile := &types.Profile{
+		UserID:         userID,
+		Sex:            payload.Sex,
+		HeightCm:       payload.HeightCm,
+		DiabetesType:   payload.DiabetesType,
+		GlucoseUnit:    payload.GlucoseUnit,
+		TargetLowMgdL:  targetLow,
+		TargetHighMgdL: targetHigh,
+		VeryLowMgdL:    veryLow,
+		VeryHighMgdL:   veryHigh,
+		Timezone:       payload.Timezone,
+	}
+
+	if payload.DateOfBirth != "" {
+		dob, _ := time.Parse("2006-01-02", payload.DateOfBirth)
+		if dob.After(time.Now()) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Date of birth cannot be in the future"})
+		}
+		profile.DateOfBirth = &dob
+	}
+
+	if payload.DiagnosisDate != "" {
+		diagnosed, _ := time.Parse("2006-01-02", payload.DiagnosisDate)
+		if diagnosed.After(time.Now()) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Diagnosis date cannot be in the future"})
+		}
+		profile.DiagnosisDate = &diagnosed
+	}
+
+	if err := h.store.UpsertProfile(c.Context(), profile); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error updating profile: %v", err)})
+	}
+
+	return h.handleGetProfile(c)
+}
+
+// Handler for listing the current user's weight history
+func (h *Handler) handleGetWeights(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	const (
+		defaultLimit = 50
+		maxLimit     = 500
+	)
+
+	limit := defaultLimit
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= maxLimit {
+		limit = l
+	}
+
+	entries, err := h.store.GetWeightEntries(userID, int32(limit))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting weight entries: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"weights": entries})
+}
+
+// Handler for recording a weight measurement
+func (h *Handler) handleCreateWeight(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.CreateWeightEntryPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	if payload.RecordedAt.IsZero() {
+		payload.RecordedAt = time.Now()
+	}
+
+	entry := &types.WeightEntry{
+		UserID:     userID,
+		WeightKg:   payload.WeightKg,
+		RecordedAt: payload.RecordedAt,
+	}
+
+	id, err := h.store.CreateWeightEntry(c.Context(), entry)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error creating weight entry: %v", err)})
+	}
+	entry.ID = id
+
+	return c.Status(fiber.StatusCreated).JSON(entry)
+}
+
+// Handler for deleting a weight measurement
+func (h *Handler) handleDeleteWeight(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+
+	deleted, err := h.store.DeleteWeightEntry(c.Context(), int32(intID), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error deleting weight entry: %v", err)})
+	}
+	if !deleted {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Weight entry not found"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Weight entry deleted successfully"})
+}
+
+// profileResponse adds the target range expressed in the user's preferred unit
+func profileResponse(profile *types.Profile) fiber.Map {
+	return fiber.Map{
+		"profile":     profile,
+		"target_low":  glucose.FromMgdL(profile.TargetLowMgdL, profile.GlucoseUnit),
+		"target_high": glucose.FromMgdL(profile.TargetHighMgdL, profile.GlucoseUnit),
+		"very_low":    glucose.FromMgdL(profile.VeryLowMgdL, profile.GlucoseUnit),
+		"very_high":   glucose.FromMgdL(profile.VeryHighMgdL, profile.GlucoseUnit),
+	}
+}
@@ -0,0 +1,171 @@
+package profile
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// GetProfileByUserID fetches the user's profile, returning the default settings
+// when the user has not filled it in yet
+func (s *Store) GetProfileByUserID(userID int32) (*types.Profile, error) {
+	profile := &types.Profile{
+		UserID:         userID,
+		GlucoseUnit:    types.GlucoseUnitMgdL,
+		TargetLowMgdL:  types.DefaultTargetLowMgdL,
+		TargetHighMgdL: types.DefaultTargetHighMgdL,
+		VeryLowMgdL:    types.DefaultVeryLowMgdL,
+		VeryHighMgdL:   types.DefaultVeryHighMgdL,
+		Timezone:       types.DefaultTimezone,
+	}
+
+	row, err := s.db.GetUserProfile(context.Background(), userID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	if err == nil {
+		profile.DateOfBirth = nullTimeToPtr(row.DateOfBirth)
+		profile.Sex = string(row.Sex.UserProfilesSex)
+		profile.HeightCm = nullFloatToPtr(row.HeightCm)
+		profile.DiabetesType = string(row.DiabetesType.UserProfilesDiabetesType)
+		profile.DiagnosisDate = nullTimeToPtr(row.DiagnosisDate)
+		profile.GlucoseUnit = string(row.GlucoseUnit)
+		profile.TargetLowMgdL = row.TargetLow
+		profile.TargetHighMgdL = row.TargetHigh
+		profile.VeryLowMgdL = row.VeryLowThreshold
+		profile.VeryHighMgdL = row.VeryHighThreshold
+		profile.Timezone = row.Timezone
+		profile.IsComplete = true
+		profile.UpdatedAt = &row.UpdatedAt
+	}
+
+	weights, err := s.GetWeightEntries(userID, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(weights) > 0 {
+		profile.LatestWeight = weights[0]
+	}
+
+	return profile, nil
+}
+
+// UpsertProfile creates or replaces the user's profile
+func (s *Store) UpsertProfile(ctx context.Context, profile *types.Profile) error {
+	err := s.db.UpsertUserProfile(ctx, database.UpsertUserProfileParams{
+		UserID:      profile.UserID,
+		DateOfBirth: ptrToNullTime(profile.DateOfBirth),
+		Sex: database.NullUserProfilesSex{
+			UserProfilesSex: database.UserProfilesSex(profile.Sex),
+			Valid:           profile.Sex != "",
+		},
+		HeightCm: ptrToNullFloat(profile.HeightCm),
+		DiabetesType: database.NullUserProfilesDiabetesType{
+			UserProfilesDiabetesType: database.UserProfilesDiabetesType(profile.DiabetesType),
+			Valid:                    profile.DiabetesType != "",
+		},
+		DiagnosisDate:     ptrToNullTime(profile.DiagnosisDate),
+		GlucoseUnit:       database.UserProfilesGlucoseUnit(profile.GlucoseUnit),
+		TargetLow:         profile.TargetLowMgdL,
+		TargetHigh:        profile.TargetHighMgdL,
+		VeryLowThreshold:  profile.VeryLowMgdL,
+		VeryHighThreshold: profile.VeryHighMgdL,
+		Timezone:          profile.Timezone,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetWeightEntries fetches the user's most recent weight entries
+func (s *Store) GetWeightEntries(userID int32, limit int32) ([]*types.WeightEntry, error) {
+	rows, err := s.db.GetWeightEntriesByUserID(context.Background(), database.GetWeightEntriesByUserIDParams{
+		UserID: userID,
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*types.WeightEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, &types.WeightEntry{
+			ID:         row.WeightEntryID,
+			UserID:     row.UserID,
+			WeightKg:   row.WeightKg,
+			RecordedAt: row.RecordedAt,
+		})
+	}
+
+	return entries, nil
+}
+
+// CreateWeightEntry adds a weight measurement to the user's history
+func (s *Store) CreateWeightEntry(ctx context.Context, entry *types.WeightEntry) (int32, error) {
+	id, err := s.db.CreateWeightEntry(ctx, database.CreateWeightEntryParams{
+		UserID:     entry.UserID,
+		WeightKg:   entry.WeightKg,
+		RecordedAt: entry.RecordedAt.UTC(),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(id), nil
+}
+
+// DeleteWeightEntry deletes a weight entry. It returns false if it did not exist.
+func (s *Store) DeleteWeightEntry(ctx context.Context, id int32, userID int32) (bool, error) {
+	rows, err := s.db.DeleteWeightEntry(ctx, database.DeleteWeightEntryParams{
+		WeightEntryID: id,
+		UserID:        userID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func nullTimeToPtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+func ptrToNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+func nullFloatToPtr(f sql.NullFloat64) *float64 {
+	if !f.Valid {
+		return nil
+	}
+	return &f.Float64
+}
+
+func ptrToNullFloat(f *float64) sql.NullFloat64 {
+	if f == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *f, Valid: true}
+}
@@ -0,0 +1,49 @@
+package profile
+
+import (
+	"context"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+)
+
+// TimelineTypes lists the event types of the store
+func (s *Store) TimelineTypes() []string {
+	return []string{types.TimelineWeight}
+}
+
+// TimelineEvents returns up to the query's limit of the user's weight measurements on
+// the page, in the order they were recorded
+func (s *Store) TimelineEvents(userID int32, query types.TimelineQuery) ([]*types.TimelineEvent, error) {
+	start, afterID := query.Bound(types.TimelineWeight)
+
+	rows, err := s.db.GetWeightEntriesForTimeline(context.Background(), database.GetWeightEntriesForTimelineParams{
+		UserID:        userID,
+		RecordedAt:    start.UTC(),
+		RecordedAt_2:  start.UTC(),
+		WeightEntryID: afterID,
+		RecordedAt_3:  query.End.UTC(),
+		Limit:         int32(query.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*types.TimelineEvent, 0, len(rows))
+	for _, row := range rows {
+		entry := &types.WeightEntry{
+			ID:         row.WeightEntryID,
+			UserID:     row.UserID,
+			WeightKg:   row.WeightKg,
+			RecordedAt: row.RecordedAt,
+		}
+		events = append(events, &types.TimelineEvent{
+			Type:       types.TimelineWeight,
+			ID:         entry.ID,
+			OccurredAt: entry.RecordedAt,
+			Data:       entry,
+		})
+	}
+
+	return events, nil
+}
@@ -0,0 +1,264 @@
+package share
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/service/email"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+type Handler struct {
+	store     types.ShareStore
+	userStore types.UserStore
+	mailer    email.ShareMailer
+}
+
+func NewHandler(store types.ShareStore, userStore types.UserStore, mailer email.ShareMailer) *Handler {
+	return &Handler{store: store, userStore: userStore, mailer: mailer}
+}
+
+// RegisterRoutes for Fiber
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	rateLimiterInvite := auth.CreateRateLimiter(10, time.Hour, "Too many invitations. Please try again later.")
+	rateLimiterAccept := auth.CreateRateLimiter(10, 15*time.Minute, "Too many attempts. Please try again later.")
+
+	router.Get("/shares", auth.WithJWTAuth(h.handleGetShares, h.userStore))
+	router.Post("/shares", rateLimiterInvite, auth.WithJWTAuth(h.handleCreateShare, h.userStore))
+	router.Get("/shares/received", auth.WithJWTAuth(h.handleGetReceivedShares, h.userStore))
+	router.Post("/shares/accept", rateLimiterAccept, auth.WithJWTAuth(h.handleAcceptShare, h.userStore))
+	router.Put("/shares/:id", auth.WithJWTAuth(h.handleUpdateShare, h.userStore))
+	router.Delete("/shares/:id", auth.WithJWTAuth(h.handleRevokeShare, h.userStore))
+}
+
+// Handler for listing who can see the current user's data, including pending invitations
+func (h *Handler) handleGetShares(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	shares, err := h.store.GetSharesByOwnerID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting shares: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"shares": Visible(shares, time.Now()),
+		"scopes": types.ShareScopes,
+	})
+}
+
+// Handler for listing the users whose data the current user can see
+func (h *Handler) handleGetReceivedShares(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	shares, err := h.store.GetSharesByCaregiverID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting shares: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"shares": shares})
+}
+
+// Handler for inviting someone by email to follow the current user's data
+func (h *Handler) handleCreateShare(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.CreateSharePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	scopes, err := NormalizeScopes(payload.Access, payload.Scopes)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	owner, err := h.userStore.GetUserByID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting user: %v", err)})
+	}
+
+	inviteeEmail := strings.ToLower(strings.TrimSpace(payload.Email))
+	if strings.EqualFold(inviteeEmail, owner.Email) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "You cannot share your data with yourself"})
+	}
+
+	existing, err := h.store.GetSharesByOwnerID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting shares: %v", err)})
+	}
+	if HasOpenInvitation(existing, inviteeEmail, time.Now()) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "You already share your data with this email address"})
+	}
+
+	// Generate a single-use invitation token and store only its hash
+	token, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	id, err := h.store.CreateShare(c.Context(), &types.Share{
+		OwnerID:      userID,
+		InviteeEmail: inviteeEmail,
+		Access:       payload.Access,
+		Scopes:       scopes,
+		ExpiresAt:    time.Now().Add(InvitationTTL),
+	}, auth.HashToken(token))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error creating share: %v", err)})
+	}
+
+	// Send email asynchronously
+	go func() {
+		err := h.mailer.SendShareInvitationEmail(inviteeEmail, owner.Username, token)
+		if err != nil {
+			fmt.Printf("Error sending share invitation email: %v\n", err)
+		}
+	}()
+
+	created, err := h.store.GetShareByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting share: %v", err)})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(created)
+}
+
+// Handler for accepting an invitation with the emailed token
+func (h *Handler) handleAcceptShare(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.AcceptSharePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	share, err := h.store.GetShareByTokenHash(auth.HashToken(payload.Token))
+	if err != nil || !IsPending(share, time.Now()) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invitation is invalid or has expired"})
+	}
+
+	user, err := h.userStore.GetUserByID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting user: %v", err)})
+	}
+
+	// The invitation only works for the account it was sent to
+	if share.OwnerID == userID || !strings.EqualFold(share.InviteeEmail, user.Email) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "This invitation was sent to a different email address"})
+	}
+
+	accepted, err := h.store.AcceptShare(c.Context(), share.ID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error accepting invitation: %v", err)})
+	}
+	if !accepted {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invitation is invalid or has expired"})
+	}
+
+	updated, err := h.store.GetShareByID(share.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting share: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(updated)
+}
+
+// Handler for changing what a share gives access to
+func (h *Handler) handleUpdateShare(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+
+	// Parse JSON payload
+	var payload types.UpdateSharePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	scopes, err := NormalizeScopes(payload.Access, payload.Scopes)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	// Only the owner can change a share
+	share, err := h.store.GetShareByID(int32(intID))
+	if err != nil || share.OwnerID != userID || share.Status == types.ShareStatusRevoked {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Share not found"})
+	}
+
+	if err := h.store.UpdateShareAccess(c.Context(), share.ID, payload.Access, scopes); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error updating share: %v", err)})
+	}
+
+	updated, err := h.store.GetShareByID(share.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting share: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(updated)
+}
+
+// Handler for ending a share. The owner can revoke access and the caregiver can stop following.
+func (h *Handler) handleRevokeShare(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+
+	share, err := h.store.GetShareByID(int32(intID))
+	if err != nil || !IsParty(share, userID) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Share not found"})
+	}
+
+	revoked, err := h.store.RevokeShare(c.Context(), share.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error revoking share: %v", err)})
+	}
+	if !revoked {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Share not found or already revoked"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Share revoked successfully"})
+}
@@ -0,0 +1,87 @@
+package share
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+// InvitationTTL is how long an invitation can be accepted for
+const InvitationTTL = 7 * 24 * time.Hour
+
+// IsValidScope reports whether scope is one of types.ShareScopes
+func IsValidScope(scope string) bool {
+	for _, s := range types.ShareScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeScopes validates the scopes of a share and returns them without duplicates
+// in the order of types.ShareScopes. Alert-only shares always have just the alerts scope.
+func NormalizeScopes(access string, scopes []string) ([]string, error) {
+	if access == types.ShareAccessAlerts {
+		return []string{types.ShareScopeAlerts}, nil
+	}
+
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("at least one data type must be shared")
+	}
+
+	requested := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if !IsValidScope(scope) {
+			return nil, fmt.Errorf("unknown data type %q", scope)
+		}
+		requested[scope] = true
+	}
+
+	normalized := make([]string, 0, len(requested))
+	for _, scope := range types.ShareScopes {
+		if requested[scope] {
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized, nil
+}
+
+// IsPending reports whether the invitation can still be accepted
+func IsPending(share *types.Share, now time.Time) bool {
+	return share.Status == types.ShareStatusPending && now.Before(share.ExpiresAt)
+}
+
+// IsParty reports whether the user is the owner or the caregiver of the share
+func IsParty(share *types.Share, userID int32) bool {
+	return share.OwnerID == userID || (share.CaregiverID != nil && *share.CaregiverID == userID)
+}
+
+// HasOpenInvitation reports whether the owner already shares with, or has a pending
+// invitation for, the email address
+func HasOpenInvitation(shares []*types.Share, email string, now time.Time) bool {
+	for _, share := range shares {
+		if !strings.EqualFold(share.InviteeEmail, email) {
+			continue
+		}
+		if share.Status == types.ShareStatusAccepted || IsPending(share, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// Visible drops the expired invitations from the owner's shares
+func Visible(shares []*types.Share, now time.Time) []*types.Share {
+	visible := make([]*types.Share, 0, len(shares))
+	for _, share := range shares {
+		if share.Status == types.ShareStatusPending && !IsPending(share, now) {
+			continue
+		}
+		visible = append(visible, share)
+	}
+	return visible
+}
@@ -0,0 +1,63 @@
+package share
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+func TestNormalizeScopes(t *testing.T) {
+	got, err := NormalizeScopes(types.ShareAccessRead, []string{"labs", "glucose", "labs"})
+	if err != nil {
+		t.Fatalf("NormalizeScopes() error: %v", err)
+	}
+	if want := []string{"glucose", "labs"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeScopes() = %v, want %v", got, want)
+	}
+
+	got, err = NormalizeScopes(types.ShareAccessAlerts, []string{"glucose"})
+	if err != nil || !reflect.DeepEqual(got, []string{types.ShareScopeAlerts}) {
+		t.Errorf("NormalizeScopes(alerts) = %v, %v, want [alerts]", got, err)
+	}
+
+	if _, err := NormalizeScopes(types.ShareAccessRead, nil); err == nil {
+		t.Error("expected an error for a read share without data types")
+	}
+	if _, err := NormalizeScopes(types.ShareAccessRead, []string{"passwords"}); err == nil {
+		t.Error("expected an error for an unknown data type")
+	}
+}
+
+func TestInvitations(t *testing.T) {
+	now := time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)
+	caregiverID := int32(2)
+
+	pending := &types.Share{InviteeEmail: "a@example.com", Status: types.ShareStatusPending, ExpiresAt: now.Add(time.Hour)}
+	expired := &types.Share{InviteeEmail: "b@example.com", Status: types.ShareStatusPending, ExpiresAt: now.Add(-time.Hour)}
+	accepted := &types.Share{OwnerID: 1, CaregiverID: &caregiverID, InviteeEmail: "c@example.com", Status: types.ShareStatusAccepted, ExpiresAt: now.Add(-time.Hour)}
+	shares := []*types.Share{pending, expired, accepted}
+
+	if !IsPending(pending, now) || IsPending(expired, now) || IsPending(accepted, now) {
+		t.Error("IsPending() should only hold for unexpired pending invitations")
+	}
+
+	if !HasOpenInvitation(shares, "A@Example.com", now) {
+		t.Error("expected the pending invitation to be found case-insensitively")
+	}
+	if HasOpenInvitation(shares, "b@example.com", now) {
+		t.Error("an expired invitation should not block a new one")
+	}
+	if !HasOpenInvitation(shares, "c@example.com", now) {
+		t.Error("expected the accepted share to block a new invitation")
+	}
+
+	if got := Visible(shares, now); len(got) != 2 || got[0] != pending || got[1] != accepted {
+		t.Errorf("Visible() = %v, want the pending and accepted shares", got)
+	}
+
+	if !IsParty(accepted, 1) || !IsParty(accepted, 2) || IsParty(accepted, 3) || IsParty(pending, 2) {
+		t.Error("IsParty() should hold for the owner and caregiver only")
+	}
+}
@@ -0,0 +1,202 @@
+package share
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// GetShareByID fetches a share by its ID
+func (s *Store) GetShareByID(id int32) (*types.Share, error) {
+	row, err := s.db.GetShareByID(context.Background(), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("share not found")
+		}
+		return nil, err
+	}
+
+	return toShare(row), nil
+}
+
+// GetShareByTokenHash fetches the share created with the invitation token of the given hash
+func (s *Store) GetShareByTokenHash(tokenHash string) (*types.Share, error) {
+	row, err := s.db.GetShareByTokenHash(context.Background(), tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("share not found")
+		}
+		return nil, err
+	}
+
+	return toShare(database.GetShareByIDRow(row)), nil
+}
+
+// GetSharesByOwnerID fetches the pending and accepted shares of the owner's data
+func (s *Store) GetSharesByOwnerID(ownerID int32) ([]*types.Share, error) {
+	rows, err := s.db.GetSharesByOwnerID(context.Background(), ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make([]*types.Share, 0, len(rows))
+	for _, row := range rows {
+		shares = append(shares, toShare(database.GetShareByIDRow(row)))
+	}
+
+	return shares, nil
+}
+
+// GetSharesByCaregiverID fetches the accepted shares the caregiver has received
+func (s *Store) GetSharesByCaregiverID(caregiverID int32) ([]*types.Share, error) {
+	rows, err := s.db.GetSharesByCaregiverID(context.Background(), sql.NullInt32{Int32: caregiverID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make([]*types.Share, 0, len(rows))
+	for _, row := range rows {
+		shares = append(shares, toShare(database.GetShareByIDRow(row)))
+	}
+
+	return shares, nil
+}
+
+// GetAcceptedShare fetches the share of the owner's data with the caregiver, or nil if there is none
+func (s *Store) GetAcceptedShare(ownerID int32, caregiverID int32) (*types.Share, error) {
+	row, err := s.db.GetAcceptedShare(context.Background(), database.GetAcceptedShareParams{
+		OwnerID:     ownerID,
+		CaregiverID: sql.NullInt32{Int32: caregiverID, Valid: true},
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return toShare(database.GetShareByIDRow(row)), nil
+}
+
+// CreateShare creates a pending share. Only the hash of the invitation token is stored.
+func (s *Store) CreateShare(ctx context.Context, share *types.Share, tokenHash string) (int32, error) {
+	id, err := s.db.CreateShare(ctx, database.CreateShareParams{
+		OwnerID:      share.OwnerID,
+		InviteeEmail: share.InviteeEmail,
+		Access:       database.DataSharesAccess(share.Access),
+		Scopes:       strings.Join(share.Scopes, ","),
+		TokenHash:    tokenHash,
+		ExpiresAt:    share.ExpiresAt,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(id), nil
+}
+
+// AcceptShare links a pending share to the caregiver. Any earlier share of the same
+// owner's data with the caregiver is revoked so that only one applies.
+// It returns false if the share was no longer pending.
+func (s *Store) AcceptShare(ctx context.Context, id int32, caregiverID int32) (bool, error) {
+	share, err := s.GetShareByID(id)
+	if err != nil {
+		return false, err
+	}
+
+	caregiver := sql.NullInt32{Int32: caregiverID, Valid: true}
+	affected, err := s.db.AcceptShare(ctx, database.AcceptShareParams{
+		CaregiverID: caregiver,
+		ShareID:     id,
+	})
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	err = s.db.RevokeOtherCaregiverShares(ctx, database.RevokeOtherCaregiverSharesParams{
+		OwnerID:     share.OwnerID,
+		CaregiverID: caregiver,
+		ShareID:     id,
+	})
+	return err == nil, err
+}
+
+// UpdateShareAccess changes the access level and data types of a share
+func (s *Store) UpdateShareAccess(ctx context.Context, id int32, access string, scopes []string) error {
+	if _, err := s.GetShareByID(id); err != nil {
+		return err
+	}
+
+	// MySQL reports unchanged rows as not affected, so existence is checked above
+	return s.db.UpdateShareAccess(ctx, database.UpdateShareAccessParams{
+		Access:  database.DataSharesAccess(access),
+		Scopes:  strings.Join(scopes, ","),
+		ShareID: id,
+	})
+}
+
+// RevokeShare ends a share. It returns false if it was already revoked.
+func (s *Store) RevokeShare(ctx context.Context, id int32) (bool, error) {
+	affected, err := s.db.RevokeShare(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func toShare(row database.GetShareByIDRow) *types.Share {
+	share := &types.Share{
+		ID:            row.ShareID,
+		OwnerID:       row.OwnerID,
+		OwnerUsername: row.OwnerUsername,
+		OwnerEmail:    row.OwnerEmail,
+		InviteeEmail:  row.InviteeEmail,
+		Access:        string(row.Access),
+		Scopes:        splitList(row.Scopes),
+		Status:        string(row.Status),
+		ExpiresAt:     row.ExpiresAt,
+		CreatedAt:     row.CreatedAt,
+	}
+	if row.CaregiverID.Valid {
+		caregiverID := row.CaregiverID.Int32
+		share.CaregiverID = &caregiverID
+	}
+	if row.CaregiverUsername.Valid {
+		username := row.CaregiverUsername.String
+		share.CaregiverUsername = &username
+	}
+	if row.AcceptedAt.Valid {
+		accepted := row.AcceptedAt.Time
+		share.AcceptedAt = &accepted
+	}
+	if row.RevokedAt.Valid {
+		revoked := row.RevokedAt.Time
+		share.RevokedAt = &revoked
+	}
+	return share
+}
+
+// splitList splits a comma-separated column, returning an empty list for an empty string
+func splitList(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(s, ",")
+}
@@ -0,0 +1,91 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/jayden1905/abundance/service/glucose"
+	"github.com/jayden1905/abundance/types"
+)
+
+// AGPBucketSizes are the supported AGP slot widths in minutes. Each divides a day evenly.
+var AGPBucketSizes = []int{5, 10, 15, 20, 30, 60}
+
+// IsValidBucketSize reports whether minutes is a supported AGP slot width
+func IsValidBucketSize(minutes int) bool {
+	for _, size := range AGPBucketSizes {
+		if size == minutes {
+			return true
+		}
+	}
+	return false
+}
+
+// ComputeAGP overlays the readings measured between from and to onto a single day
+// in loc and computes the glucose percentiles for each time-of-day slot
+func ComputeAGP(readings []*types.GlucoseReading, unit string, loc *time.Location, bucketMinutes int, from time.Time, to time.Time) *types.AGP {
+	slots := 24 * 60 / bucketMinutes
+	values := make([][]float64, slots)
+
+	agp := &types.AGP{
+		From:          from,
+		To:            to,
+		Timezone:      loc.String(),
+		Unit:          unit,
+		BucketMinutes: bucketMinutes,
+		Buckets:       make([]*types.AGPBucket, slots),
+	}
+
+	for _, reading := range readings {
+		if reading.MeasuredAt.Before(from) || reading.MeasuredAt.After(to) {
+			continue
+		}
+
+		local := reading.MeasuredAt.In(loc)
+		slot := (local.Hour()*60 + local.Minute()) / bucketMinutes
+		values[slot] = append(values[slot], glucose.ConvertValue(reading.Value, reading.Unit, unit))
+		agp.Readings++
+	}
+
+	for i := range agp.Buckets {
+		start := i * bucketMinutes
+		bucket := &types.AGPBucket{
+			Start:    fmt.Sprintf("%02d:%02d", start/60, start%60),
+			Readings: len(values[i]),
+		}
+
+		if len(values[i]) > 0 {
+			sort.Float64s(values[i])
+			bucket.Percentiles = &types.AGPPercentiles{
+				P5:  round(Percentile(values[i], 5), 1),
+				P25: round(Percentile(values[i], 25), 1),
+				P50: round(Percentile(values[i], 50), 1),
+				P75: round(Percentile(values[i], 75), 1),
+				P95: round(Percentile(values[i], 95), 1),
+			}
+		}
+
+		agp.Buckets[i] = bucket
+	}
+
+	return agp
+}
+
+// Percentile returns the p-th percentile of sorted values using linear
+// interpolation between the closest ranks
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return sorted[lower]
+	}
+
+	return sorted[lower] + (rank-float64(lower))*(sorted[upper]-sorted[lower])
+}
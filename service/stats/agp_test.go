@@ -0,0 +1,60 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+func TestPercentile(t *testing.T) {
+	values := []float64{100, 110, 120, 130, 140}
+
+	cases := map[float64]float64{0: 100, 5: 102, 25: 110, 50: 120, 75: 130, 95: 138, 100: 140}
+	for p, expected := range cases {
+		if got := Percentile(values, p); got != expected {
+			t.Errorf("expected P%v to be %v, got %v", p, expected, got)
+		}
+	}
+
+	if Percentile([]float64{42}, 95) != 42 {
+		t.Error("expected a single value to be every percentile")
+	}
+}
+
+func TestComputeAGPUsesLocalTime(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Singapore")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	to := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, 0, -14)
+
+	// 23:30 UTC is 07:30 in Singapore
+	var readings []*types.GlucoseReading
+	for day := 1; day <= 3; day++ {
+		readings = append(readings, &types.GlucoseReading{
+			Value:      float64(100 + day*10),
+			Unit:       types.GlucoseUnitMgdL,
+			MeasuredAt: time.Date(2025, 2, day, 23, 30, 0, 0, time.UTC),
+		})
+	}
+
+	agp := ComputeAGP(readings, types.GlucoseUnitMgdL, loc, 60, from, to)
+
+	if len(agp.Buckets) != 24 || agp.Readings != 3 {
+		t.Fatalf("expected 24 buckets and 3 readings, got %d and %d", len(agp.Buckets), agp.Readings)
+	}
+
+	bucket := agp.Buckets[7]
+	if bucket.Start != "07:00" || bucket.Readings != 3 || bucket.Percentiles == nil {
+		t.Fatalf("expected all readings in the 07:00 bucket, got %+v", bucket)
+	}
+	if bucket.Percentiles.P50 != 120 {
+		t.Errorf("expected median 120, got %v", bucket.Percentiles.P50)
+	}
+	if agp.Buckets[23].Percentiles != nil {
+		t.Error("expected the 23:00 bucket to be empty")
+	}
+}
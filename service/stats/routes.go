@@ -0,0 +1,122 @@
+package stats
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+type Handler struct {
+	glucoseStore types.GlucoseStore
+	profileStore types.ProfileStore
+	userStore    types.UserStore
+}
+
+func NewHandler(glucoseStore types.GlucoseStore, profileStore types.ProfileStore, userStore types.UserStore) *Handler {
+	return &Handler{glucoseStore: glucoseStore, profileStore: profileStore, userStore: userStore}
+}
+
+// RegisterRoutes for Fiber
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/glucose/stats", auth.WithSharedAccess(h.handleGetStats, h.userStore, types.ShareScopeGlucose))
+	router.Get("/glucose/agp", auth.WithSharedAccess(h.handleGetAGP, h.userStore, types.ShareScopeGlucose))
+}
+
+// Handler for the user's glucose statistics. Without ?days the statistics for
+// every supported window are returned.
+func (h *Handler) handleGetStats(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	windows := types.StatsWindows
+	if c.Query("days") != "" {
+		days, err := strconv.Atoi(c.Query("days"))
+		if err != nil || !IsValidWindow(days) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("days must be one of %v", types.StatsWindows)})
+		}
+		windows = []int{days}
+	}
+
+	profile, err := h.profileStore.GetProfileByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting profile: %v", err)})
+	}
+
+	// Report in the user's preferred unit unless another one is requested
+	unit := profile.GlucoseUnit
+	if c.Query("unit") != "" {
+		unit = c.Query("unit")
+		if unit != types.GlucoseUnitMgdL && unit != types.GlucoseUnitMmolL {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "unit must be mg/dL or mmol/L"})
+		}
+	}
+
+	// Load the longest window once and compute the shorter ones from it
+	longest := 0
+	for _, days := range windows {
+		longest = max(longest, days)
+	}
+
+	to := time.Now().UTC()
+	readings, err := h.glucoseStore.GetGlucoseReadingsByTimeRange(userID, to.AddDate(0, 0, -longest), to)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting glucose readings: %v", err)})
+	}
+
+	thresholds := ThresholdsFromProfile(profile)
+	stats := make([]*types.GlucoseStats, 0, len(windows))
+	for _, days := range windows {
+		stats = append(stats, Compute(readings, thresholds, unit, to.AddDate(0, 0, -days), to))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"stats": stats})
+}
+
+// Handler for the user's ambulatory glucose profile over a date range
+func (h *Handler) handleGetAGP(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	const maxRange = 90 * 24 * time.Hour
+
+	// Default to the last fourteen days, the usual AGP reporting period
+	from, to, err := utils.ParseTimeRange(c.Query("from"), c.Query("to"), 14*24*time.Hour)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if to.Sub(from) > maxRange {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Range cannot be longer than 90 days"})
+	}
+
+	bucketMinutes := 15
+	if c.Query("bucket_minutes") != "" {
+		bucketMinutes, err = strconv.Atoi(c.Query("bucket_minutes"))
+		if err != nil || !IsValidBucketSize(bucketMinutes) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("bucket_minutes must be one of %v", AGPBucketSizes)})
+		}
+	}
+
+	profile, err := h.profileStore.GetProfileByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting profile: %v", err)})
+	}
+
+	unit := profile.GlucoseUnit
+	if c.Query("unit") != "" {
+		unit = c.Query("unit")
+		if unit != types.GlucoseUnitMgdL && unit != types.GlucoseUnitMmolL {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "unit must be mg/dL or mmol/L"})
+		}
+	}
+
+	readings, err := h.glucoseStore.GetGlucoseReadingsByTimeRange(userID, from, to)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting glucose readings: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(ComputeAGP(readings, unit, profile.Location(), bucketMinutes, from, to))
+}
@@ -0,0 +1,121 @@
+package stats
+
+import (
+	"math"
+	"time"
+
+	"github.com/jayden1905/abundance/service/glucose"
+	"github.com/jayden1905/abundance/types"
+)
+
+// ThresholdsFromProfile returns the user's range thresholds in mg/dL
+func ThresholdsFromProfile(profile *types.Profile) types.GlucoseThresholds {
+	return types.GlucoseThresholds{
+		VeryLow:  profile.VeryLowMgdL,
+		Low:      profile.TargetLowMgdL,
+		High:     profile.TargetHighMgdL,
+		VeryHigh: profile.VeryHighMgdL,
+	}
+}
+
+// IsValidWindow reports whether days is one of the supported reporting windows
+func IsValidWindow(days int) bool {
+	for _, window := range types.StatsWindows {
+		if window == days {
+			return true
+		}
+	}
+	return false
+}
+
+// Compute calculates the CGM metrics for the readings measured between from and to.
+// Thresholds are in mg/dL and the results are converted to unit.
+func Compute(readings []*types.GlucoseReading, thresholds types.GlucoseThresholds, unit string, from time.Time, to time.Time) *types.GlucoseStats {
+	stats := &types.GlucoseStats{
+		Days: int(math.Round(to.Sub(from).Hours() / 24)),
+		From: from,
+		To:   to,
+		Unit: unit,
+		Thresholds: types.GlucoseThresholds{
+			VeryLow:  round(glucose.FromMgdL(thresholds.VeryLow, unit), 1),
+			Low:      round(glucose.FromMgdL(thresholds.Low, unit), 1),
+			High:     round(glucose.FromMgdL(thresholds.High, unit), 1),
+			VeryHigh: round(glucose.FromMgdL(thresholds.VeryHigh, unit), 1),
+		},
+	}
+
+	var values []float64
+	var veryLow, low, inRange, high, veryHigh int
+	for _, reading := range readings {
+		if reading.MeasuredAt.Before(from) || reading.MeasuredAt.After(to) {
+			continue
+		}
+
+		value := glucose.ToMgdL(reading.Value, reading.Unit)
+		values = append(values, value)
+
+		switch {
+		case value < thresholds.VeryLow:
+			veryLow++
+		case value < thresholds.Low:
+			low++
+		case value <= thresholds.High:
+			inRange++
+		case value <= thresholds.VeryHigh:
+			high++
+		default:
+			veryHigh++
+		}
+	}
+
+	stats.Readings = len(values)
+	if len(values) == 0 {
+		return stats
+	}
+
+	mean, sd := meanAndStandardDeviation(values)
+
+	stats.Mean = round(glucose.FromMgdL(mean, unit), 1)
+	stats.StandardDeviation = round(glucose.FromMgdL(sd, unit), 1)
+	stats.CoefficientOfVariation = round(sd/mean*100, 1)
+	stats.GMI = round(GMI(mean), 1)
+
+	total := float64(len(values))
+	stats.TimeVeryLow = round(float64(veryLow)/total*100, 1)
+	stats.TimeLow = round(float64(low)/total*100, 1)
+	stats.TimeInRange = round(float64(inRange)/total*100, 1)
+	stats.TimeHigh = round(float64(high)/total*100, 1)
+	stats.TimeVeryHigh = round(float64(veryHigh)/total*100, 1)
+
+	return stats
+}
+
+// GMI estimates A1c in percent from the mean glucose in mg/dL (Bergenstal et al., 2018)
+func GMI(meanMgdL float64) float64 {
+	return 3.31 + 0.02392*meanMgdL
+}
+
+// meanAndStandardDeviation returns the mean and sample standard deviation of values
+func meanAndStandardDeviation(values []float64) (float64, float64) {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	if len(values) < 2 {
+		return mean, 0
+	}
+
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+
+	return mean, math.Sqrt(squares / float64(len(values)-1))
+}
+
+func round(value float64, places int) float64 {
+	factor := math.Pow(10, float64(places))
+	return math.Round(value*factor) / factor
+}
@@ -0,0 +1,105 @@
+package stats
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+var testThresholds = types.GlucoseThresholds{VeryLow: 54, Low: 70, High: 180, VeryHigh: 250}
+
+func readingsAt(end time.Time, unit string, values ...float64) []*types.GlucoseReading {
+	readings := make([]*types.GlucoseReading, 0, len(values))
+	for i, v := range values {
+		readings = append(readings, &types.GlucoseReading{
+			Value:      v,
+			Unit:       unit,
+			MeasuredAt: end.Add(-time.Duration(i+1) * time.Hour),
+		})
+	}
+	return readings
+}
+
+func TestComputeRanges(t *testing.T) {
+	to := time.Date(2025, 2, 10, 12, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, 0, -1)
+
+	readings := readingsAt(to, types.GlucoseUnitMgdL, 50, 60, 70, 120, 180, 181, 250, 300, 100, 110)
+	stats := Compute(readings, testThresholds, types.GlucoseUnitMgdL, from, to)
+
+	if stats.Readings != 10 || stats.Days != 1 {
+		t.Fatalf("expected 10 readings over 1 day, got %d over %d", stats.Readings, stats.Days)
+	}
+
+	expected := map[string][2]float64{
+		"very low":  {stats.TimeVeryLow, 10},
+		"low":       {stats.TimeLow, 10},
+		"in range":  {stats.TimeInRange, 50},
+		"high":      {stats.TimeHigh, 20},
+		"very high": {stats.TimeVeryHigh, 10},
+	}
+	for name, got := range expected {
+		if got[0] != got[1] {
+			t.Errorf("expected time %s to be %v%%, got %v%%", name, got[1], got[0])
+		}
+	}
+}
+
+func TestComputeMeanAndVariability(t *testing.T) {
+	to := time.Date(2025, 2, 10, 12, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, 0, -7)
+
+	// 100 and 200 mg/dL: mean 150, sample SD 70.7
+	readings := readingsAt(to, types.GlucoseUnitMgdL, 100, 200)
+	stats := Compute(readings, testThresholds, types.GlucoseUnitMgdL, from, to)
+
+	if stats.Mean != 150 {
+		t.Errorf("expected mean 150, got %v", stats.Mean)
+	}
+	if stats.StandardDeviation != 70.7 {
+		t.Errorf("expected standard deviation 70.7, got %v", stats.StandardDeviation)
+	}
+	if stats.CoefficientOfVariation != 47.1 {
+		t.Errorf("expected coefficient of variation 47.1, got %v", stats.CoefficientOfVariation)
+	}
+	if stats.GMI != 6.9 {
+		t.Errorf("expected GMI 6.9, got %v", stats.GMI)
+	}
+}
+
+func TestComputeUnitsAndWindow(t *testing.T) {
+	to := time.Date(2025, 2, 10, 12, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, 0, -1)
+
+	// Mixed units are normalised, and the reading outside the window is ignored
+	readings := []*types.GlucoseReading{
+		{Value: 5.5, Unit: types.GlucoseUnitMmolL, MeasuredAt: to.Add(-time.Hour)},
+		{Value: 99.1, Unit: types.GlucoseUnitMgdL, MeasuredAt: to.Add(-2 * time.Hour)},
+		{Value: 400, Unit: types.GlucoseUnitMgdL, MeasuredAt: from.Add(-time.Hour)},
+	}
+	stats := Compute(readings, testThresholds, types.GlucoseUnitMmolL, from, to)
+
+	if stats.Readings != 2 {
+		t.Fatalf("expected 2 readings in the window, got %d", stats.Readings)
+	}
+	if math.Abs(stats.Mean-5.5) > 0.05 {
+		t.Errorf("expected mean of about 5.5 mmol/L, got %v", stats.Mean)
+	}
+	if stats.Thresholds.Low != 3.9 || stats.Thresholds.High != 10 {
+		t.Errorf("expected target range 3.9-10 mmol/L, got %v-%v", stats.Thresholds.Low, stats.Thresholds.High)
+	}
+	if stats.TimeInRange != 100 {
+		t.Errorf("expected all readings in range, got %v%%", stats.TimeInRange)
+	}
+}
+
+func TestComputeEmpty(t *testing.T) {
+	to := time.Now()
+	stats := Compute(nil, testThresholds, types.GlucoseUnitMgdL, to.AddDate(0, 0, -14), to)
+
+	if stats.Readings != 0 || stats.Mean != 0 || stats.TimeInRange != 0 {
+		t.Errorf("expected empty statistics, got %+v", stats)
+	}
+}
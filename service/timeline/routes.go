@@ -0,0 +1,166 @@
+package timeline
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 200
+	maxRange     = 366 * 24 * time.Hour
+)
+
+type Handler struct {
+	sources   []types.TimelineSource
+	userStore types.UserStore
+}
+
+func NewHandler(userStore types.UserStore, sources ...types.TimelineSource) *Handler {
+	return &Handler{sources: sources, userStore: userStore}
+}
+
+// Register adds sources to the timeline. Sources must be registered before the routes.
+func (h *Handler) Register(sources ...types.TimelineSource) {
+	h.sources = append(h.sources, sources...)
+}
+
+// RegisterRoutes for Fiber
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/timeline", auth.WithSharedAccess(h.handleGetTimeline, h.userStore))
+	router.Get("/timeline/types", auth.WithJWTAuth(h.handleGetTypes, h.userStore))
+}
+
+// Handler for listing the event types clients can filter on
+func (h *Handler) handleGetTypes(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(h.types())
+}
+
+// Handler for a page of the user's events in a time window, oldest first
+func (h *Handler) handleGetTimeline(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Default to the last seven days when no range is given
+	from, to, err := utils.ParseTimeRange(c.Query("from"), c.Query("to"), 7*24*time.Hour)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if to.Sub(from) > maxRange {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Range cannot be longer than a year"})
+	}
+
+	limit := defaultLimit
+	if l := c.Query("limit"); l != "" {
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit <= 0 || limit > maxLimit {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("limit must be between 1 and %d", maxLimit)})
+		}
+	}
+
+	wanted, err := h.parseTypes(c.Query("types"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	// Caregivers only see the event types of the data shared with them
+	if share := auth.GetShareFromContext(c); share != nil {
+		wanted = Shared(share, wanted, h.types())
+	}
+
+	// Each source returns the first events after the cursor. One more than the limit
+	// tells whether there is a next page.
+	query := types.TimelineQuery{Start: from, End: to, Limit: limit + 1}
+	var after *Cursor
+	if s := c.Query("cursor"); s != "" {
+		cursor, err := DecodeCursor(s)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		after = &cursor
+		query.After = &types.TimelinePosition{OccurredAt: cursor.OccurredAt, Type: cursor.Type, ID: cursor.ID}
+	}
+
+	events := make([]*types.TimelineEvent, 0)
+	for _, source := range h.sources {
+		if !wantsAny(wanted, source.TimelineTypes()) {
+			continue
+		}
+
+		sourceEvents, err := source.TimelineEvents(userID, query)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting timeline: %v", err)})
+		}
+		events = append(events, Filter(sourceEvents, wanted)...)
+	}
+
+	page, next := Paginate(events, after, limit)
+
+	response := fiber.Map{
+		"from":        from,
+		"to":          to,
+		"events":      page,
+		"next_cursor": nil,
+	}
+	if next != "" {
+		response["next_cursor"] = next
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// parseTypes reads a comma-separated list of event types. An empty list means every type.
+func (h *Handler) parseTypes(s string) (map[string]bool, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	known := make(map[string]bool)
+	for _, t := range h.types() {
+		known[t] = true
+	}
+
+	wanted := make(map[string]bool)
+	for _, t := range strings.Split(s, ",") {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		if !known[t] {
+			return nil, fmt.Errorf("unknown event type %q", t)
+		}
+		wanted[t] = true
+	}
+
+	return wanted, nil
+}
+
+// types returns every event type of the registered sources, sorted
+func (h *Handler) types() []string {
+	all := make([]string, 0)
+	for _, source := range h.sources {
+		all = append(all, source.TimelineTypes()...)
+	}
+	sort.Strings(all)
+	return all
+}
+
+func wantsAny(wanted map[string]bool, sourceTypes []string) bool {
+	if wanted == nil {
+		return true
+	}
+	for _, t := range sourceTypes {
+		if wanted[t] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package timeline
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+// Cursor marks the last event of a page. The next page starts after it.
+type Cursor struct {
+	OccurredAt time.Time
+	Type       string
+	ID         int32
+}
+
+// Encode returns the cursor as an opaque URL-safe string
+func (c Cursor) Encode() string {
+	raw := fmt.Sprintf("%d|%s|%d", c.OccurredAt.UnixNano(), c.Type, c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a cursor returned by Encode
+func DecodeCursor(s string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("invalid cursor")
+	}
+
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 || parts[1] == "" {
+		return Cursor{}, fmt.Errorf("invalid cursor")
+	}
+
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("invalid cursor")
+	}
+	id, err := strconv.ParseInt(parts[2], 10, 32)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("invalid cursor")
+	}
+
+	return Cursor{OccurredAt: time.Unix(0, nanos).UTC(), Type: parts[1], ID: int32(id)}, nil
+}
+
+func cursorOf(event *types.TimelineEvent) Cursor {
+	return Cursor{OccurredAt: event.OccurredAt, Type: event.Type, ID: event.ID}
+}
+
+// less orders events by time, then by type and ID so that events at the same
+// instant keep a stable order across pages
+func less(a Cursor, b Cursor) bool {
+	if !a.OccurredAt.Equal(b.OccurredAt) {
+		return a.OccurredAt.Before(b.OccurredAt)
+	}
+	if a.Type != b.Type {
+		return a.Type < b.Type
+	}
+	return a.ID < b.ID
+}
+
+// Paginate sorts the events chronologically and returns up to limit of them after
+// the cursor, with the cursor of the next page or an empty string on the last page
+func Paginate(events []*types.TimelineEvent, after *Cursor, limit int) ([]*types.TimelineEvent, string) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return less(cursorOf(events[i]), cursorOf(events[j]))
+	})
+
+	start := 0
+	if after != nil {
+		start = sort.Search(len(events), func(i int) bool {
+			return less(*after, cursorOf(events[i]))
+		})
+	}
+
+	end := start + limit
+	if end >= len(events) {
+		return events[start:], ""
+	}
+
+	page := events[start:end]
+	return page, cursorOf(page[len(page)-1]).Encode()
+}
+
+// Filter keeps the events whose type is in wanted. A nil set keeps every event.
+func Filter(events []*types.TimelineEvent, wanted map[string]bool) []*types.TimelineEvent {
+	if wanted == nil {
+		return events
+	}
+
+	filtered := make([]*types.TimelineEvent, 0, len(events))
+	for _, event := range events {
+		if wanted[event.Type] {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+// shareScopes maps event types to the share scope a caregiver needs to see them.
+// Types without a scope, such as sign-ins, are never shared.
+var shareScopes = map[string]string{
+	types.TimelineGoal:               types.ShareScopeHealth,
+	types.TimelineHealthCondition:    types.ShareScopeHealth,
+	types.TimelineDietaryRestriction: types.ShareScopeHealth,
+	types.TimelineGlucoseReading:     types.ShareScopeGlucose,
+	types.TimelineMeal:               types.ShareScopeMeals,
+	types.TimelineMedicationDose:     types.ShareScopeMedications,
+	types.TimelineActivity:           types.ShareScopeActivity,
+	types.TimelineLabResult:          types.ShareScopeLabs,
+	types.TimelineGlucoseAlert:       types.ShareScopeAlerts,
+	types.TimelineWeight:             types.ShareScopeProfile,
+}
+
+// Shared narrows the wanted event types, nil meaning all of known, to those the share covers
+func Shared(share *types.Share, wanted map[string]bool, known []string) map[string]bool {
+	allowed := make(map[string]bool)
+	for _, t := range known {
+		if wanted != nil && !wanted[t] {
+			continue
+		}
+		if scope, ok := shareScopes[t]; ok && share.HasScope(scope) {
+			allowed[t] = true
+		}
+	}
+	return allowed
+}
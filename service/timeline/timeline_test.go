@@ -0,0 +1,159 @@
+package timeline
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jayden1905/abundance/types"
+)
+
+func event(eventType string, id int32, at time.Time) *types.TimelineEvent {
+	return &types.TimelineEvent{Type: eventType, ID: id, OccurredAt: at}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := Cursor{OccurredAt: time.Date(2025, 2, 3, 8, 15, 30, 123, time.UTC), Type: types.TimelineMeal, ID: 42}
+
+	decoded, err := DecodeCursor(cursor.Encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.OccurredAt.Equal(cursor.OccurredAt) || decoded.Type != cursor.Type || decoded.ID != cursor.ID {
+		t.Errorf("decoded cursor = %+v, want %+v", decoded, cursor)
+	}
+
+	for _, bad := range []string{"not base64!", "bWVhbA", cursor.Encode()[:5]} {
+		if _, err := DecodeCursor(bad); err == nil {
+			t.Errorf("DecodeCursor(%q) should fail", bad)
+		}
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	base := time.Date(2025, 2, 3, 8, 0, 0, 0, time.UTC)
+	events := []*types.TimelineEvent{
+		event(types.TimelineMeal, 1, base.Add(time.Hour)),
+		event(types.TimelineGlucoseReading, 7, base),
+		event(types.TimelineGlucoseReading, 8, base.Add(time.Hour)),
+		event(types.TimelineGoal, 3, base.Add(2*time.Hour)),
+		event(types.TimelineActivity, 2, base.Add(time.Hour)),
+	}
+
+	// Events at the same instant are ordered by type, then ID
+	want := []string{"glucose_reading:7", "activity:2", "glucose_reading:8", "meal:1", "goal:3"}
+
+	var got []string
+	var after *Cursor
+	pages := 0
+	for {
+		page, next := Paginate(events, after, 2)
+		pages++
+		for _, e := range page {
+			got = append(got, fmt.Sprintf("%s:%d", e.Type, e.ID))
+		}
+		if next == "" {
+			break
+		}
+		cursor, err := DecodeCursor(next)
+		if err != nil {
+			t.Fatal(err)
+		}
+		after = &cursor
+	}
+
+	if pages != 3 {
+		t.Errorf("got %d pages, want 3", pages)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event %d = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestPaginateLastPageHasNoCursor(t *testing.T) {
+	base := time.Date(2025, 2, 3, 8, 0, 0, 0, time.UTC)
+	events := []*types.TimelineEvent{event(types.TimelineMeal, 1, base), event(types.TimelineMeal, 2, base.Add(time.Minute))}
+
+	page, next := Paginate(events, nil, 2)
+	if len(page) != 2 || next != "" {
+		t.Errorf("exact page = %d events, next %q; want 2 and no cursor", len(page), next)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	base := time.Date(2025, 2, 3, 8, 0, 0, 0, time.UTC)
+	events := []*types.TimelineEvent{event(types.TimelineMeal, 1, base), event(types.TimelineGoal, 2, base)}
+
+	if got := Filter(events, nil); len(got) != 2 {
+		t.Errorf("nil filter kept %d events, want 2", len(got))
+	}
+	got := Filter(events, map[string]bool{types.TimelineGoal: true})
+	if len(got) != 1 || got[0].Type != types.TimelineGoal {
+		t.Errorf("goal filter = %v", got)
+	}
+}
+
+func TestShared(t *testing.T) {
+	share := &types.Share{Scopes: []string{types.ShareScopeGlucose, types.ShareScopeHealth}}
+	known := []string{types.TimelineGlucoseReading, types.TimelineGoal, types.TimelineMeal, types.TimelineSignIn}
+
+	got := Shared(share, nil, known)
+	if len(got) != 2 || !got[types.TimelineGlucoseReading] || !got[types.TimelineGoal] {
+		t.Errorf("Shared() = %v, want glucose_reading and goal", got)
+	}
+
+	got = Shared(share, map[string]bool{types.TimelineMeal: true}, known)
+	if got == nil || len(got) != 0 {
+		t.Errorf("Shared() = %v, want an empty filter", got)
+	}
+}
+
+func TestTimelineQueryIncludes(t *testing.T) {
+	base := time.Date(2025, 2, 3, 8, 0, 0, 0, time.UTC)
+	query := types.TimelineQuery{
+		Start: base.Add(-time.Hour),
+		End:   base.Add(time.Hour),
+		After: &types.TimelinePosition{OccurredAt: base, Type: types.TimelineGlucoseReading, ID: 7},
+	}
+
+	tests := []struct {
+		name string
+		e    *types.TimelineEvent
+		want bool
+	}{
+		{"before the cursor", event(types.TimelineMeal, 1, base.Add(-time.Minute)), false},
+		{"same time, type sorts before", event(types.TimelineActivity, 99, base), false},
+		{"same time, same type, lower ID", event(types.TimelineGlucoseReading, 7, base), false},
+		{"same time, same type, higher ID", event(types.TimelineGlucoseReading, 8, base), true},
+		{"same time, type sorts after", event(types.TimelineMeal, 1, base), true},
+		{"after the cursor", event(types.TimelineActivity, 1, base.Add(time.Minute)), true},
+		{"at the end", event(types.TimelineMeal, 1, base.Add(time.Hour)), false},
+	}
+	for _, tt := range tests {
+		if got := query.Includes(tt.e.Type, tt.e.OccurredAt, tt.e.ID); got != tt.want {
+			t.Errorf("%s: Includes() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// Without a cursor the page starts at the beginning of the range
+	query.After = nil
+	if !query.Includes(types.TimelineMeal, query.Start, 1) {
+		t.Error("expected an event at the start of the range to be included")
+	}
+}
+
+func TestSharedWeight(t *testing.T) {
+	known := []string{types.TimelineWeight}
+
+	if got := Shared(&types.Share{Scopes: []string{types.ShareScopeHealth}}, nil, known); got[types.TimelineWeight] {
+		t.Error("expected weights to need the profile scope")
+	}
+	if got := Shared(&types.Share{Scopes: []string{types.ShareScopeProfile}}, nil, known); !got[types.TimelineWeight] {
+		t.Error("expected the profile scope to share weights")
+	}
+}
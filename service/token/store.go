@@ -0,0 +1,221 @@
+package token
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// CreateRefreshToken stores the hash of a newly issued refresh token
+func (s *Store) CreateRefreshToken(ctx context.Context, token *types.RefreshToken) error {
+	err := s.db.CreateRefreshToken(ctx, database.CreateRefreshTokenParams{
+		UserID:    token.UserID,
+		FamilyID:  token.FamilyID,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt.UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetRefreshTokenByHash fetches a refresh token by the hash of its value
+func (s *Store) GetRefreshTokenByHash(tokenHash string) (*types.RefreshToken, error) {
+	token, err := s.db.GetRefreshTokenByHash(context.Background(), tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("refresh token not found")
+		}
+		return nil, err
+	}
+
+	return &types.RefreshToken{
+		ID:        token.RefreshTokenID,
+		UserID:    token.UserID,
+		FamilyID:  token.FamilyID,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+		UsedAt:    nullTimeToPtr(token.UsedAt),
+		RevokedAt: nullTimeToPtr(token.RevokedAt),
+		CreatedAt: token.CreatedAt,
+	}, nil
+}
+
+// MarkRefreshTokenUsed marks a refresh token as used. It returns false when the
+// token had already been used or revoked, which means it is being replayed.
+func (s *Store) MarkRefreshTokenUsed(ctx context.Context, id int32) (bool, error) {
+	rows, err := s.db.MarkRefreshTokenUsed(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+// RevokeRefreshTokenFamily revokes every token issued from the same login
+func (s *Store) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	err := s.db.RevokeRefreshTokenFamily(ctx, familyID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RevokeUserRefreshTokens revokes every refresh token issued to the user
+func (s *Store) RevokeUserRefreshTokens(ctx context.Context, userID int32) error {
+	err := s.db.RevokeUserRefreshTokens(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CreateRevokedToken records an access token ID that must no longer be accepted
+func (s *Store) CreateRevokedToken(ctx context.Context, token *types.RevokedToken) error {
+	err := s.db.CreateRevokedToken(ctx, database.CreateRevokedTokenParams{
+		Jti:       token.JTI,
+		UserID:    token.UserID,
+		ExpiresAt: token.ExpiresAt.UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetActiveRevokedTokens fetches revoked access tokens that have not expired yet
+func (s *Store) GetActiveRevokedTokens() ([]*types.RevokedToken, error) {
+	tokens, err := s.db.GetActiveRevokedTokens(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	revoked := make([]*types.RevokedToken, 0, len(tokens))
+	for _, token := range tokens {
+		revoked = append(revoked, &types.RevokedToken{
+			JTI:       token.Jti,
+			UserID:    token.UserID,
+			ExpiresAt: token.ExpiresAt,
+		})
+	}
+
+	return revoked, nil
+}
+
+// DeleteExpiredRevokedTokens removes revocations for tokens that would be rejected anyway
+func (s *Store) DeleteExpiredRevokedTokens(ctx context.Context) error {
+	err := s.db.DeleteExpiredRevokedTokens(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RevokeUserSessions invalidates every access token issued to the user before the given time
+func (s *Store) RevokeUserSessions(ctx context.Context, userID int32, revokedBefore time.Time) error {
+	err := s.db.UpsertUserSessionRevocation(ctx, database.UpsertUserSessionRevocationParams{
+		UserID:        userID,
+		RevokedBefore: revokedBefore.UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetUserSessionRevocations fetches the revocation cut-off time of every user that has one
+func (s *Store) GetUserSessionRevocations() (map[int32]time.Time, error) {
+	revocations, err := s.db.GetUserSessionRevocations(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	cutoffs := make(map[int32]time.Time, len(revocations))
+	for _, revocation := range revocations {
+		cutoffs[revocation.UserID] = revocation.RevokedBefore
+	}
+
+	return cutoffs, nil
+}
+
+// CreatePasswordResetToken stores the hash of a newly issued password reset token
+func (s *Store) CreatePasswordResetToken(ctx context.Context, token *types.PasswordResetToken) error {
+	err := s.db.CreatePasswordResetToken(ctx, database.CreatePasswordResetTokenParams{
+		UserID:    token.UserID,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt.UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetPasswordResetTokenByHash fetches a password reset token by the hash of its value
+func (s *Store) GetPasswordResetTokenByHash(tokenHash string) (*types.PasswordResetToken, error) {
+	token, err := s.db.GetPasswordResetTokenByHash(context.Background(), tokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("password reset token not found")
+		}
+		return nil, err
+	}
+
+	return &types.PasswordResetToken{
+		ID:        token.PasswordResetTokenID,
+		UserID:    token.UserID,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+		UsedAt:    nullTimeToPtr(token.UsedAt),
+		CreatedAt: token.CreatedAt,
+	}, nil
+}
+
+// MarkPasswordResetTokenUsed consumes a reset token. It returns false when the
+// token was already used or has expired.
+func (s *Store) MarkPasswordResetTokenUsed(ctx context.Context, id int32) (bool, error) {
+	rows, err := s.db.MarkPasswordResetTokenUsed(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+// InvalidateUserPasswordResetTokens consumes every outstanding reset token of the user
+func (s *Store) InvalidateUserPasswordResetTokens(ctx context.Context, userID int32) error {
+	err := s.db.InvalidateUserPasswordResetTokens(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func nullTimeToPtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
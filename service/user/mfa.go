@@ -0,0 +1,185 @@
+package user
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/service/mfa"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+// mfaChallenge answers a login that needs a second step with a short-lived MFA token
+// instead of a session
+func mfaChallenge(c *fiber.Ctx, userID int32, purpose string, flag string) error {
+	token, err := auth.CreateMFAToken(userID, purpose)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		flag:        true,
+		"mfa_token": token,
+	})
+}
+
+// Handler for the second login step with a TOTP code or a recovery code
+func (h *Handler) handleLoginMFA(c *fiber.Ctx) error {
+	// Parse JSON payload
+	var payload types.MFALoginPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	userID, err := auth.ValidateMFAToken(payload.MFAToken, auth.MFAPurposeVerify)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Login has expired. Please log in again"})
+	}
+
+	userMFA, err := h.mfaStore.GetUserMFA(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting two-factor authentication: %v", err)})
+	}
+	if userMFA == nil || !userMFA.IsEnabled {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Login has expired. Please log in again"})
+	}
+
+	now := time.Now()
+	if mfa.IsLocked(userMFA, now) {
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{"error": "Too many invalid codes. Please try again later"})
+	}
+
+	ok, err := mfa.Verify(c.Context(), h.mfaStore, userMFA, payload.Code, payload.RecoveryCode, now)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error checking code: %v", err)})
+	}
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid code"})
+	}
+
+	return h.startSession(c, userID, nil)
+}
+
+// Handler for starting the enrollment a role requires before the user can log in
+func (h *Handler) handleLoginMFASetup(c *fiber.Ctx) error {
+	// Parse JSON payload
+	var payload types.MFASetupPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	userID, err := auth.ValidateMFAToken(payload.MFAToken, auth.MFAPurposeSetup)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Login has expired. Please log in again"})
+	}
+
+	userMFA, err := h.mfaStore.GetUserMFA(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting two-factor authentication: %v", err)})
+	}
+	if userMFA != nil && userMFA.IsEnabled {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Two-factor authentication is already enabled. Please log in again"})
+	}
+
+	u, err := h.store.GetUserByID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Login has expired. Please log in again"})
+	}
+
+	enrollment, err := mfa.Enroll(c.Context(), h.mfaStore, u)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error starting enrollment: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(enrollment)
+}
+
+// Handler for confirming the required enrollment, which completes the login
+func (h *Handler) handleLoginMFASetupConfirm(c *fiber.Ctx) error {
+	// Parse JSON payload
+	var payload types.MFASetupConfirmPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	userID, err := auth.ValidateMFAToken(payload.MFAToken, auth.MFAPurposeSetup)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Login has expired. Please log in again"})
+	}
+
+	userMFA, err := h.mfaStore.GetUserMFA(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting two-factor authentication: %v", err)})
+	}
+	if userMFA == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Start enrollment first"})
+	}
+	if userMFA.IsEnabled {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Two-factor authentication is already enabled. Please log in again"})
+	}
+
+	codes, err := mfa.Confirm(c.Context(), h.mfaStore, userMFA, payload.Code, time.Now())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error enabling two-factor authentication: %v", err)})
+	}
+	if codes == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid code"})
+	}
+
+	return h.startSession(c, userID, codes)
+}
+
+// startSession issues a session in a new refresh token family once the login is
+// complete, including the recovery codes of a just finished enrollment
+func (h *Handler) startSession(c *fiber.Ctx, userID int32, recoveryCodes []string) error {
+	// Make sure the user still exists
+	if _, err := h.store.GetUserByID(userID); err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Login has expired. Please log in again"})
+	}
+
+	familyID, err := auth.GenerateTokenFamilyID()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	session, err := h.issueSession(c, userID, familyID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if recoveryCodes != nil {
+		session["recovery_codes"] = recoveryCodes
+	}
+
+	return c.Status(fiber.StatusOK).JSON(session)
+}
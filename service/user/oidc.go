@@ -0,0 +1,236 @@
+package user
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/config"
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/service/oidc"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+// oidcStateCookie binds a login at a provider to the browser that started it
+const oidcStateCookie = "oidc_state"
+
+// Handler for starting a login with an OpenID Connect provider. The client sends the
+// user to the returned URL and posts the code it gets back to the callback.
+func (h *Handler) handleBeginOIDCLogin(c *fiber.Ctx) error {
+	provider, ok := h.oidcProviders[c.Params("provider")]
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Unknown sign in provider"})
+	}
+
+	state, err := oidc.GenerateState()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	nonce, err := oidc.GenerateState()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	verifier, err := oidc.GenerateCodeVerifier()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	authURL, err := provider.AuthCodeURL(c.Context(), state, nonce, verifier)
+	if err != nil {
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": fmt.Sprintf("Error contacting %s: %v", provider.Name, err)})
+	}
+
+	err = h.oidcStore.CreateLoginState(c.Context(), &types.OIDCLoginState{
+		StateHash:    auth.HashToken(state),
+		Provider:     provider.Name,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    time.Now().UTC().Add(oidc.StateTTL),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error saving login state: %v", err)})
+	}
+
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		HTTPOnly: true,
+		Secure:   config.Envs.ISProduction,
+		SameSite: "Lax",
+		Path:     "/api/v1/user/auth/oidc",
+		MaxAge:   int(oidc.StateTTL.Seconds()),
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"authorization_url": authURL})
+}
+
+// Handler for finishing a login with an OpenID Connect provider. The provider account
+// logs in the user it is linked to, is linked to the account with the same verified
+// email, or creates a new account.
+func (h *Handler) handleFinishOIDCLogin(c *fiber.Ctx) error {
+	provider, ok := h.oidcProviders[c.Params("provider")]
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Unknown sign in provider"})
+	}
+
+	// Parse JSON payload
+	var payload types.OIDCCallbackPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	// The state must come back to the browser that started the login
+	cookieState := c.Cookies(oidcStateCookie)
+	if subtle.ConstantTimeCompare([]byte(cookieState), []byte(payload.State)) != 1 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Sign in has expired. Please try again"})
+	}
+
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcStateCookie,
+		Value:    "",
+		HTTPOnly: true,
+		Secure:   config.Envs.ISProduction,
+		SameSite: "Lax",
+		Path:     "/api/v1/user/auth/oidc",
+		Expires:  time.Now().Add(-time.Hour),
+	})
+
+	state, err := h.oidcStore.ConsumeLoginState(c.Context(), auth.HashToken(payload.State))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting login state: %v", err)})
+	}
+	if state == nil || state.Provider != provider.Name {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Sign in has expired. Please try again"})
+	}
+
+	idToken, err := provider.Exchange(c.Context(), payload.Code, state.CodeVerifier)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": fmt.Sprintf("Error signing in with %s: %v", provider.Name, err)})
+	}
+
+	claims, err := provider.VerifyIDToken(c.Context(), idToken, state.Nonce)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": fmt.Sprintf("Error signing in with %s: %v", provider.Name, err)})
+	}
+
+	identity, err := h.oidcStore.GetIdentity(provider.Name, claims.Subject)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting linked account: %v", err)})
+	}
+
+	// A linked provider account logs in its user
+	if identity != nil {
+		if err := h.oidcStore.UpdateIdentityLogin(c.Context(), identity.ID, claims.Email); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error updating linked account: %v", err)})
+		}
+
+		u, err := h.store.GetUserByID(identity.UserID)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Account not found"})
+		}
+
+		return h.completeLogin(c, u)
+	}
+
+	// Without a verified email there is nothing to link or create the account with
+	if !claims.HasVerifiedEmail() {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("%s did not share a verified email", provider.Name)})
+	}
+
+	u, err := h.store.GetUserByEmail(claims.Email)
+	if err == nil {
+		// Linking to an unverified account would let whoever registered the email
+		// without proving they own it keep access to it
+		if !u.IsVerified {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "An account with this email is not verified yet. Please verify your email first"})
+		}
+	} else {
+		u, err = h.createOIDCUser(c, claims)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error creating account: %v", err)})
+		}
+	}
+
+	err = h.oidcStore.CreateIdentity(c.Context(), &types.UserIdentity{
+		UserID:   u.ID,
+		Provider: provider.Name,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error linking account: %v", err)})
+	}
+
+	return h.completeLogin(c, u)
+}
+
+// Handler for listing the provider accounts linked to the current user
+func (h *Handler) handleGetIdentities(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	identities, err := h.oidcStore.GetIdentitiesByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting linked accounts: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(identities)
+}
+
+// createOIDCUser creates a verified account for a new provider user. It gets a random
+// password, so it can only log in through the provider until the password is reset.
+func (h *Handler) createOIDCUser(c *fiber.Ctx, claims *oidc.Claims) (*types.User, error) {
+	password, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := auth.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	username := strings.TrimSpace(claims.Name)
+	if username == "" {
+		username = strings.SplitN(claims.Email, "@", 2)[0]
+	}
+	if runes := []rune(username); len(runes) > 50 {
+		username = string(runes[:50])
+	}
+
+	err = h.store.CreateUser(c.Context(), &database.User{
+		Username:       username,
+		Email:          claims.Email,
+		PasswordHash:   hashedPassword,
+		RoleID:         utils.ConvertRoleStringToRoleID("free_user"),
+		SubscriptionID: utils.ConvertSubscriptionStringToSubscriptionID("Active"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := h.store.GetUserByEmail(claims.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	// The provider has verified the email
+	if err := h.store.UpdateUserVerification(c.Context(), u.ID); err != nil {
+		return nil, err
+	}
+	u.IsVerified = true
+
+	return u, nil
+}
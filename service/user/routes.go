@@ -1,44 +1,87 @@
 package user
 
 import (
+	"context"
 	"fmt"
+	"log"
 	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/golang-jwt/jwt/v4"
 
 	"github.com/jayden1905/abundance/cmd/pkg/database"
 	"github.com/jayden1905/abundance/config"
 	"github.com/jayden1905/abundance/service/auth"
 	"github.com/jayden1905/abundance/service/email"
+	"github.com/jayden1905/abundance/service/mfa"
+	"github.com/jayden1905/abundance/service/oidc"
+	"github.com/jayden1905/abundance/service/webauthn"
 	"github.com/jayden1905/abundance/types"
 	"github.com/jayden1905/abundance/utils"
 )
 
 type Handler struct {
-	store  types.UserStore
-	mailer email.Mailer
+	store         types.UserStore
+	tokenStore    types.TokenStore
+	mfaStore      types.MFAStore
+	webauthnStore types.WebAuthnStore
+	relyingParty  *webauthn.RelyingParty
+	oidcStore     types.OIDCStore
+	oidcProviders map[string]*oidc.Provider
+	mailer        email.Mailer
 }
 
-func NewHandler(store types.UserStore, mailer email.Mailer) *Handler {
-	return &Handler{store: store, mailer: mailer}
+func NewHandler(store types.UserStore, tokenStore types.TokenStore, mfaStore types.MFAStore, webauthnStore types.WebAuthnStore, relyingParty *webauthn.RelyingParty, oidcStore types.OIDCStore, oidcProviders map[string]*oidc.Provider, mailer email.Mailer) *Handler {
+	return &Handler{
+		store:         store,
+		tokenStore:    tokenStore,
+		mfaStore:      mfaStore,
+		webauthnStore: webauthnStore,
+		relyingParty:  relyingParty,
+		oidcStore:     oidcStore,
+		oidcProviders: oidcProviders,
+		mailer:        mailer,
+	}
 }
 
 // RegisterRoutes for Fiber
 func (h *Handler) RegisterRoutes(router fiber.Router) {
 	rateLimiterEmailVerification := auth.CreateRateLimiter(1, 5*time.Minute, "We have sent you a verification email. Please check your inbox and spam folder.")
+	rateLimiterForgotPassword := auth.CreateRateLimiter(3, 15*time.Minute, "We have sent you a password reset email. Please check your inbox and spam folder.")
+	rateLimiterResetPassword := auth.CreateRateLimiter(5, 15*time.Minute, "Too many password reset attempts. Please try again later.")
+	rateLimiterMFA := auth.CreateRateLimiter(5, 15*time.Minute, "Too many two-factor authentication attempts. Please try again later.")
+	rateLimiterMFASetup := auth.CreateRateLimiter(5, 15*time.Minute, "Too many two-factor authentication setup attempts. Please try again later.")
+	rateLimiterOIDC := auth.CreateRateLimiter(10, 15*time.Minute, "Too many sign in attempts. Please try again later.")
+	rateLimiterPasskey := auth.CreateRateLimiter(10, 15*time.Minute, "Too many passkey login attempts. Please try again later.")
 
 	router.Post("/user/auth/login", auth.BlockIfAuthenticated(h.handleLogin))
+	router.Post("/user/auth/login/mfa", rateLimiterMFA, auth.BlockIfAuthenticated(h.handleLoginMFA))
+	router.Post("/user/auth/login/mfa/setup", rateLimiterMFASetup, auth.BlockIfAuthenticated(h.handleLoginMFASetup))
+	router.Post("/user/auth/login/mfa/setup/confirm", rateLimiterMFA, auth.BlockIfAuthenticated(h.handleLoginMFASetupConfirm))
+	router.Post("/user/auth/passkey/begin", rateLimiterPasskey, auth.BlockIfAuthenticated(h.handleBeginPasskeyLogin))
+	router.Post("/user/auth/passkey/finish", rateLimiterPasskey, auth.BlockIfAuthenticated(h.handleFinishPasskeyLogin))
+	router.Post("/user/auth/oidc/:provider", rateLimiterOIDC, auth.BlockIfAuthenticated(h.handleBeginOIDCLogin))
+	router.Post("/user/auth/oidc/:provider/callback", rateLimiterOIDC, auth.BlockIfAuthenticated(h.handleFinishOIDCLogin))
 	router.Post("/user/auth/logout", h.handleLogout)
+	router.Post("/user/auth/refresh", h.handleRefreshToken)
 	router.Post("/user/register", h.handleRegister)
 	router.Patch("/user/super-user", h.handleCreateSuperUser)
 	router.Get("/users", auth.WithJWTAuth(h.handleGetUsersPaginated, h.store))
+	router.Get("/user/me", auth.WithJWTAuth(h.handleGetCurrentUser, h.store))
+	router.Patch("/user/me", auth.WithJWTAuth(h.handleUpdateCurrentUser, h.store))
+	router.Put("/user/me/password", auth.WithJWTAuth(h.handleUpdateUserPassword, h.store))
+	router.Get("/user/me/identities", auth.WithJWTAuth(h.handleGetIdentities, h.store))
+	router.Get("/user/me/passkeys", auth.WithJWTAuth(h.handleGetPasskeys, h.store))
+	router.Post("/user/me/passkeys/register/begin", auth.WithJWTAuth(h.handleBeginPasskeyRegistration, h.store))
+	router.Post("/user/me/passkeys/register/finish", auth.WithJWTAuth(h.handleFinishPasskeyRegistration, h.store))
+	router.Delete("/user/me/passkeys/:id", auth.WithJWTAuth(h.handleDeletePasskey, h.store))
 	router.Get("/user/:id", auth.WithJWTAuth(h.handleGetUserByID, h.store))
 	router.Delete("/user/:id", auth.WithJWTAuth(h.handleDeleteUser, h.store))
 	router.Get("/user/auth/status", h.handleIsAuthenticated)
 	router.Get("/user/verify/email", h.handleVerifyAccount)
 	router.Post("/user/verify/email/resend", rateLimiterEmailVerification, h.handleResendVerificationEmail)
+	router.Post("/user/password/forgot", rateLimiterForgotPassword, h.handleForgotPassword)
+	router.Post("/user/password/reset", rateLimiterResetPassword, h.handleResetPassword)
 }
 
 // Handler for registering a new user
@@ -153,6 +196,114 @@ func (h *Handler) handleResendVerificationEmail(c *fiber.Ctx) error {
 	})
 }
 
+// Handler for requesting a password reset email
+func (h *Handler) handleForgotPassword(c *fiber.Ctx) error {
+	var payload types.ForgotPasswordPayload
+
+	// Parse JSON payload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	// Always answer the same way so the endpoint cannot be used to find accounts
+	response := fiber.Map{"message": "If an account with that email exists, a password reset email has been sent"}
+
+	user, err := h.store.GetUserByEmail(payload.Email)
+	if err != nil {
+		return c.Status(fiber.StatusOK).JSON(response)
+	}
+
+	// Only the most recent reset link should work
+	if err := h.tokenStore.InvalidateUserPasswordResetTokens(c.Context(), user.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error invalidating reset tokens: %v", err)})
+	}
+
+	// Generate a single-use reset token and store only its hash
+	token, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	expiration := time.Second * time.Duration(config.Envs.PasswordResetExpirationInSeconds)
+	err = h.tokenStore.CreatePasswordResetToken(c.Context(), &types.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: auth.HashToken(token),
+		ExpiresAt: time.Now().Add(expiration),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error creating reset token: %v", err)})
+	}
+
+	// Send email asynchronously
+	go func() {
+		err := h.mailer.SendPasswordResetEmail(user.Email, token)
+		if err != nil {
+			fmt.Printf("Error sending password reset email: %v\n", err)
+		}
+	}()
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// Handler for resetting the password with an emailed token
+func (h *Handler) handleResetPassword(c *fiber.Ctx) error {
+	var payload types.ResetPasswordPayload
+
+	// Parse JSON payload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	resetToken, err := h.tokenStore.GetPasswordResetTokenByHash(auth.HashToken(payload.Token))
+	if err != nil || resetToken.UsedAt != nil || time.Now().After(resetToken.ExpiresAt) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Reset token is invalid or has expired"})
+	}
+
+	// Consume the token first so it cannot be used twice
+	ok, err := h.tokenStore.MarkPasswordResetTokenUsed(c.Context(), resetToken.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error consuming reset token: %v", err)})
+	}
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Reset token is invalid or has expired"})
+	}
+
+	// Hash the new password
+	hashedPassword, err := auth.HashPassword(payload.NewPassword)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Error hashing password"})
+	}
+
+	if err := h.store.UpdateUserPassword(c.Context(), resetToken.UserID, hashedPassword); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error updating user password: %v", err)})
+	}
+
+	// Sign out every existing session
+	if err := h.revokeAllSessions(c.Context(), resetToken.UserID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error revoking user sessions: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Password has been reset. Please log in with your new password"})
+}
+
 // Handler for verifying a user
 func (h *Handler) handleVerifyAccount(c *fiber.Ctx) error {
 	tokenString := c.Query("token")
@@ -160,6 +311,11 @@ func (h *Handler) handleVerifyAccount(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Token is missing"})
 	}
 
+	// Tokens sent to a new address confirm an email change instead of a signup
+	if userID, newEmail, err := auth.ValidateEmailChangeToken(tokenString); err == nil {
+		return h.confirmEmailChange(c, userID, newEmail)
+	}
+
 	// Validate the verification token and return email
 	email, err := auth.ValidateVerificationToken(tokenString)
 	if err != nil {
@@ -184,6 +340,29 @@ func (h *Handler) handleVerifyAccount(c *fiber.Ctx) error {
 	return c.Redirect(config.Envs.PublicHost+"/", fiber.StatusSeeOther)
 }
 
+// confirmEmailChange moves the user to the new, now verified, email address
+func (h *Handler) confirmEmailChange(c *fiber.Ctx, userID int32, newEmail string) error {
+	user, err := h.store.GetUserByID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Error getting user by id: %v", err)})
+	}
+
+	if user.Email == newEmail {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Email has already been changed"})
+	}
+
+	// The address may have been taken since the change was requested
+	if _, err := h.store.GetUserByEmail(newEmail); err == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("User with email %s already exists", newEmail)})
+	}
+
+	if err := h.store.UpdateUserEmail(c.Context(), user.ID, newEmail); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error updating user email: %v", err)})
+	}
+
+	return c.Redirect(config.Envs.PublicHost+"/", fiber.StatusSeeOther)
+}
+
 // Hanlder for login
 func (h *Handler) handleLogin(c *fiber.Ctx) error {
 	// Parse JSON payload
@@ -216,12 +395,161 @@ func (h *Handler) handleLogin(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Please verify your email"})
 	}
 
-	secret := []byte(config.Envs.JWTSecret)
-	token, err := auth.CreateJWT(secret, int(u.ID))
+	return h.completeLogin(c, u)
+}
+
+// completeLogin finishes a first login step: users with two-factor authentication get
+// a code challenge, and everyone else a session in a new refresh token family
+func (h *Handler) completeLogin(c *fiber.Ctx, u *types.User) error {
+	// Users with two-factor authentication finish logging in with a code
+	userMFA, err := h.mfaStore.GetUserMFA(u.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting two-factor authentication: %v", err)})
+	}
+	if userMFA != nil && userMFA.IsEnabled {
+		return mfaChallenge(c, u.ID, auth.MFAPurposeVerify, "mfa_required")
+	}
+
+	// Roles that must use two-factor authentication enroll before they get a session
+	required, err := mfa.IsRequired(u.ID, h.store)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	if required {
+		return mfaChallenge(c, u.ID, auth.MFAPurposeSetup, "mfa_setup_required")
+	}
+
+	// Start a new refresh token family for this login
+	familyID, err := auth.GenerateTokenFamilyID()
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
 
+	session, err := h.issueSession(c, u.ID, familyID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(session)
+}
+
+// Handler for rotating a refresh token into a new access and refresh token pair
+func (h *Handler) handleRefreshToken(c *fiber.Ctx) error {
+	refreshToken := c.Cookies("refresh_token")
+	if refreshToken == "" {
+		// Fall back to the JSON body for clients that do not use cookies
+		var payload types.RefreshTokenPayload
+		if err := c.BodyParser(&payload); err == nil {
+			refreshToken = payload.RefreshToken
+		}
+	}
+
+	if refreshToken == "" {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Refresh token is missing"})
+	}
+
+	stored, err := h.tokenStore.GetRefreshTokenByHash(auth.HashToken(refreshToken))
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Refresh token is invalid"})
+	}
+
+	// A token that was already rotated or revoked is being replayed, so the
+	// whole family is considered compromised
+	if stored.UsedAt != nil || stored.RevokedAt != nil {
+		return h.handleRefreshTokenReuse(c, stored)
+	}
+
+	if time.Now().After(stored.ExpiresAt) {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Refresh token has expired"})
+	}
+
+	// Mark the token as used; losing this race to a concurrent request is also reuse
+	ok, err := h.tokenStore.MarkRefreshTokenUsed(c.Context(), stored.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error rotating refresh token: %v", err)})
+	}
+	if !ok {
+		return h.handleRefreshTokenReuse(c, stored)
+	}
+
+	// Make sure the user still exists
+	u, err := h.store.GetUserByID(stored.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Refresh token is invalid"})
+	}
+
+	session, err := h.issueSession(c, u.ID, stored.FamilyID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(session)
+}
+
+// Handler for logout
+func (h *Handler) handleLogout(c *fiber.Ctx) error {
+	// Revoke the access token so copies sent via the Authorization header stop working
+	accessToken := c.Cookies("token")
+	if accessToken == "" {
+		accessToken = c.Get("Authorization")
+	}
+	if accessToken != "" {
+		if err := auth.RevokeAccessToken(c.Context(), accessToken); err != nil {
+			log.Printf("error revoking access token: %v", err)
+		}
+	}
+
+	// Revoke the refresh token family so the session cannot be renewed
+	if refreshToken := c.Cookies("refresh_token"); refreshToken != "" {
+		if stored, err := h.tokenStore.GetRefreshTokenByHash(auth.HashToken(refreshToken)); err == nil {
+			if err := h.tokenStore.RevokeRefreshTokenFamily(c.Context(), stored.FamilyID); err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error revoking refresh token: %v", err)})
+			}
+		}
+	}
+
+	clearSessionCookies(c)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Logged out successfully"})
+}
+
+// handleRefreshTokenReuse revokes the token family and clears the session cookies
+func (h *Handler) handleRefreshTokenReuse(c *fiber.Ctx, stored *types.RefreshToken) error {
+	log.Printf("refresh token reuse detected for user %d, revoking family %s", stored.UserID, stored.FamilyID)
+
+	if err := h.tokenStore.RevokeRefreshTokenFamily(c.Context(), stored.FamilyID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error revoking refresh token: %v", err)})
+	}
+
+	clearSessionCookies(c)
+
+	return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Refresh token reuse detected. Please log in again"})
+}
+
+// issueSession creates an access token and a refresh token in the given family
+// and sets both as cookies
+func (h *Handler) issueSession(c *fiber.Ctx, userID int32, familyID string) (fiber.Map, error) {
+	token, err := auth.CreateJWT(int(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := auth.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	refreshExpiration := time.Second * time.Duration(config.Envs.RefreshTokenExpirationInSeconds)
+	err = h.tokenStore.CreateRefreshToken(c.Context(), &types.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: auth.HashToken(refreshToken),
+		ExpiresAt: time.Now().Add(refreshExpiration),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error storing refresh token: %v", err)
+	}
+
 	c.Cookie(&fiber.Cookie{
 		Name:     "token",
 		Value:    token,
@@ -232,12 +560,35 @@ func (h *Handler) handleLogin(c *fiber.Ctx) error {
 		MaxAge:   int(config.Envs.JWTExpirationInSeconds),
 	})
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{"token": token, "expires_in": fmt.Sprintf("%d", config.Envs.JWTExpirationInSeconds)})
+	c.Cookie(&fiber.Cookie{
+		Name:     "refresh_token",
+		Value:    refreshToken,
+		HTTPOnly: true,
+		Secure:   config.Envs.ISProduction,
+		SameSite: "Strict",
+		Path:     "/api/v1/user/auth", // Only sent to the refresh and logout routes
+		MaxAge:   int(config.Envs.RefreshTokenExpirationInSeconds),
+	})
+
+	return fiber.Map{
+		"token":              token,
+		"expires_in":         fmt.Sprintf("%d", config.Envs.JWTExpirationInSeconds),
+		"refresh_token":      refreshToken,
+		"refresh_expires_in": fmt.Sprintf("%d", config.Envs.RefreshTokenExpirationInSeconds),
+	}, nil
 }
 
-// Handler for logout
-func (h *Handler) handleLogout(c *fiber.Ctx) error {
-	// Clear the token cookie by setting an expired cookie
+// revokeAllSessions revokes every access and refresh token issued to the user
+func (h *Handler) revokeAllSessions(ctx context.Context, userID int32) error {
+	if err := auth.RevokeUserTokens(ctx, userID); err != nil {
+		return err
+	}
+
+	return h.tokenStore.RevokeUserRefreshTokens(ctx, userID)
+}
+
+// clearSessionCookies clears the access and refresh token cookies by setting expired cookies
+func clearSessionCookies(c *fiber.Ctx) {
 	c.Cookie(&fiber.Cookie{
 		Name:     "token",
 		Value:    "",
@@ -248,7 +599,15 @@ func (h *Handler) handleLogout(c *fiber.Ctx) error {
 		Path:     "/",                      // Valid for the entire site
 	})
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Logged out successfully"})
+	c.Cookie(&fiber.Cookie{
+		Name:     "refresh_token",
+		Value:    "",
+		Expires:  time.Now().Add(-time.Hour),
+		HTTPOnly: true,
+		Secure:   config.Envs.ISProduction,
+		SameSite: "Strict",
+		Path:     "/api/v1/user/auth",
+	})
 }
 
 // Handler for creating a super user
@@ -347,6 +706,11 @@ func (h *Handler) handleDeleteUser(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "You cannot delete yourself"})
 	}
 
+	// Kill the user's outstanding tokens before the account goes away
+	if err := h.revokeAllSessions(c.Context(), id); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error revoking user sessions: %v", err)})
+	}
+
 	// delete user
 	if err := h.store.DeleteUserByID(c.Context(), id); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error deleting user by id: %v", err)})
@@ -445,33 +809,23 @@ func (h *Handler) handleIsAuthenticated(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Token is missing"})
 	}
 
-	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-		}
-		return []byte(config.Envs.JWTSecret), nil
-	})
+	claims, err := auth.ValidateAccessToken(tokenString)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Error parsing token"})
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Token is invalid"})
 	}
 
-	if !token.Valid {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Token is invalid"})
+	if auth.IsTokenRevoked(claims) {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Token has been revoked"})
 	}
 
 	// get user id from token
-	claims := token.Claims.(jwt.MapClaims)
-	str := claims["userID"].(string)
-
-	userIDInt, err := strconv.Atoi(str)
+	userID, err := claims.UserID()
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Error getting user id from token"})
 	}
 
-	userID := int32(userIDInt)
-
 	// get if user exists
-	user, err := h.store.GetUserByID(int32(userID))
+	user, err := h.store.GetUserByID(userID)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting user by id: %v", err)})
 	}
@@ -481,6 +835,84 @@ func (h *Handler) handleIsAuthenticated(c *fiber.Ctx) error {
 	})
 }
 
+// Handler for getting the current user
+func (h *Handler) handleGetCurrentUser(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	u, err := h.store.GetUserByID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting user by id: %v", err)})
+	}
+
+	// Never send the password hash back to the client
+	u.PasswordHash = ""
+
+	return c.Status(fiber.StatusOK).JSON(u)
+}
+
+// Handler for updating the current user's username and email
+func (h *Handler) handleUpdateCurrentUser(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.UpdateUserInformationPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		// Return the invalid fields if validation fails
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	u, err := h.store.GetUserByID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "User not found"})
+	}
+
+	if payload.Username != "" && payload.Username != u.Username {
+		if err := h.store.UpdateUsername(c.Context(), userID, payload.Username); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error updating username: %v", err)})
+		}
+		u.Username = payload.Username
+	}
+
+	response := fiber.Map{"message": "User updated successfully"}
+
+	// The new email only takes effect once the user proves they own it
+	if payload.Email != "" && payload.Email != u.Email {
+		if _, err := h.store.GetUserByEmail(payload.Email); err == nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("User with email %s already exists", payload.Email)})
+		}
+
+		token, err := auth.GenerateEmailChangeToken(userID, payload.Email)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		// Send email asynchronously
+		go func() {
+			err := h.mailer.SendVerificationEmail(payload.Email, token)
+			if err != nil {
+				fmt.Printf("Error sending verification email: %v\n", err)
+			}
+		}()
+
+		response["pending_email"] = payload.Email
+		response["message"] = "User updated successfully. Please verify your new email address"
+	}
+
+	u.PasswordHash = ""
+	response["user"] = u
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
 // Handler for updating user password
 func (h *Handler) handleUpdateUserPassword(c *fiber.Ctx) error {
 	userID := auth.GetUserIDFromContext(c)
@@ -523,5 +955,22 @@ func (h *Handler) handleUpdateUserPassword(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error updating user password: %v", err)})
 	}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Password updated successfully"})
+	// Sign out every session that was using the old password, including this one
+	if err := h.revokeAllSessions(c.Context(), userID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error revoking user sessions: %v", err)})
+	}
+
+	// Keep the current client signed in with a session in a new refresh token family
+	familyID, err := auth.GenerateTokenFamilyID()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	session, err := h.issueSession(c, userID, familyID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	session["message"] = "Password updated successfully"
+
+	return c.Status(fiber.StatusOK).JSON(session)
 }
@@ -192,3 +192,30 @@ func (s *Store) UpdateUserPassword(ctx context.Context, id int32, passwordHash s
 
 	return nil
 }
+
+// UpdateUsername updates the username in the database
+func (s *Store) UpdateUsername(ctx context.Context, id int32, username string) error {
+	err := s.db.UpdateUserUsername(ctx, database.UpdateUserUsernameParams{
+		Username: username,
+		UserID:   id,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateUserEmail sets a new, already verified email address for the user
+func (s *Store) UpdateUserEmail(ctx context.Context, id int32, email string) error {
+	err := s.db.UpdateUserEmail(ctx, database.UpdateUserEmailParams{
+		Email:      email,
+		IsVerified: true,
+		UserID:     id,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
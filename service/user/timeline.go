@@ -0,0 +1,56 @@
+package user
+
+import (
+	"context"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+)
+
+// TimelineTypes lists the account event types of the store
+func (s *Store) TimelineTypes() []string {
+	return []string{types.TimelineAccountCreated, types.TimelineSignIn}
+}
+
+// TimelineEvents returns the user's account creation and up to the query's limit of
+// sign-ins on the page. A sign-in is the first refresh token of a token family.
+func (s *Store) TimelineEvents(userID int32, query types.TimelineQuery) ([]*types.TimelineEvent, error) {
+	user, err := s.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*types.TimelineEvent, 0)
+	if query.Includes(types.TimelineAccountCreated, user.CreatedAt, user.ID) {
+		events = append(events, &types.TimelineEvent{
+			Type:       types.TimelineAccountCreated,
+			ID:         user.ID,
+			OccurredAt: user.CreatedAt,
+			Data:       &types.AccountEvent{UserID: user.ID, Description: "Account created"},
+		})
+	}
+
+	start, afterID := query.Bound(types.TimelineSignIn)
+	signIns, err := s.db.GetSignInsForTimeline(context.Background(), database.GetSignInsForTimelineParams{
+		UserID:         userID,
+		CreatedAt:      start.UTC(),
+		CreatedAt_2:    start.UTC(),
+		RefreshTokenID: afterID,
+		CreatedAt_3:    query.End.UTC(),
+		Limit:          int32(query.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, signIn := range signIns {
+		events = append(events, &types.TimelineEvent{
+			Type:       types.TimelineSignIn,
+			ID:         signIn.RefreshTokenID,
+			OccurredAt: signIn.CreatedAt,
+			Data:       &types.AccountEvent{UserID: userID, Description: "Signed in"},
+		})
+	}
+
+	return events, nil
+}
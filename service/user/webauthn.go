@@ -0,0 +1,298 @@
+package user
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/abundance/service/auth"
+	"github.com/jayden1905/abundance/service/webauthn"
+	"github.com/jayden1905/abundance/types"
+	"github.com/jayden1905/abundance/utils"
+)
+
+// Handler for starting the registration of a passkey for the current user
+func (h *Handler) handleBeginPasskeyRegistration(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	u, err := h.store.GetUserByID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting user by id: %v", err)})
+	}
+
+	existing, err := h.webauthnStore.GetCredentialsByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting passkeys: %v", err)})
+	}
+
+	// Keep authenticators from registering a second passkey for the same user
+	exclude := make([]webauthn.CredentialDescriptor, 0, len(existing))
+	for _, credential := range existing {
+		exclude = append(exclude, webauthn.CredentialDescriptor{
+			Type:       "public-key",
+			ID:         webauthn.EncodeBase64URL(credential.ExternalID),
+			Transports: credential.Transports,
+		})
+	}
+
+	challenge, err := h.newPasskeyChallenge(c, webauthn.CeremonyRegistration, &userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error creating challenge: %v", err)})
+	}
+
+	options := h.relyingParty.CreationOptions(challenge, userID, u.Email, u.Username, exclude)
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"publicKey": options})
+}
+
+// Handler for finishing the registration of a passkey for the current user
+func (h *Handler) handleFinishPasskeyRegistration(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Parse JSON payload
+	var payload types.PasskeyRegistrationPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	for _, transport := range payload.Response.Transports {
+		if !webauthn.IsValidTransport(transport) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid transport: %s", transport)})
+		}
+	}
+
+	clientDataJSON, err := webauthn.DecodeBase64URL(payload.Response.ClientDataJSON)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid client data"})
+	}
+	attestationObject, err := webauthn.DecodeBase64URL(payload.Response.AttestationObject)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid attestation object"})
+	}
+
+	challenge, err := h.consumePasskeyChallenge(c, clientDataJSON, webauthn.CeremonyRegistration)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting challenge: %v", err)})
+	}
+	if challenge == nil || challenge.UserID == nil || *challenge.UserID != userID {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Registration has expired. Please try again"})
+	}
+
+	credential, err := h.relyingParty.VerifyRegistration(challenge.Challenge, clientDataJSON, attestationObject)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid passkey: %v", err)})
+	}
+
+	// The ID the client reports must be the one the authenticator attested
+	if id, err := webauthn.DecodeBase64URL(payload.ID); err != nil || !bytes.Equal(id, credential.ID) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid passkey: credential ID does not match"})
+	}
+
+	existing, err := h.webauthnStore.GetCredentialByExternalID(credential.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting passkey: %v", err)})
+	}
+	if existing != nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "This passkey is already registered"})
+	}
+
+	name := payload.Name
+	if name == "" {
+		name = "Passkey"
+	}
+
+	transports := payload.Response.Transports
+	if transports == nil {
+		transports = []string{}
+	}
+
+	err = h.webauthnStore.CreateCredential(c.Context(), &types.WebAuthnCredential{
+		UserID:         userID,
+		ExternalID:     credential.ID,
+		PublicKey:      credential.PublicKey,
+		Algorithm:      credential.Algorithm,
+		SignCount:      credential.SignCount,
+		Transports:     transports,
+		AAGUID:         credential.AAGUID,
+		Name:           name,
+		BackupEligible: credential.BackupEligible,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error saving passkey: %v", err)})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"message": "Passkey registered successfully"})
+}
+
+// Handler for listing the current user's passkeys
+func (h *Handler) handleGetPasskeys(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	credentials, err := h.webauthnStore.GetCredentialsByUserID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting passkeys: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(credentials)
+}
+
+// Handler for removing one of the current user's passkeys
+func (h *Handler) handleDeletePasskey(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// convert id to int
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid passkey ID"})
+	}
+
+	credential, err := h.webauthnStore.GetCredentialByID(int32(id))
+	if err != nil || credential.UserID != userID {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Passkey not found"})
+	}
+
+	if err := h.webauthnStore.DeleteCredential(c.Context(), credential.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error deleting passkey: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Passkey deleted successfully"})
+}
+
+// Handler for starting a login with a passkey
+func (h *Handler) handleBeginPasskeyLogin(c *fiber.Ctx) error {
+	challenge, err := h.newPasskeyChallenge(c, webauthn.CeremonyLogin, nil)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error creating challenge: %v", err)})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"publicKey": h.relyingParty.RequestOptions(challenge)})
+}
+
+// Handler for finishing a login with a passkey. The passkey verifies the user itself,
+// so the login does not take a second step.
+func (h *Handler) handleFinishPasskeyLogin(c *fiber.Ctx) error {
+	// Parse JSON payload
+	var payload types.PasskeyLoginPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	// Validate the payload
+	invalidFields, err := utils.ValidatePayload(payload)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	externalID, err := webauthn.DecodeBase64URL(payload.ID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid credential ID"})
+	}
+	clientDataJSON, err := webauthn.DecodeBase64URL(payload.Response.ClientDataJSON)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid client data"})
+	}
+	authenticatorData, err := webauthn.DecodeBase64URL(payload.Response.AuthenticatorData)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid authenticator data"})
+	}
+	signature, err := webauthn.DecodeBase64URL(payload.Response.Signature)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid signature"})
+	}
+
+	challenge, err := h.consumePasskeyChallenge(c, clientDataJSON, webauthn.CeremonyLogin)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting challenge: %v", err)})
+	}
+	if challenge == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Login has expired. Please try again"})
+	}
+
+	credential, err := h.webauthnStore.GetCredentialByExternalID(externalID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting passkey: %v", err)})
+	}
+	if credential == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Passkey is not registered"})
+	}
+
+	// A discoverable credential names its user, which must own the passkey
+	if payload.Response.UserHandle != "" {
+		userHandle, err := webauthn.DecodeBase64URL(payload.Response.UserHandle)
+		if err != nil || !bytes.Equal(userHandle, webauthn.UserHandle(credential.UserID)) {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Passkey is not registered"})
+		}
+	}
+
+	signCount, err := h.relyingParty.VerifyAssertion(challenge.Challenge, credential.PublicKey, credential.SignCount, clientDataJSON, authenticatorData, signature)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": fmt.Sprintf("Invalid passkey: %v", err)})
+	}
+
+	if err := h.webauthnStore.UpdateCredentialUsage(c.Context(), credential.ID, signCount); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error updating passkey: %v", err)})
+	}
+
+	u, err := h.store.GetUserByID(credential.UserID)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Passkey is not registered"})
+	}
+	if !u.IsVerified {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Please verify your email"})
+	}
+
+	return h.startSession(c, u.ID, nil)
+}
+
+// newPasskeyChallenge stores a new challenge for a ceremony
+func (h *Handler) newPasskeyChallenge(c *fiber.Ctx, ceremony string, userID *int32) (string, error) {
+	challenge, err := webauthn.GenerateChallenge()
+	if err != nil {
+		return "", err
+	}
+
+	err = h.webauthnStore.CreateChallenge(c.Context(), &types.WebAuthnChallenge{
+		Challenge: challenge,
+		Ceremony:  ceremony,
+		UserID:    userID,
+		ExpiresAt: time.Now().UTC().Add(webauthn.ChallengeTTL),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return challenge, nil
+}
+
+// consumePasskeyChallenge looks up the challenge the client data answers and uses it
+// up. It returns nil if the challenge is unknown, expired or for another ceremony.
+func (h *Handler) consumePasskeyChallenge(c *fiber.Ctx, clientDataJSON []byte, ceremony string) (*types.WebAuthnChallenge, error) {
+	value, err := webauthn.ChallengeOf(clientDataJSON)
+	if err != nil {
+		return nil, nil
+	}
+
+	challenge, err := h.webauthnStore.ConsumeChallenge(c.Context(), value)
+	if err != nil {
+		return nil, err
+	}
+	if challenge == nil || challenge.Ceremony != ceremony {
+		return nil, nil
+	}
+
+	return challenge, nil
+}
@@ -0,0 +1,165 @@
+package webauthn
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// maxCBORDepth limits nesting so that hostile input cannot exhaust the stack
+const maxCBORDepth = 16
+
+// decodeCBOR decodes the first CBOR data item (RFC 8949) in data and returns it with
+// the number of bytes it used. Only the definite-length encodings WebAuthn uses are
+// supported. Integers decode to int64, byte strings to []byte, text to string, arrays
+// to []interface{} and maps to map[interface{}]interface{}.
+func decodeCBOR(data []byte) (interface{}, int, error) {
+	return decodeItem(data, 0)
+}
+
+func decodeItem(data []byte, depth int) (interface{}, int, error) {
+	if depth > maxCBORDepth {
+		return nil, 0, fmt.Errorf("cbor: nested too deeply")
+	}
+	if len(data) == 0 {
+		return nil, 0, fmt.Errorf("cbor: unexpected end of data")
+	}
+
+	major := data[0] >> 5
+	info := data[0] & 0x1f
+
+	// Simple values and floats carry their value in the argument bits
+	if major == 7 {
+		return decodeSimple(data, info)
+	}
+
+	arg, n, err := decodeArgument(data, info)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch major {
+	case 0:
+		if arg > math.MaxInt64 {
+			return nil, 0, fmt.Errorf("cbor: integer overflows int64")
+		}
+		return int64(arg), n, nil
+	case 1:
+		if arg > math.MaxInt64 {
+			return nil, 0, fmt.Errorf("cbor: integer overflows int64")
+		}
+		return -1 - int64(arg), n, nil
+	case 2, 3:
+		if arg > uint64(len(data)-n) {
+			return nil, 0, fmt.Errorf("cbor: string longer than data")
+		}
+		end := n + int(arg)
+		if major == 2 {
+			b := make([]byte, arg)
+			copy(b, data[n:end])
+			return b, end, nil
+		}
+		return string(data[n:end]), end, nil
+	case 4:
+		// Every item takes at least one byte
+		if arg > uint64(len(data)-n) {
+			return nil, 0, fmt.Errorf("cbor: array longer than data")
+		}
+		items := make([]interface{}, 0, arg)
+		for i := uint64(0); i < arg; i++ {
+			item, used, err := decodeItem(data[n:], depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			items = append(items, item)
+			n += used
+		}
+		return items, n, nil
+	case 5:
+		if arg > uint64(len(data)-n)/2 {
+			return nil, 0, fmt.Errorf("cbor: map longer than data")
+		}
+		m := make(map[interface{}]interface{}, arg)
+		for i := uint64(0); i < arg; i++ {
+			key, used, err := decodeItem(data[n:], depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			n += used
+
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, 0, fmt.Errorf("cbor: unsupported map key type %T", key)
+			}
+			if _, dup := m[key]; dup {
+				return nil, 0, fmt.Errorf("cbor: duplicate map key %v", key)
+			}
+
+			value, used, err := decodeItem(data[n:], depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			m[key] = value
+			n += used
+		}
+		return m, n, nil
+	default:
+		return nil, 0, fmt.Errorf("cbor: tags are not supported")
+	}
+}
+
+// decodeArgument reads the argument of a data item head
+func decodeArgument(data []byte, info byte) (uint64, int, error) {
+	switch {
+	case info < 24:
+		return uint64(info), 1, nil
+	case info == 24:
+		if len(data) < 2 {
+			return 0, 0, fmt.Errorf("cbor: unexpected end of data")
+		}
+		return uint64(data[1]), 2, nil
+	case info == 25:
+		if len(data) < 3 {
+			return 0, 0, fmt.Errorf("cbor: unexpected end of data")
+		}
+		return uint64(binary.BigEndian.Uint16(data[1:3])), 3, nil
+	case info == 26:
+		if len(data) < 5 {
+			return 0, 0, fmt.Errorf("cbor: unexpected end of data")
+		}
+		return uint64(binary.BigEndian.Uint32(data[1:5])), 5, nil
+	case info == 27:
+		if len(data) < 9 {
+			return 0, 0, fmt.Errorf("cbor: unexpected end of data")
+		}
+		return binary.BigEndian.Uint64(data[1:9]), 9, nil
+	default:
+		return 0, 0, fmt.Errorf("cbor: indefinite lengths are not supported")
+	}
+}
+
+func decodeSimple(data []byte, info byte) (interface{}, int, error) {
+	switch info {
+	case 20:
+		return false, 1, nil
+	case 21:
+		return true, 1, nil
+	case 22, 23:
+		return nil, 1, nil
+	case 26:
+		bits, n, err := decodeArgument(data, info)
+		if err != nil {
+			return nil, 0, err
+		}
+		return float64(math.Float32frombits(uint32(bits))), n, nil
+	case 27:
+		bits, n, err := decodeArgument(data, info)
+		if err != nil {
+			return nil, 0, err
+		}
+		return math.Float64frombits(bits), n, nil
+	default:
+		return nil, 0, fmt.Errorf("cbor: unsupported simple value %d", info)
+	}
+}
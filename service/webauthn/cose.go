@@ -0,0 +1,132 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+)
+
+// COSE algorithm identifiers (RFC 9053) of the supported credential keys
+const (
+	AlgES256 int64 = -7
+	AlgEdDSA int64 = -8
+	AlgRS256 int64 = -257
+)
+
+// SupportedAlgorithms are offered to authenticators in order of preference
+var SupportedAlgorithms = []int64{AlgES256, AlgEdDSA, AlgRS256}
+
+// COSE key parameters
+const (
+	coseKty = 1
+	coseAlg = 3
+	coseCrv = -1
+	coseX   = -2
+	coseY   = -3
+	coseN   = -1
+	coseE   = -2
+
+	ktyOKP = 1
+	ktyEC2 = 2
+	ktyRSA = 3
+
+	crvP256    = 1
+	crvEd25519 = 6
+)
+
+// PublicKey is a credential public key decoded from its COSE encoding
+type PublicKey struct {
+	Algorithm int64
+	key       crypto.PublicKey
+}
+
+// ParsePublicKey decodes a COSE_Key and checks it is a supported algorithm
+func ParsePublicKey(cose []byte) (*PublicKey, error) {
+	value, n, err := decodeCBOR(cose)
+	if err != nil {
+		return nil, err
+	}
+	if n != len(cose) {
+		return nil, fmt.Errorf("trailing data after COSE key")
+	}
+
+	m, ok := value.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("COSE key is not a map")
+	}
+
+	kty, _ := m[int64(coseKty)].(int64)
+	alg, _ := m[int64(coseAlg)].(int64)
+
+	switch {
+	case kty == ktyEC2 && alg == AlgES256:
+		crv, _ := m[int64(coseCrv)].(int64)
+		x, _ := m[int64(coseX)].([]byte)
+		y, _ := m[int64(coseY)].([]byte)
+		if crv != crvP256 || len(x) != 32 || len(y) != 32 {
+			return nil, fmt.Errorf("invalid P-256 key")
+		}
+
+		// Reject points that are not on the curve
+		point := append([]byte{0x04}, append(x, y...)...)
+		if _, err := ecdh.P256().NewPublicKey(point); err != nil {
+			return nil, fmt.Errorf("invalid P-256 key: %v", err)
+		}
+
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		return &PublicKey{Algorithm: alg, key: key}, nil
+
+	case kty == ktyOKP && alg == AlgEdDSA:
+		crv, _ := m[int64(coseCrv)].(int64)
+		x, _ := m[int64(coseX)].([]byte)
+		if crv != crvEd25519 || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key")
+		}
+		return &PublicKey{Algorithm: alg, key: ed25519.PublicKey(x)}, nil
+
+	case kty == ktyRSA && alg == AlgRS256:
+		n, _ := m[int64(coseN)].([]byte)
+		e, _ := m[int64(coseE)].([]byte)
+		if len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("invalid RSA key")
+		}
+
+		key := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		if key.N.BitLen() < 2048 || key.E < 3 {
+			return nil, fmt.Errorf("RSA key is too weak")
+		}
+		return &PublicKey{Algorithm: alg, key: key}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported key type %d with algorithm %d", kty, alg)
+	}
+}
+
+// Verify checks a signature over data made with the key's algorithm
+func (k *PublicKey) Verify(data []byte, signature []byte) error {
+	switch key := k.key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(data)
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return fmt.Errorf("invalid signature")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, data, signature) {
+			return fmt.Errorf("invalid signature")
+		}
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(data)
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return fmt.Errorf("invalid signature")
+		}
+	default:
+		return fmt.Errorf("unsupported key")
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package webauthn
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jayden1905/abundance/cmd/pkg/database"
+	"github.com/jayden1905/abundance/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+func credentialFromRow(row database.WebauthnCredential) *types.WebAuthnCredential {
+	credential := &types.WebAuthnCredential{
+		ID:             row.CredentialID,
+		UserID:         row.UserID,
+		ExternalID:     row.ExternalID,
+		PublicKey:      row.PublicKey,
+		Algorithm:      int64(row.Algorithm),
+		SignCount:      row.SignCount,
+		Transports:     []string{},
+		AAGUID:         row.Aaguid,
+		Name:           row.Name,
+		BackupEligible: row.BackupEligible,
+		CreatedAt:      row.CreatedAt,
+	}
+	if row.Transports != "" {
+		credential.Transports = strings.Split(row.Transports, ",")
+	}
+	if row.LastUsedAt.Valid {
+		lastUsed := row.LastUsedAt.Time
+		credential.LastUsedAt = &lastUsed
+	}
+	return credential
+}
+
+// CreateCredential stores a newly registered passkey
+func (s *Store) CreateCredential(ctx context.Context, credential *types.WebAuthnCredential) error {
+	return s.db.CreateWebAuthnCredential(ctx, database.CreateWebAuthnCredentialParams{
+		UserID:         credential.UserID,
+		ExternalID:     credential.ExternalID,
+		PublicKey:      credential.PublicKey,
+		Algorithm:      int32(credential.Algorithm),
+		SignCount:      credential.SignCount,
+		Transports:     strings.Join(credential.Transports, ","),
+		Aaguid:         credential.AAGUID,
+		Name:           credential.Name,
+		BackupEligible: credential.BackupEligible,
+	})
+}
+
+// GetCredentialByID fetches a passkey by its ID
+func (s *Store) GetCredentialByID(id int32) (*types.WebAuthnCredential, error) {
+	row, err := s.db.GetWebAuthnCredentialByID(context.Background(), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("passkey not found")
+		}
+		return nil, err
+	}
+
+	return credentialFromRow(row), nil
+}
+
+// GetCredentialByExternalID fetches a passkey by the credential ID the authenticator
+// assigned, or nil if no such passkey is registered
+func (s *Store) GetCredentialByExternalID(externalID []byte) (*types.WebAuthnCredential, error) {
+	row, err := s.db.GetWebAuthnCredentialByExternalID(context.Background(), externalID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return credentialFromRow(row), nil
+}
+
+// GetCredentialsByUserID fetches the user's passkeys, newest first
+func (s *Store) GetCredentialsByUserID(userID int32) ([]*types.WebAuthnCredential, error) {
+	rows, err := s.db.GetWebAuthnCredentialsByUserID(context.Background(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := make([]*types.WebAuthnCredential, 0, len(rows))
+	for _, row := range rows {
+		credentials = append(credentials, credentialFromRow(row))
+	}
+	return credentials, nil
+}
+
+// UpdateCredentialUsage records a login with the passkey and its new sign count
+func (s *Store) UpdateCredentialUsage(ctx context.Context, id int32, signCount uint32) error {
+	return s.db.UpdateWebAuthnCredentialUsage(ctx, database.UpdateWebAuthnCredentialUsageParams{
+		SignCount:    signCount,
+		CredentialID: id,
+	})
+}
+
+// DeleteCredential removes a passkey
+func (s *Store) DeleteCredential(ctx context.Context, id int32) error {
+	return s.db.DeleteWebAuthnCredential(ctx, id)
+}
+
+// CreateChallenge stores the challenge of a new ceremony and clears expired ones
+func (s *Store) CreateChallenge(ctx context.Context, challenge *types.WebAuthnChallenge) error {
+	if err := s.db.DeleteExpiredWebAuthnChallenges(ctx); err != nil {
+		return err
+	}
+
+	params := database.CreateWebAuthnChallengeParams{
+		Challenge: challenge.Challenge,
+		Ceremony:  database.WebauthnChallengesCeremony(challenge.Ceremony),
+		ExpiresAt: challenge.ExpiresAt,
+	}
+	if challenge.UserID != nil {
+		params.UserID = sql.NullInt32{Int32: *challenge.UserID, Valid: true}
+	}
+
+	return s.db.CreateWebAuthnChallenge(ctx, params)
+}
+
+// ConsumeChallenge fetches an unexpired challenge and deletes it so that it can only
+// be used once. It returns nil if the challenge is unknown, expired or already used.
+func (s *Store) ConsumeChallenge(ctx context.Context, challenge string) (*types.WebAuthnChallenge, error) {
+	row, err := s.db.GetWebAuthnChallenge(ctx, challenge)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// Another request may have consumed it in the meantime
+	affected, err := s.db.DeleteWebAuthnChallenge(ctx, challenge)
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, nil
+	}
+
+	consumed := &types.WebAuthnChallenge{
+		Challenge: row.Challenge,
+		Ceremony:  string(row.Ceremony),
+		ExpiresAt: row.ExpiresAt,
+	}
+	if row.UserID.Valid {
+		userID := row.UserID.Int32
+		consumed.UserID = &userID
+	}
+	return consumed, nil
+}
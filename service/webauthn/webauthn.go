@@ -0,0 +1,400 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChallengeTTL is how long a ceremony may take before its challenge expires
+const ChallengeTTL = 5 * time.Minute
+
+// Ceremonies a challenge can be used for
+const (
+	CeremonyRegistration = "registration"
+	CeremonyLogin        = "login"
+)
+
+// Authenticator data flags
+const (
+	FlagUserPresent    byte = 0x01
+	FlagUserVerified   byte = 0x04
+	FlagBackupEligible byte = 0x08
+	FlagBackedUp       byte = 0x10
+	FlagAttestedData   byte = 0x40
+	FlagExtensionData  byte = 0x80
+)
+
+// Transports an authenticator may report for a credential
+var Transports = []string{"ble", "hybrid", "internal", "nfc", "smart-card", "usb"}
+
+// RelyingParty describes this service to authenticators and verifies their responses
+type RelyingParty struct {
+	ID      string
+	Name    string
+	Origins []string
+}
+
+// NewRelyingParty creates a relying party from a comma-separated list of allowed origins
+func NewRelyingParty(id string, name string, origins string) *RelyingParty {
+	rp := &RelyingParty{ID: id, Name: name}
+	for _, origin := range strings.Split(origins, ",") {
+		if origin = strings.TrimRight(strings.TrimSpace(origin), "/"); origin != "" {
+			rp.Origins = append(rp.Origins, origin)
+		}
+	}
+	return rp
+}
+
+// Credential is a credential created by a registration ceremony
+type Credential struct {
+	ID             []byte
+	PublicKey      []byte
+	Algorithm      int64
+	SignCount      uint32
+	AAGUID         []byte
+	BackupEligible bool
+}
+
+// CredentialDescriptor identifies an existing credential to an authenticator
+type CredentialDescriptor struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+type credentialParameter struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+type rpEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type userEntity struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type authenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// CreationOptions are the options for navigator.credentials.create, with binary
+// values encoded as base64url
+type CreationOptions struct {
+	Challenge              string                 `json:"challenge"`
+	RP                     rpEntity               `json:"rp"`
+	User                   userEntity             `json:"user"`
+	PubKeyCredParams       []credentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                  `json:"timeout"`
+	ExcludeCredentials     []CredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection authenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                 `json:"attestation"`
+}
+
+// RequestOptions are the options for navigator.credentials.get, with binary values
+// encoded as base64url
+type RequestOptions struct {
+	Challenge        string                 `json:"challenge"`
+	Timeout          int64                  `json:"timeout"`
+	RPID             string                 `json:"rpId"`
+	AllowCredentials []CredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                 `json:"userVerification"`
+}
+
+// GenerateChallenge creates a random challenge encoded as base64url
+func GenerateChallenge() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeBase64URL decodes base64url with or without padding, as browsers and client
+// libraries differ
+func DecodeBase64URL(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+// EncodeBase64URL encodes b as unpadded base64url
+func EncodeBase64URL(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// UserHandle is the opaque user handle stored with a credential: the user ID in decimal
+func UserHandle(userID int32) []byte {
+	return []byte(strconv.Itoa(int(userID)))
+}
+
+// CreationOptions builds the options for registering a new credential for the user,
+// excluding the credentials they already have
+func (rp *RelyingParty) CreationOptions(challenge string, userID int32, name string, displayName string, existing []CredentialDescriptor) *CreationOptions {
+	params := make([]credentialParameter, 0, len(SupportedAlgorithms))
+	for _, alg := range SupportedAlgorithms {
+		params = append(params, credentialParameter{Type: "public-key", Alg: alg})
+	}
+	if existing == nil {
+		existing = []CredentialDescriptor{}
+	}
+
+	return &CreationOptions{
+		Challenge:          challenge,
+		RP:                 rpEntity{ID: rp.ID, Name: rp.Name},
+		User:               userEntity{ID: EncodeBase64URL(UserHandle(userID)), Name: name, DisplayName: displayName},
+		PubKeyCredParams:   params,
+		Timeout:            ChallengeTTL.Milliseconds(),
+		ExcludeCredentials: existing,
+		AuthenticatorSelection: authenticatorSelection{
+			ResidentKey:      "preferred",
+			UserVerification: "required",
+		},
+		Attestation: "none",
+	}
+}
+
+// RequestOptions builds the options for a login. No credentials are listed, so the
+// authenticator offers the discoverable credentials it holds for this relying party.
+func (rp *RelyingParty) RequestOptions(challenge string) *RequestOptions {
+	return &RequestOptions{
+		Challenge:        challenge,
+		Timeout:          ChallengeTTL.Milliseconds(),
+		RPID:             rp.ID,
+		AllowCredentials: []CredentialDescriptor{},
+		UserVerification: "required",
+	}
+}
+
+type clientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin"`
+}
+
+// ChallengeOf reads the challenge from a client data JSON, so that the stored
+// challenge can be looked up before the response is verified
+func ChallengeOf(clientDataJSON []byte) (string, error) {
+	var cd clientData
+	if err := json.Unmarshal(clientDataJSON, &cd); err != nil {
+		return "", fmt.Errorf("invalid client data")
+	}
+	if cd.Challenge == "" {
+		return "", fmt.Errorf("client data has no challenge")
+	}
+	return cd.Challenge, nil
+}
+
+// verifyClientData checks that the client data belongs to the ceremony, the challenge
+// and one of the allowed origins
+func (rp *RelyingParty) verifyClientData(clientDataJSON []byte, ceremonyType string, challenge string) error {
+	var cd clientData
+	if err := json.Unmarshal(clientDataJSON, &cd); err != nil {
+		return fmt.Errorf("invalid client data")
+	}
+	if cd.Type != ceremonyType {
+		return fmt.Errorf("unexpected client data type %q", cd.Type)
+	}
+	if cd.Challenge != challenge {
+		return fmt.Errorf("challenge does not match")
+	}
+	if cd.CrossOrigin {
+		return fmt.Errorf("cross-origin requests are not allowed")
+	}
+	for _, origin := range rp.Origins {
+		if cd.Origin == origin {
+			return nil
+		}
+	}
+	return fmt.Errorf("origin %q is not allowed", cd.Origin)
+}
+
+// authenticatorData is the parsed authenticator data of a response
+type authenticatorData struct {
+	RPIDHash     []byte
+	Flags        byte
+	SignCount    uint32
+	AAGUID       []byte
+	CredentialID []byte
+	PublicKey    []byte
+}
+
+// parseAuthenticatorData parses the binary authenticator data, including the attested
+// credential data when present
+func parseAuthenticatorData(data []byte) (*authenticatorData, error) {
+	if len(data) < 37 {
+		return nil, fmt.Errorf("authenticator data is too short")
+	}
+
+	ad := &authenticatorData{
+		RPIDHash:  data[:32],
+		Flags:     data[32],
+		SignCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+	rest := data[37:]
+
+	if ad.Flags&FlagAttestedData != 0 {
+		if len(rest) < 18 {
+			return nil, fmt.Errorf("attested credential data is too short")
+		}
+		ad.AAGUID = rest[:16]
+		idLen := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if idLen == 0 || idLen > 1023 || len(rest) < idLen {
+			return nil, fmt.Errorf("invalid credential ID length")
+		}
+		ad.CredentialID = rest[:idLen]
+		rest = rest[idLen:]
+
+		_, n, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid credential public key: %v", err)
+		}
+		ad.PublicKey = rest[:n]
+		rest = rest[n:]
+	}
+
+	if ad.Flags&FlagExtensionData != 0 {
+		value, n, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid extension data: %v", err)
+		}
+		if _, ok := value.(map[interface{}]interface{}); !ok {
+			return nil, fmt.Errorf("extension data is not a map")
+		}
+		rest = rest[n:]
+	}
+
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("trailing data after authenticator data")
+	}
+
+	return ad, nil
+}
+
+// verifyAuthenticatorData checks the relying party and the flags every ceremony requires
+func (rp *RelyingParty) verifyAuthenticatorData(ad *authenticatorData) error {
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if !bytes.Equal(ad.RPIDHash, rpIDHash[:]) {
+		return fmt.Errorf("relying party does not match")
+	}
+	if ad.Flags&FlagUserPresent == 0 {
+		return fmt.Errorf("user was not present")
+	}
+	if ad.Flags&FlagUserVerified == 0 {
+		return fmt.Errorf("user was not verified")
+	}
+	if ad.Flags&FlagBackedUp != 0 && ad.Flags&FlagBackupEligible == 0 {
+		return fmt.Errorf("invalid backup flags")
+	}
+	return nil
+}
+
+// VerifyRegistration verifies the response to a registration ceremony and returns the
+// new credential. Only "none" attestation is requested, so the attestation statement
+// is not checked.
+func (rp *RelyingParty) VerifyRegistration(challenge string, clientDataJSON []byte, attestationObject []byte) (*Credential, error) {
+	if err := rp.verifyClientData(clientDataJSON, "webauthn.create", challenge); err != nil {
+		return nil, err
+	}
+
+	value, n, err := decodeCBOR(attestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attestation object: %v", err)
+	}
+	if n != len(attestationObject) {
+		return nil, fmt.Errorf("trailing data after attestation object")
+	}
+	attestation, ok := value.(map[interface{}]interface{})
+	if !ok {
+		return nil, fmt.Errorf("attestation object is not a map")
+	}
+	if _, ok := attestation["fmt"].(string); !ok {
+		return nil, fmt.Errorf("attestation object has no format")
+	}
+	rawAuthData, ok := attestation["authData"].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("attestation object has no authenticator data")
+	}
+
+	ad, err := parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if err := rp.verifyAuthenticatorData(ad); err != nil {
+		return nil, err
+	}
+	if ad.Flags&FlagAttestedData == 0 {
+		return nil, fmt.Errorf("no credential was attested")
+	}
+
+	key, err := ParsePublicKey(ad.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Credential{
+		ID:             ad.CredentialID,
+		PublicKey:      ad.PublicKey,
+		Algorithm:      key.Algorithm,
+		SignCount:      ad.SignCount,
+		AAGUID:         ad.AAGUID,
+		BackupEligible: ad.Flags&FlagBackupEligible != 0,
+	}, nil
+}
+
+// VerifyAssertion verifies the response to a login ceremony against the stored public
+// key and sign count, and returns the new sign count. A counter that does not increase
+// means the credential may have been cloned, unless the authenticator keeps no counter.
+func (rp *RelyingParty) VerifyAssertion(challenge string, publicKey []byte, signCount uint32, clientDataJSON []byte, authenticatorDataRaw []byte, signature []byte) (uint32, error) {
+	if err := rp.verifyClientData(clientDataJSON, "webauthn.get", challenge); err != nil {
+		return 0, err
+	}
+
+	ad, err := parseAuthenticatorData(authenticatorDataRaw)
+	if err != nil {
+		return 0, err
+	}
+	if err := rp.verifyAuthenticatorData(ad); err != nil {
+		return 0, err
+	}
+
+	key, err := ParsePublicKey(publicKey)
+	if err != nil {
+		return 0, err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte{}, authenticatorDataRaw...), clientDataHash[:]...)
+	if err := key.Verify(signed, signature); err != nil {
+		return 0, err
+	}
+
+	if (ad.SignCount != 0 || signCount != 0) && ad.SignCount <= signCount {
+		return 0, fmt.Errorf("sign count did not increase")
+	}
+
+	return ad.SignCount, nil
+}
+
+// IsValidTransport checks whether the transport is one the relying party stores
+func IsValidTransport(transport string) bool {
+	for _, t := range Transports {
+		if t == transport {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,250 @@
+package webauthn
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+	"testing"
+)
+
+const testOrigin = "https://app.example.com"
+
+// encodeCBOR is a small encoder for the values the tests need. Map keys are sorted by
+// their encoding, as authenticators do.
+func encodeCBOR(v interface{}) []byte {
+	head := func(major byte, n uint64) []byte {
+		switch {
+		case n < 24:
+			return []byte{major<<5 | byte(n)}
+		case n < 1<<8:
+			return []byte{major<<5 | 24, byte(n)}
+		case n < 1<<16:
+			b := []byte{major<<5 | 25, 0, 0}
+			binary.BigEndian.PutUint16(b[1:], uint16(n))
+			return b
+		default:
+			b := []byte{major<<5 | 26, 0, 0, 0, 0}
+			binary.BigEndian.PutUint32(b[1:], uint32(n))
+			return b
+		}
+	}
+
+	switch v := v.(type) {
+	case int:
+		if v < 0 {
+			return head(1, uint64(-1-v))
+		}
+		return head(0, uint64(v))
+	case int64:
+		return encodeCBOR(int(v))
+	case []byte:
+		return append(head(2, uint64(len(v))), v...)
+	case string:
+		return append(head(3, uint64(len(v))), v...)
+	case map[interface{}]interface{}:
+		entries := make([][2][]byte, 0, len(v))
+		for key, value := range v {
+			entries = append(entries, [2][]byte{encodeCBOR(key), encodeCBOR(value)})
+		}
+		sort.Slice(entries, func(i, j int) bool { return bytes.Compare(entries[i][0], entries[j][0]) < 0 })
+		out := head(5, uint64(len(v)))
+		for _, entry := range entries {
+			out = append(append(out, entry[0]...), entry[1]...)
+		}
+		return out
+	default:
+		panic("unsupported type")
+	}
+}
+
+type authenticator struct {
+	key       *ecdsa.PrivateKey
+	id        []byte
+	signCount uint32
+}
+
+func newAuthenticator(t *testing.T) *authenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &authenticator{key: key, id: []byte("credential-1")}
+}
+
+func (a *authenticator) coseKey() []byte {
+	return encodeCBOR(map[interface{}]interface{}{
+		1:  2,
+		3:  -7,
+		-1: 1,
+		-2: a.key.X.FillBytes(make([]byte, 32)),
+		-3: a.key.Y.FillBytes(make([]byte, 32)),
+	})
+}
+
+func (a *authenticator) authData(rpID string, flags byte, attested bool) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	data := append([]byte{}, rpIDHash[:]...)
+	data = append(data, flags, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(data[33:], a.signCount)
+
+	if attested {
+		data = append(data, make([]byte, 16)...)
+		data = append(data, byte(len(a.id)>>8), byte(len(a.id)))
+		data = append(data, a.id...)
+		data = append(data, a.coseKey()...)
+	}
+	return data
+}
+
+func clientDataJSON(t *testing.T, ceremonyType string, challenge string, origin string) []byte {
+	data, err := json.Marshal(clientData{Type: ceremonyType, Challenge: challenge, Origin: origin})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func (a *authenticator) register(t *testing.T, rpID string, challenge string, origin string) ([]byte, []byte) {
+	authData := a.authData(rpID, FlagUserPresent|FlagUserVerified|FlagAttestedData, true)
+	attestation := encodeCBOR(map[interface{}]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[interface{}]interface{}{},
+		"authData": authData,
+	})
+	return clientDataJSON(t, "webauthn.create", challenge, origin), attestation
+}
+
+func (a *authenticator) assert(t *testing.T, rpID string, challenge string, flags byte) ([]byte, []byte, []byte) {
+	a.signCount++
+	authData := a.authData(rpID, flags, false)
+	cd := clientDataJSON(t, "webauthn.get", challenge, testOrigin)
+
+	clientDataHash := sha256.Sum256(cd)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cd, authData, signature
+}
+
+func TestDecodeCBOR(t *testing.T) {
+	value, n, err := decodeCBOR([]byte{0xa2, 0x01, 0x02, 0x20, 0x43, 0x01, 0x02, 0x03, 0xff})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 8 {
+		t.Errorf("expected 8 bytes used, got %d", n)
+	}
+	m := value.(map[interface{}]interface{})
+	if m[int64(1)] != int64(2) || !bytes.Equal(m[int64(-1)].([]byte), []byte{1, 2, 3}) {
+		t.Errorf("unexpected value %v", m)
+	}
+
+	invalid := map[string][]byte{
+		"truncated string":  {0x43, 0x01},
+		"indefinite length": {0x5f},
+		"duplicate key":     {0xa2, 0x01, 0x01, 0x01, 0x02},
+		"huge array":        {0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		"tag":               {0xc0, 0x01},
+	}
+	for name, data := range invalid {
+		if _, _, err := decodeCBOR(data); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	deep := bytes.Repeat([]byte{0x81}, maxCBORDepth+2)
+	if _, _, err := decodeCBOR(append(deep, 0x00)); err == nil {
+		t.Error("expected deeply nested data to be rejected")
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ParsePublicKey(encodeCBOR(map[interface{}]interface{}{1: 1, 3: -8, -1: 6, -2: []byte(pub)}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := key.Verify([]byte("data"), ed25519.Sign(priv, []byte("data"))); err != nil {
+		t.Errorf("expected a valid signature: %v", err)
+	}
+	if err := key.Verify([]byte("other"), ed25519.Sign(priv, []byte("data"))); err == nil {
+		t.Error("expected an invalid signature")
+	}
+
+	offCurve := encodeCBOR(map[interface{}]interface{}{1: 2, 3: -7, -1: 1, -2: make([]byte, 32), -3: make([]byte, 32)})
+	if _, err := ParsePublicKey(offCurve); err == nil {
+		t.Error("expected a point that is not on the curve to be rejected")
+	}
+
+	if _, err := ParsePublicKey(encodeCBOR(map[interface{}]interface{}{1: 2, 3: -36})); err == nil {
+		t.Error("expected an unsupported algorithm to be rejected")
+	}
+}
+
+func TestCeremonies(t *testing.T) {
+	rp := NewRelyingParty("example.com", "Example", testOrigin+"/, http://localhost:3000")
+	a := newAuthenticator(t)
+
+	cd, attestation := a.register(t, "example.com", "reg-challenge", testOrigin)
+	credential, err := rp.VerifyRegistration("reg-challenge", cd, attestation)
+	if err != nil {
+		t.Fatalf("unexpected registration error: %v", err)
+	}
+	if !bytes.Equal(credential.ID, a.id) || credential.Algorithm != AlgES256 {
+		t.Errorf("unexpected credential %+v", credential)
+	}
+
+	challenge, err := ChallengeOf(cd)
+	if err != nil || challenge != "reg-challenge" {
+		t.Errorf("expected the challenge to be read, got %q, %v", challenge, err)
+	}
+
+	registrations := map[string]func() ([]byte, []byte){
+		"wrong challenge": func() ([]byte, []byte) { return a.register(t, "example.com", "other", testOrigin) },
+		"wrong origin":    func() ([]byte, []byte) { return a.register(t, "example.com", "reg-challenge", "https://evil.com") },
+		"wrong rp":        func() ([]byte, []byte) { return a.register(t, "evil.com", "reg-challenge", testOrigin) },
+	}
+	for name, register := range registrations {
+		cd, attestation := register()
+		if _, err := rp.VerifyRegistration("reg-challenge", cd, attestation); err == nil {
+			t.Errorf("%s: expected registration to fail", name)
+		}
+	}
+
+	signCount := credential.SignCount
+	cd, authData, signature := a.assert(t, "example.com", "login-challenge", FlagUserPresent|FlagUserVerified)
+	signCount, err = rp.VerifyAssertion("login-challenge", credential.PublicKey, signCount, cd, authData, signature)
+	if err != nil {
+		t.Fatalf("unexpected assertion error: %v", err)
+	}
+	if signCount != 1 {
+		t.Errorf("expected sign count 1, got %d", signCount)
+	}
+
+	// Replaying the same response must fail the counter check
+	if _, err := rp.VerifyAssertion("login-challenge", credential.PublicKey, signCount, cd, authData, signature); err == nil {
+		t.Error("expected a replayed sign count to be rejected")
+	}
+
+	cd, authData, signature = a.assert(t, "example.com", "login-challenge", FlagUserPresent)
+	if _, err := rp.VerifyAssertion("login-challenge", credential.PublicKey, signCount, cd, authData, signature); err == nil {
+		t.Error("expected an unverified user to be rejected")
+	}
+
+	cd, authData, signature = a.assert(t, "example.com", "login-challenge", FlagUserPresent|FlagUserVerified)
+	signature[len(signature)-1] ^= 0xff
+	if _, err := rp.VerifyAssertion("login-challenge", credential.PublicKey, signCount, cd, authData, signature); err == nil {
+		t.Error("expected a tampered signature to be rejected")
+	}
+}
@@ -0,0 +1,113 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// Default activity targets, following the WHO recommendation of 150 minutes of
+// moderate activity a week
+const (
+	DefaultWeeklyActiveMinutes = 150
+	DefaultDailySteps          = 8000
+)
+
+// Activity glucose statuses
+const (
+	ActivityGlucoseOK               = "ok"
+	ActivityGlucoseInsufficientData = "insufficient_data"
+)
+
+type Activity struct {
+	ID              int32     `json:"id"`
+	UserID          int32     `json:"user_id"`
+	ActivityType    string    `json:"activity_type"`
+	Intensity       string    `json:"intensity"`
+	StartedAt       time.Time `json:"started_at"`
+	DurationMinutes int       `json:"duration_minutes"`
+	Steps           *int      `json:"steps"`
+	Calories        *float64  `json:"calories"`
+	Notes           string    `json:"notes"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// EndedAt is when the activity finished
+func (a *Activity) EndedAt() time.Time {
+	return a.StartedAt.Add(time.Duration(a.DurationMinutes) * time.Minute)
+}
+
+type ActivityTarget struct {
+	UserID              int32 `json:"user_id"`
+	WeeklyActiveMinutes int   `json:"weekly_active_minutes"`
+	DailySteps          int   `json:"daily_steps"`
+}
+
+// ActivityWeek holds the activity totals for a week starting on Monday in the user's
+// time zone, and the progress against their target. Active minutes count vigorous
+// minutes twice and leave out light activity.
+type ActivityWeek struct {
+	// First local day of the week, formatted as YYYY-MM-DD
+	Start                string  `json:"start"`
+	Activities           int     `json:"activities"`
+	DurationMinutes      int     `json:"duration_minutes"`
+	ActiveMinutes        int     `json:"active_minutes"`
+	Steps                int     `json:"steps"`
+	AverageDailySteps    int     `json:"average_daily_steps"`
+	Calories             float64 `json:"calories"`
+	ActiveMinutesPercent float64 `json:"active_minutes_percent"`
+	StepsPercent         float64 `json:"steps_percent"`
+	TargetMet            bool    `json:"target_met"`
+}
+
+// ActivityGlucose describes how glucose changed during and after an activity. Glucose
+// values are in Unit.
+type ActivityGlucose struct {
+	ActivityID      int32     `json:"activity_id"`
+	ActivityType    string    `json:"activity_type"`
+	Intensity       string    `json:"intensity"`
+	StartedAt       time.Time `json:"started_at"`
+	DurationMinutes int       `json:"duration_minutes"`
+	Unit            string    `json:"unit"`
+	Status          string    `json:"status"`
+
+	Before float64 `json:"before"`
+	After  float64 `json:"after"`
+	Change float64 `json:"change"`
+	// Lowest reading from the end of the activity through the recovery window
+	RecoveryLow float64 `json:"recovery_low"`
+}
+
+// ActivityTypeGlucose summarises the glucose change over every analysed activity of a type
+type ActivityTypeGlucose struct {
+	ActivityType   string  `json:"activity_type"`
+	Activities     int     `json:"activities"`
+	Unit           string  `json:"unit"`
+	AverageChange  float64 `json:"average_change"`
+	LowestRecovery float64 `json:"lowest_recovery"`
+}
+
+type ActivityStore interface {
+	GetActivityByID(id int32, userID int32) (*Activity, error)
+	GetActivitiesByTimeRange(userID int32, start time.Time, end time.Time) ([]*Activity, error)
+	CreateActivity(ctx context.Context, activity *Activity) (int32, error)
+	UpdateActivity(ctx context.Context, activity *Activity) (bool, error)
+	DeleteActivity(ctx context.Context, id int32, userID int32) (bool, error)
+	GetActivityTarget(userID int32) (*ActivityTarget, error)
+	UpdateActivityTarget(ctx context.Context, target *ActivityTarget) error
+}
+
+type ActivityPayload struct {
+	ActivityType    string    `json:"activity_type" validate:"omitempty,oneof=walking running cycling swimming strength yoga hiit sports other"`
+	Intensity       string    `json:"intensity" validate:"omitempty,oneof=light moderate vigorous"`
+	StartedAt       time.Time `json:"started_at" validate:"required"`
+	DurationMinutes int       `json:"duration_minutes" validate:"required,gt=0,lte=1440"`
+	Steps           *int      `json:"steps" validate:"omitempty,gte=0,lte=100000"`
+	Calories        *float64  `json:"calories" validate:"omitempty,gte=0,lte=10000"`
+	Notes           string    `json:"notes" validate:"max=500"`
+}
+
+type UpdateActivityTargetPayload struct {
+	WeeklyActiveMinutes int `json:"weekly_active_minutes" validate:"required,gt=0,lte=3000"`
+	DailySteps          int `json:"daily_steps" validate:"required,gt=0,lte=100000"`
+}
@@ -0,0 +1,95 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// Alert rule types
+const (
+	AlertLow         = "low"
+	AlertUrgentLow   = "urgent_low"
+	AlertHigh        = "high"
+	AlertRapidFall   = "rapid_fall"
+	AlertRapidRise   = "rapid_rise"
+	AlertMissingData = "missing_data"
+)
+
+// AlertRuleTypes lists every rule type in the order they are evaluated
+var AlertRuleTypes = []string{AlertUrgentLow, AlertLow, AlertHigh, AlertRapidFall, AlertRapidRise, AlertMissingData}
+
+// AlertRule is one of the user's alert rules. Threshold is in mg/dL for level rules,
+// mg/dL per minute for rate rules and hours for missing data. A nil threshold follows
+// the user's profile or the built-in default.
+type AlertRule struct {
+	ID              int32      `json:"id"`
+	UserID          int32      `json:"user_id"`
+	RuleType        string     `json:"rule_type"`
+	IsEnabled       bool       `json:"is_enabled"`
+	Threshold       *float64   `json:"threshold"`
+	CooldownMinutes int        `json:"cooldown_minutes"`
+	Channels        []string   `json:"channels"`
+	SnoozedUntil    *time.Time `json:"snoozed_until"`
+	// Filled in for responses, where thresholds are converted to ThresholdUnit
+	EffectiveThreshold float64 `json:"effective_threshold"`
+	ThresholdUnit      string  `json:"threshold_unit"`
+}
+
+// Alert is a triggered rule in the user's alert history
+type Alert struct {
+	ID                int32      `json:"id"`
+	UserID            int32      `json:"user_id"`
+	RuleType          string     `json:"rule_type"`
+	GlucoseReadingID  *int32     `json:"glucose_reading_id"`
+	ValueMgdL         *float64   `json:"value_mgdl"`
+	RateMgdLPerMinute *float64   `json:"rate_mgdl_per_minute"`
+	Message           string     `json:"message"`
+	TriggeredAt       time.Time  `json:"triggered_at"`
+	DeliveredChannels []string   `json:"delivered_channels"`
+	DeliveryError     string     `json:"delivery_error"`
+	AcknowledgedAt    *time.Time `json:"acknowledged_at"`
+}
+
+// AlertChannel delivers alerts to a user, such as by email. Times are shown in loc.
+type AlertChannel interface {
+	Name() string
+	Send(user *User, alert *Alert, loc *time.Location) error
+}
+
+// CaregiverAlertChannel is a channel that can also forward the owner's alerts to
+// caregivers they share alerts with
+type CaregiverAlertChannel interface {
+	AlertChannel
+	SendToCaregiver(caregiver *User, owner *User, alert *Alert, loc *time.Location) error
+}
+
+// GlucoseAlerter checks newly recorded readings against the user's alert rules
+type GlucoseAlerter interface {
+	CheckReading(reading *GlucoseReading)
+}
+
+type AlertStore interface {
+	GetAlertRules(userID int32) ([]*AlertRule, error)
+	GetEnabledAlertRules(ruleType string) ([]*AlertRule, error)
+	UpsertAlertRule(ctx context.Context, rule *AlertRule) error
+	SnoozeAlertRule(ctx context.Context, userID int32, ruleType string, until *time.Time) (bool, error)
+	GetLatestAlert(userID int32, ruleType string) (*Alert, error)
+	GetAlertsByTimeRange(userID int32, start time.Time, end time.Time) ([]*Alert, error)
+	CreateAlert(ctx context.Context, alert *Alert) (int32, error)
+	UpdateAlertDelivery(ctx context.Context, id int32, channels []string, deliveryError string) error
+	AcknowledgeAlert(ctx context.Context, id int32, userID int32) (bool, error)
+	GetLatestReadingTime(userID int32) (*time.Time, error)
+}
+
+// Threshold is in the user's glucose unit for level rules and in that unit per
+// minute for rate rules, or in hours for missing data. Omit it to follow the profile.
+type UpdateAlertRulePayload struct {
+	IsEnabled       *bool    `json:"is_enabled" validate:"required"`
+	Threshold       *float64 `json:"threshold" validate:"omitempty,gt=0"`
+	CooldownMinutes *int     `json:"cooldown_minutes" validate:"omitempty,gte=5,lte=1440"`
+	Channels        []string `json:"channels" validate:"omitempty,max=5,dive,required,max=20"`
+}
+
+type SnoozeAlertRulePayload struct {
+	Minutes int `json:"minutes" validate:"required,gt=0,lte=1440"`
+}
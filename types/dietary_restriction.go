@@ -0,0 +1,50 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// DietaryRestrictionOptions is the curated vocabulary accepted for dietary restrictions
+var DietaryRestrictionOptions = []string{
+	"vegetarian",
+	"vegan",
+	"pescatarian",
+	"gluten_free",
+	"dairy_free",
+	"lactose_free",
+	"egg_free",
+	"nut_free",
+	"peanut_free",
+	"shellfish_free",
+	"soy_free",
+	"halal",
+	"kosher",
+	"low_carb",
+	"low_fat",
+	"low_sodium",
+	"low_sugar",
+	"ketogenic",
+}
+
+type DietaryRestriction struct {
+	DietaryRestrictionID int32     `json:"dietary_restriction_id"`
+	UserID               int32     `json:"user_id"`
+	RestrictionName      string    `json:"restriction_name"`
+	CreatedAt            time.Time `json:"created_at"`
+}
+
+type DietaryRestrictionStore interface {
+	GetDietaryRestrictionsByUserID(userID int32) ([]*DietaryRestriction, error)
+	AddDietaryRestrictions(ctx context.Context, userID int32, restrictions []string) error
+	ReplaceDietaryRestrictions(ctx context.Context, userID int32, restrictions []string) error
+	DeleteDietaryRestriction(ctx context.Context, dietaryRestrictionID int32, userID int32) (bool, error)
+}
+
+type CreateDietaryRestrictionsPayload struct {
+	Restrictions []string `json:"restrictions" validate:"required,min=1,max=20,dive,dietary_restriction"`
+}
+
+type UpdateDietaryRestrictionsPayload struct {
+	Restrictions []string `json:"restrictions" validate:"max=20,dive,dietary_restriction"`
+}
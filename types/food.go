@@ -0,0 +1,66 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// Food sources
+const (
+	FoodSourceUSDA   = "usda"
+	FoodSourceCustom = "custom"
+)
+
+// Nutrients are given per 100 g of the food
+type Nutrients struct {
+	EnergyKcal float64 `json:"energy_kcal"`
+	CarbsG     float64 `json:"carbs_g"`
+	ProteinG   float64 `json:"protein_g"`
+	FatG       float64 `json:"fat_g"`
+	FibreG     float64 `json:"fibre_g"`
+	SugarG     float64 `json:"sugar_g"`
+}
+
+type FoodServing struct {
+	Description string  `json:"description"`
+	Grams       float64 `json:"grams"`
+}
+
+type Food struct {
+	ID       int32  `json:"id"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Source   string `json:"source"`
+	// Owner of a custom food, nil for the shared catalogue
+	UserID    *int32        `json:"user_id"`
+	Per100g   Nutrients     `json:"per_100g"`
+	Servings  []FoodServing `json:"servings"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type FoodStore interface {
+	GetCatalogueFoods() ([]*Food, error)
+	GetCustomFoods(userID int32) ([]*Food, error)
+	GetFoodByID(id int32, userID int32) (*Food, error)
+	CreateCustomFood(ctx context.Context, food *Food) (int32, error)
+	DeleteCustomFood(ctx context.Context, id int32, userID int32) (bool, error)
+	SaveCatalogueFoods(ctx context.Context, foods []*Food) (int, int, error)
+}
+
+type FoodServingPayload struct {
+	Description string  `json:"description" validate:"required,max=100"`
+	Grams       float64 `json:"grams" validate:"required,gt=0,lte=5000"`
+}
+
+type CreateCustomFoodPayload struct {
+	Name     string `json:"name" validate:"required,max=255"`
+	Category string `json:"category" validate:"max=100"`
+	// Nutrients per 100 g
+	EnergyKcal *float64             `json:"energy_kcal" validate:"omitempty,gte=0,lte=900"`
+	CarbsG     float64              `json:"carbs_g" validate:"gte=0,lte=100"`
+	ProteinG   float64              `json:"protein_g" validate:"gte=0,lte=100"`
+	FatG       float64              `json:"fat_g" validate:"gte=0,lte=100"`
+	FibreG     float64              `json:"fibre_g" validate:"gte=0,lte=100"`
+	SugarG     float64              `json:"sugar_g" validate:"gte=0,lte=100"`
+	Servings   []FoodServingPayload `json:"servings" validate:"max=10,dive"`
+}
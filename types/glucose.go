@@ -0,0 +1,48 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// Supported glucose units
+const (
+	GlucoseUnitMgdL  = "mg/dL"
+	GlucoseUnitMmolL = "mmol/L"
+)
+
+type GlucoseReading struct {
+	ID                 int32     `json:"id"`
+	UserID             int32     `json:"user_id"`
+	Value              float64   `json:"value"`
+	Unit               string    `json:"unit"`
+	Source             string    `json:"source"`
+	MeasurementContext string    `json:"measurement_context"`
+	MeasuredAt         time.Time `json:"measured_at"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+type GlucoseStore interface {
+	GetGlucoseReadingByID(id int32, userID int32) (*GlucoseReading, error)
+	GetGlucoseReadingsByTimeRange(userID int32, start time.Time, end time.Time) ([]*GlucoseReading, error)
+	CreateGlucoseReading(ctx context.Context, reading *GlucoseReading) (int32, error)
+	UpdateGlucoseReading(ctx context.Context, reading *GlucoseReading) error
+	DeleteGlucoseReading(ctx context.Context, id int32, userID int32) error
+}
+
+type CreateGlucoseReadingPayload struct {
+	Value              float64   `json:"value" validate:"required,gt=0"`
+	Unit               string    `json:"unit" validate:"required,oneof=mg/dL mmol/L"`
+	Source             string    `json:"source" validate:"omitempty,oneof=manual meter cgm import"`
+	MeasurementContext string    `json:"measurement_context" validate:"omitempty,oneof=fasting pre_meal post_meal bedtime other"`
+	MeasuredAt         time.Time `json:"measured_at" validate:"required"`
+}
+
+type UpdateGlucoseReadingPayload struct {
+	Value              float64   `json:"value" validate:"required,gt=0"`
+	Unit               string    `json:"unit" validate:"required,oneof=mg/dL mmol/L"`
+	Source             string    `json:"source" validate:"required,oneof=manual meter cgm import"`
+	MeasurementContext string    `json:"measurement_context" validate:"required,oneof=fasting pre_meal post_meal bedtime other"`
+	MeasuredAt         time.Time `json:"measured_at" validate:"required"`
+}
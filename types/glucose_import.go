@@ -0,0 +1,48 @@
+package types
+
+import "time"
+
+// Supported CGM export formats
+const (
+	ImportFormatDexcom = "dexcom"
+	ImportFormatLibre  = "libre"
+)
+
+// Import job states
+const (
+	ImportStatusPending   = "pending"
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+)
+
+// ImportRowError describes a row that could not be imported
+type ImportRowError struct {
+	Line   int    `json:"line"`
+	Reason string `json:"reason"`
+}
+
+// ImportSummary counts what happened to each row of an uploaded file
+type ImportSummary struct {
+	Imported   int `json:"imported"`
+	Duplicates int `json:"duplicates"`
+	// Rows that are not glucose readings, such as insulin, carbs or alerts
+	Skipped int              `json:"skipped"`
+	Invalid int              `json:"invalid"`
+	Errors  []ImportRowError `json:"errors"`
+}
+
+// ImportJob is a background CGM import and its progress
+type ImportJob struct {
+	ID         string        `json:"id"`
+	UserID     int32         `json:"-"`
+	Format     string        `json:"format"`
+	FileName   string        `json:"file_name"`
+	Status     string        `json:"status"`
+	Total      int           `json:"total"`
+	Processed  int           `json:"processed"`
+	Summary    ImportSummary `json:"summary"`
+	Error      string        `json:"error,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+	FinishedAt *time.Time    `json:"finished_at"`
+}
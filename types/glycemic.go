@@ -0,0 +1,58 @@
+package types
+
+// Glycemic index and glycemic load classifications
+const (
+	GlycemicLow    = "low"
+	GlycemicMedium = "medium"
+	GlycemicHigh   = "high"
+)
+
+// GlycemicIndexFood is a reference food with its glycemic index (glucose = 100)
+// and the available carbohydrate in a typical serving
+type GlycemicIndexFood struct {
+	ID              int32   `json:"id"`
+	Name            string  `json:"name"`
+	GlycemicIndex   int     `json:"glycemic_index"`
+	Classification  string  `json:"classification"`
+	ServingSizeG    float64 `json:"serving_size_g"`
+	AvailableCarbsG float64 `json:"available_carbs_g"`
+}
+
+type GlycemicIndexStore interface {
+	GetGlycemicIndexFoods() ([]*GlycemicIndexFood, error)
+}
+
+// GlycemicLoadItem is one food of a glycemic load calculation
+type GlycemicLoadItem struct {
+	FoodID          *int32   `json:"food_id"`
+	Name            string   `json:"name"`
+	Grams           *float64 `json:"grams"`
+	GlycemicIndex   int      `json:"glycemic_index"`
+	AvailableCarbsG float64  `json:"available_carbs_g"`
+	GlycemicLoad    float64  `json:"glycemic_load"`
+	Classification  string   `json:"classification"`
+}
+
+type GlycemicLoadResult struct {
+	Items                []*GlycemicLoadItem `json:"items"`
+	TotalAvailableCarbsG float64             `json:"total_available_carbs_g"`
+	TotalGlycemicLoad    float64             `json:"total_glycemic_load"`
+	Classification       string              `json:"classification"`
+	// Carbohydrate-weighted glycemic index of the whole meal
+	MealGlycemicIndex               float64 `json:"meal_glycemic_index"`
+	MealGlycemicIndexClassification string  `json:"meal_glycemic_index_classification"`
+}
+
+// A food is either a reference food with a portion in grams, or given directly
+// by its glycemic index and available carbohydrate
+type GlycemicLoadItemPayload struct {
+	FoodID          int32    `json:"food_id" validate:"omitempty,gt=0"`
+	Grams           float64  `json:"grams" validate:"omitempty,gt=0,lte=5000"`
+	Name            string   `json:"name" validate:"max=255"`
+	GlycemicIndex   int      `json:"glycemic_index" validate:"omitempty,gt=0,lte=110"`
+	AvailableCarbsG *float64 `json:"available_carbs_g" validate:"omitempty,gte=0,lte=500"`
+}
+
+type GlycemicLoadPayload struct {
+	Items []GlycemicLoadItemPayload `json:"items" validate:"required,min=1,max=50,dive"`
+}
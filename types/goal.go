@@ -1,17 +1,28 @@
 package types
 
+import (
+	"context"
+	"time"
+)
+
 type Goal struct {
-	GoalID   int    `json:"goal_id"`
-	UserID   int    `json:"user_id"`
-	GoalName string `json:"goal_name"`
+	GoalID    int32     `json:"goal_id"`
+	UserID    int32     `json:"user_id"`
+	GoalName  string    `json:"goal_name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GoalStore interface {
+	GetGoalsByUserID(userID int32) ([]*Goal, error)
+	AddGoals(ctx context.Context, userID int32, goals []string) error
+	ReplaceGoals(ctx context.Context, userID int32, goals []string) error
+	DeleteGoal(ctx context.Context, goalID int32, userID int32) (bool, error)
 }
 
 type CreateGoalsPayload struct {
-	UserID string   `json:"user_id" validate:"required"`
-	Goals  []string `json:"goals"`
+	Goals []string `json:"goals" validate:"required,min=1,max=20,dive,required,max=50"`
 }
 
 type UpdateGoalsPayload struct {
-	UserID string   `json:"user_id" validate:"required"`
-	Goals  []string `json:"goals"`
+	Goals []string `json:"goals" validate:"max=20,dive,required,max=50"`
 }
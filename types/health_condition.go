@@ -1,17 +1,28 @@
 package types
 
+import (
+	"context"
+	"time"
+)
+
 type HealthCondition struct {
-	HealthConditionID int    `json:"health_condition_id"`
-	UserID            int    `json:"user_id"`
-	ConditionName     string `json:"condition_name"`
+	HealthConditionID int32     `json:"health_condition_id"`
+	UserID            int32     `json:"user_id"`
+	ConditionName     string    `json:"condition_name"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type HealthConditionStore interface {
+	GetHealthConditionsByUserID(userID int32) ([]*HealthCondition, error)
+	AddHealthConditions(ctx context.Context, userID int32, conditions []string) error
+	ReplaceHealthConditions(ctx context.Context, userID int32, conditions []string) error
+	DeleteHealthCondition(ctx context.Context, healthConditionID int32, userID int32) (bool, error)
 }
 
 type CreateHealthConditionPayload struct {
-	UserID     string   `json:"user_id" validate:"required"`
-	Conditions []string `json:"conditions"`
+	Conditions []string `json:"conditions" validate:"required,min=1,max=20,dive,required,max=50"`
 }
 
 type UpdateHealthConditionPayload struct {
-	UserID     string   `json:"user_id" validate:"required"`
-	Conditions []string `json:"conditions"`
+	Conditions []string `json:"conditions" validate:"max=20,dive,required,max=50"`
 }
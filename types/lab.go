@@ -0,0 +1,94 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// Lab test categories
+const (
+	LabCategoryGlycemic = "glycemic"
+	LabCategoryLipid    = "lipid"
+	LabCategoryKidney   = "kidney"
+)
+
+// Lab result flags against the reference range
+const (
+	LabFlagLow    = "low"
+	LabFlagNormal = "normal"
+	LabFlagHigh   = "high"
+)
+
+// LabTest describes a supported lab test. Results are reported in Unit and can be
+// entered in any of Units. Either end of the reference range can be open.
+type LabTest struct {
+	Code          string   `json:"code"`
+	Name          string   `json:"name"`
+	Category      string   `json:"category"`
+	Unit          string   `json:"unit"`
+	Units         []string `json:"units"`
+	ReferenceLow  *float64 `json:"reference_low"`
+	ReferenceHigh *float64 `json:"reference_high"`
+}
+
+// LabResult is a lab value as it was reported, in the unit it was entered in. The
+// reference range is the one printed on the report, or the test's default range.
+type LabResult struct {
+	ID            int32     `json:"id"`
+	UserID        int32     `json:"user_id"`
+	TestCode      string    `json:"test_code"`
+	TestName      string    `json:"test_name"`
+	Value         float64   `json:"value"`
+	Unit          string    `json:"unit"`
+	ReferenceLow  *float64  `json:"reference_low"`
+	ReferenceHigh *float64  `json:"reference_high"`
+	Flag          string    `json:"flag"`
+	CollectedAt   time.Time `json:"collected_at"`
+	LabName       string    `json:"lab_name"`
+	Notes         string    `json:"notes"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// LabTrendPoint is a result converted to the trend's unit
+type LabTrendPoint struct {
+	ResultID    int32     `json:"result_id"`
+	CollectedAt time.Time `json:"collected_at"`
+	Value       float64   `json:"value"`
+	Flag        string    `json:"flag"`
+	// Nil for the first result
+	ChangeFromPrevious *float64 `json:"change_from_previous"`
+}
+
+// LabTrend follows the results of one test over time
+type LabTrend struct {
+	TestCode string           `json:"test_code"`
+	TestName string           `json:"test_name"`
+	Unit     string           `json:"unit"`
+	Points   []*LabTrendPoint `json:"points"`
+	Latest   *LabTrendPoint   `json:"latest"`
+	// Change from the first to the latest result, nil with fewer than two results
+	Change *float64 `json:"change"`
+	// Least-squares slope per year, nil with fewer than two results
+	ChangePerYear *float64 `json:"change_per_year"`
+}
+
+type LabResultStore interface {
+	GetLabResultByID(id int32, userID int32) (*LabResult, error)
+	GetLabResultsByTimeRange(userID int32, testCode string, start time.Time, end time.Time) ([]*LabResult, error)
+	CreateLabResult(ctx context.Context, result *LabResult) (int32, error)
+	UpdateLabResult(ctx context.Context, result *LabResult) (bool, error)
+	DeleteLabResult(ctx context.Context, id int32, userID int32) (bool, error)
+}
+
+type LabResultPayload struct {
+	TestCode string  `json:"test_code" validate:"required,max=50"`
+	Value    float64 `json:"value" validate:"gte=0,lte=100000"`
+	// Defaults to the test's unit
+	Unit          string    `json:"unit" validate:"max=20"`
+	ReferenceLow  *float64  `json:"reference_low" validate:"omitempty,gte=0"`
+	ReferenceHigh *float64  `json:"reference_high" validate:"omitempty,gte=0"`
+	CollectedAt   time.Time `json:"collected_at" validate:"required"`
+	LabName       string    `json:"lab_name" validate:"max=255"`
+	Notes         string    `json:"notes" validate:"max=500"`
+}
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// NutrientTotals sums the nutrients of one or more meal items
+type NutrientTotals struct {
+	CarbsG   float64 `json:"carbs_g"`
+	ProteinG float64 `json:"protein_g"`
+	FatG     float64 `json:"fat_g"`
+	FibreG   float64 `json:"fibre_g"`
+	Calories float64 `json:"calories"`
+}
+
+type MealItem struct {
+	ID          int32   `json:"id"`
+	MealID      int32   `json:"meal_id"`
+	Name        string  `json:"name"`
+	Portion     float64 `json:"portion"`
+	PortionUnit string  `json:"portion_unit"`
+	// Nutrients are for the whole portion
+	CarbsG   float64 `json:"carbs_g"`
+	ProteinG float64 `json:"protein_g"`
+	FatG     float64 `json:"fat_g"`
+	FibreG   float64 `json:"fibre_g"`
+	Calories float64 `json:"calories"`
+}
+
+type Meal struct {
+	ID        int32          `json:"id"`
+	UserID    int32          `json:"user_id"`
+	MealType  string         `json:"meal_type"`
+	EatenAt   time.Time      `json:"eaten_at"`
+	Notes     string         `json:"notes"`
+	Items     []*MealItem    `json:"items"`
+	Totals    NutrientTotals `json:"totals"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// NutrientPeriod holds the nutrient totals for a day or a week in the user's time zone
+type NutrientPeriod struct {
+	// First local day of the period, formatted as YYYY-MM-DD
+	Start  string         `json:"start"`
+	Meals  int            `json:"meals"`
+	Totals NutrientTotals `json:"totals"`
+}
+
+type MealStore interface {
+	GetMealByID(id int32, userID int32) (*Meal, error)
+	GetMealsByTimeRange(userID int32, start time.Time, end time.Time) ([]*Meal, error)
+	CreateMeal(ctx context.Context, meal *Meal) (int32, error)
+	UpdateMeal(ctx context.Context, meal *Meal) (bool, error)
+	DeleteMeal(ctx context.Context, id int32, userID int32) (bool, error)
+}
+
+type MealItemPayload struct {
+	Name        string  `json:"name" validate:"required,max=255"`
+	Portion     float64 `json:"portion" validate:"required,gt=0"`
+	PortionUnit string  `json:"portion_unit" validate:"omitempty,oneof=g ml serving piece cup tbsp tsp oz"`
+	CarbsG      float64 `json:"carbs_g" validate:"gte=0,lte=1000"`
+	ProteinG    float64 `json:"protein_g" validate:"gte=0,lte=1000"`
+	FatG        float64 `json:"fat_g" validate:"gte=0,lte=1000"`
+	FibreG      float64 `json:"fibre_g" validate:"gte=0,lte=1000"`
+	// Estimated from the macronutrients when omitted
+	Calories *float64 `json:"calories" validate:"omitempty,gte=0,lte=10000"`
+}
+
+type CreateMealPayload struct {
+	MealType string            `json:"meal_type" validate:"omitempty,oneof=breakfast lunch dinner snack other"`
+	EatenAt  time.Time         `json:"eaten_at" validate:"required"`
+	Notes    string            `json:"notes" validate:"max=1000"`
+	Items    []MealItemPayload `json:"items" validate:"required,min=1,max=50,dive"`
+}
+
+type UpdateMealPayload struct {
+	MealType string            `json:"meal_type" validate:"required,oneof=breakfast lunch dinner snack other"`
+	EatenAt  time.Time         `json:"eaten_at" validate:"required"`
+	Notes    string            `json:"notes" validate:"max=1000"`
+	Items    []MealItemPayload `json:"items" validate:"required,min=1,max=50,dive"`
+}
@@ -0,0 +1,40 @@
+package types
+
+import "time"
+
+// Meal response statuses
+const (
+	MealResponseOK               = "ok"
+	MealResponseInsufficientData = "insufficient_data"
+)
+
+// MealResponse describes how glucose changed after a meal. Glucose values are
+// in Unit and the incremental area under the curve is in Unit × minutes.
+type MealResponse struct {
+	MealID   int32     `json:"meal_id"`
+	MealType string    `json:"meal_type"`
+	EatenAt  time.Time `json:"eaten_at"`
+	Foods    []string  `json:"foods"`
+	CarbsG   float64   `json:"carbs_g"`
+	Unit     string    `json:"unit"`
+	Status   string    `json:"status"`
+	Readings int       `json:"readings"`
+
+	Baseline          float64 `json:"baseline"`
+	Peak              float64 `json:"peak"`
+	Rise              float64 `json:"rise"`
+	TimeToPeakMinutes int     `json:"time_to_peak_minutes"`
+	IncrementalAUC    float64 `json:"incremental_auc"`
+	// Nil when glucose did not come back down to baseline within the follow-up window
+	ReturnToBaselineMinutes *int `json:"return_to_baseline_minutes"`
+}
+
+// FoodResponse summarises the responses to every analysed meal containing a food
+type FoodResponse struct {
+	Name                  string  `json:"name"`
+	Meals                 int     `json:"meals"`
+	Unit                  string  `json:"unit"`
+	AverageRise           float64 `json:"average_rise"`
+	AverageIncrementalAUC float64 `json:"average_incremental_auc"`
+	MaxPeak               float64 `json:"max_peak"`
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// Medication is an entry of the medication catalogue
+type Medication struct {
+	ID         int32  `json:"id"`
+	Name       string `json:"name"`
+	BrandNames string `json:"brand_names"`
+	Class      string `json:"class"`
+	DoseUnit   string `json:"dose_unit"`
+	Route      string `json:"route"`
+}
+
+// UserMedication is a medication on the user's list, with their usual dose and schedule
+type UserMedication struct {
+	ID           int32      `json:"id"`
+	UserID       int32      `json:"user_id"`
+	MedicationID int32      `json:"medication_id"`
+	Name         string     `json:"name"`
+	Class        string     `json:"class"`
+	DoseUnit     string     `json:"dose_unit"`
+	Route        string     `json:"route"`
+	Dose         *float64   `json:"dose"`
+	Schedule     string     `json:"schedule"`
+	StartedOn    *time.Time `json:"started_on"`
+	IsActive     bool       `json:"is_active"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// MedicationDose is a single logged dose, in the dose unit of the medication
+type MedicationDose struct {
+	ID           int32     `json:"id"`
+	UserID       int32     `json:"user_id"`
+	MedicationID int32     `json:"medication_id"`
+	Name         string    `json:"name"`
+	Class        string    `json:"class"`
+	Unit         string    `json:"unit"`
+	Amount       float64   `json:"amount"`
+	TakenAt      time.Time `json:"taken_at"`
+	Notes        string    `json:"notes"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// MedicationDoseTotal sums the doses of one medication over a time range
+type MedicationDoseTotal struct {
+	MedicationID int32   `json:"medication_id"`
+	Name         string  `json:"name"`
+	Unit         string  `json:"unit"`
+	Doses        int     `json:"doses"`
+	Amount       float64 `json:"amount"`
+}
+
+type MedicationStore interface {
+	GetMedications() ([]*Medication, error)
+	GetMedicationByID(id int32) (*Medication, error)
+	GetUserMedications(userID int32) ([]*UserMedication, error)
+	GetUserMedicationByID(id int32, userID int32) (*UserMedication, error)
+	CreateUserMedication(ctx context.Context, medication *UserMedication) (int32, error)
+	UpdateUserMedication(ctx context.Context, medication *UserMedication) (bool, error)
+	DeleteUserMedication(ctx context.Context, id int32, userID int32) (bool, error)
+	GetDoseByID(id int32, userID int32) (*MedicationDose, error)
+	GetDosesByTimeRange(userID int32, start time.Time, end time.Time) ([]*MedicationDose, error)
+	CreateDose(ctx context.Context, dose *MedicationDose) (int32, error)
+	DeleteDose(ctx context.Context, id int32, userID int32) (bool, error)
+}
+
+type CreateUserMedicationPayload struct {
+	MedicationID int32    `json:"medication_id" validate:"required,gt=0"`
+	Dose         *float64 `json:"dose" validate:"omitempty,gt=0"`
+	Schedule     string   `json:"schedule" validate:"max=100"`
+	StartedOn    string   `json:"started_on" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type UpdateUserMedicationPayload struct {
+	Dose      *float64 `json:"dose" validate:"omitempty,gt=0"`
+	Schedule  string   `json:"schedule" validate:"max=100"`
+	StartedOn string   `json:"started_on" validate:"omitempty,datetime=2006-01-02"`
+	IsActive  *bool    `json:"is_active" validate:"required"`
+}
+
+type CreateMedicationDosePayload struct {
+	MedicationID int32     `json:"medication_id" validate:"required,gt=0"`
+	Amount       float64   `json:"amount" validate:"required,gt=0"`
+	TakenAt      time.Time `json:"taken_at" validate:"required"`
+	Notes        string    `json:"notes" validate:"max=500"`
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// UserMFA is the user's TOTP enrollment. The secret is kept while enrollment is
+// unconfirmed, but only an enabled enrollment is required at login. Failed login
+// codes are counted until the second step is locked.
+type UserMFA struct {
+	UserID         int32      `json:"user_id"`
+	Secret         string     `json:"-"`
+	IsEnabled      bool       `json:"is_enabled"`
+	LastUsedStep   int64      `json:"-"`
+	FailedAttempts int        `json:"-"`
+	LockedUntil    *time.Time `json:"-"`
+	EnabledAt      *time.Time `json:"enabled_at"`
+}
+
+type MFAStore interface {
+	GetUserMFA(userID int32) (*UserMFA, error)
+	SaveTOTPSecret(ctx context.Context, userID int32, secret string) error
+	EnableMFA(ctx context.Context, userID int32) error
+	DisableMFA(ctx context.Context, userID int32) error
+	UseTOTPStep(ctx context.Context, userID int32, step int64) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, userID int32, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userID int32, codeHash string) (bool, error)
+	RecordFailedAttempt(ctx context.Context, userID int32) (int, error)
+	Lock(ctx context.Context, userID int32, until time.Time) error
+	ResetFailedAttempts(ctx context.Context, userID int32) error
+	CountRecoveryCodes(userID int32) (int, error)
+}
+
+type MFACodePayload struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type MFALoginPayload struct {
+	MFAToken     string `json:"mfa_token" validate:"required"`
+	Code         string `json:"code" validate:"required_without=RecoveryCode,omitempty,len=6,numeric"`
+	RecoveryCode string `json:"recovery_code" validate:"omitempty,max=20"`
+}
+
+type MFASetupPayload struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+}
+
+type MFASetupConfirmPayload struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	Code     string `json:"code" validate:"required,len=6,numeric"`
+}
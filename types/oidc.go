@@ -0,0 +1,41 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// UserIdentity links a user to their account at an OpenID Connect provider
+type UserIdentity struct {
+	ID          int32      `json:"id"`
+	UserID      int32      `json:"user_id"`
+	Provider    string     `json:"provider"`
+	Subject     string     `json:"-"`
+	Email       string     `json:"email"`
+	LastLoginAt *time.Time `json:"last_login_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// OIDCLoginState is a login in progress at a provider. Only the hash of the state
+// parameter is stored.
+type OIDCLoginState struct {
+	StateHash    string
+	Provider     string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+}
+
+type OIDCStore interface {
+	GetIdentity(provider string, subject string) (*UserIdentity, error)
+	GetIdentitiesByUserID(userID int32) ([]*UserIdentity, error)
+	CreateIdentity(ctx context.Context, identity *UserIdentity) error
+	UpdateIdentityLogin(ctx context.Context, id int32, email string) error
+	CreateLoginState(ctx context.Context, state *OIDCLoginState) error
+	ConsumeLoginState(ctx context.Context, stateHash string) (*OIDCLoginState, error)
+}
+
+type OIDCCallbackPayload struct {
+	Code  string `json:"code" validate:"required,max=2048"`
+	State string `json:"state" validate:"required,max=128"`
+}
@@ -1 +1,78 @@
 package types
+
+import (
+	"context"
+	"time"
+)
+
+// Default per-user settings used until the user completes onboarding
+const (
+	DefaultTargetLowMgdL  = 70.0
+	DefaultTargetHighMgdL = 180.0
+	DefaultVeryLowMgdL    = 54.0
+	DefaultVeryHighMgdL   = 250.0
+	DefaultTimezone       = "UTC"
+)
+
+type Profile struct {
+	UserID        int32      `json:"user_id"`
+	DateOfBirth   *time.Time `json:"date_of_birth"`
+	Sex           string     `json:"sex"`
+	HeightCm      *float64   `json:"height_cm"`
+	DiabetesType  string     `json:"diabetes_type"`
+	DiagnosisDate *time.Time `json:"diagnosis_date"`
+	GlucoseUnit   string     `json:"glucose_unit"`
+	// Target range and thresholds are always stored in mg/dL
+	TargetLowMgdL  float64      `json:"target_low_mgdl"`
+	TargetHighMgdL float64      `json:"target_high_mgdl"`
+	VeryLowMgdL    float64      `json:"very_low_mgdl"`
+	VeryHighMgdL   float64      `json:"very_high_mgdl"`
+	Timezone       string       `json:"timezone"`
+	LatestWeight   *WeightEntry `json:"latest_weight"`
+	IsComplete     bool         `json:"is_complete"`
+	UpdatedAt      *time.Time   `json:"updated_at"`
+}
+
+// Location returns the user's time zone, falling back to UTC
+func (p *Profile) Location() *time.Location {
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+type WeightEntry struct {
+	ID         int32     `json:"id"`
+	UserID     int32     `json:"user_id"`
+	WeightKg   float64   `json:"weight_kg"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+type ProfileStore interface {
+	GetProfileByUserID(userID int32) (*Profile, error)
+	UpsertProfile(ctx context.Context, profile *Profile) error
+	GetWeightEntries(userID int32, limit int32) ([]*WeightEntry, error)
+	CreateWeightEntry(ctx context.Context, entry *WeightEntry) (int32, error)
+	DeleteWeightEntry(ctx context.Context, id int32, userID int32) (bool, error)
+}
+
+type UpdateProfilePayload struct {
+	DateOfBirth   string   `json:"date_of_birth" validate:"omitempty,datetime=2006-01-02"`
+	Sex           string   `json:"sex" validate:"omitempty,oneof=female male other prefer_not_to_say"`
+	HeightCm      *float64 `json:"height_cm" validate:"omitempty,gt=30,lt=300"`
+	DiabetesType  string   `json:"diabetes_type" validate:"omitempty,oneof=type_1 type_2 gestational prediabetes lada mody other none"`
+	DiagnosisDate string   `json:"diagnosis_date" validate:"omitempty,datetime=2006-01-02"`
+	GlucoseUnit   string   `json:"glucose_unit" validate:"required,oneof=mg/dL mmol/L"`
+	// Target range and thresholds are given in GlucoseUnit
+	TargetLow  float64 `json:"target_low" validate:"required,gt=0"`
+	TargetHigh float64 `json:"target_high" validate:"required,gtfield=TargetLow"`
+	VeryLow    float64 `json:"very_low" validate:"omitempty,gt=0,ltfield=TargetLow"`
+	VeryHigh   float64 `json:"very_high" validate:"omitempty,gtfield=TargetHigh"`
+	Timezone   string  `json:"timezone" validate:"required,timezone"`
+}
+
+type CreateWeightEntryPayload struct {
+	WeightKg   float64   `json:"weight_kg" validate:"required,gt=1,lt=700"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
@@ -0,0 +1,105 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// Share access levels. Read access lets the caregiver read the shared data types;
+// alert-only access forwards the user's glucose alerts without any data access.
+const (
+	ShareAccessRead   = "read"
+	ShareAccessAlerts = "alerts"
+)
+
+// Share statuses
+const (
+	ShareStatusPending  = "pending"
+	ShareStatusAccepted = "accepted"
+	ShareStatusRevoked  = "revoked"
+)
+
+// Data types a share can grant access to
+const (
+	ShareScopeGlucose     = "glucose"
+	ShareScopeMeals       = "meals"
+	ShareScopeMedications = "medications"
+	ShareScopeActivity    = "activity"
+	ShareScopeLabs        = "labs"
+	ShareScopeHealth      = "health"
+	ShareScopeProfile     = "profile"
+	ShareScopeAlerts      = "alerts"
+)
+
+// ShareScopes lists every data type that can be shared
+var ShareScopes = []string{
+	ShareScopeGlucose,
+	ShareScopeMeals,
+	ShareScopeMedications,
+	ShareScopeActivity,
+	ShareScopeLabs,
+	ShareScopeHealth,
+	ShareScopeProfile,
+	ShareScopeAlerts,
+}
+
+// Share gives a caregiver read-only access to some of the owner's data. Until the
+// invitation is accepted only the invitee's email is known.
+type Share struct {
+	ID                int32      `json:"id"`
+	OwnerID           int32      `json:"owner_id"`
+	OwnerUsername     string     `json:"owner_username"`
+	OwnerEmail        string     `json:"owner_email"`
+	CaregiverID       *int32     `json:"caregiver_id"`
+	CaregiverUsername *string    `json:"caregiver_username"`
+	InviteeEmail      string     `json:"invitee_email"`
+	Access            string     `json:"access"`
+	Scopes            []string   `json:"scopes"`
+	Status            string     `json:"status"`
+	ExpiresAt         time.Time  `json:"expires_at"`
+	AcceptedAt        *time.Time `json:"accepted_at"`
+	RevokedAt         *time.Time `json:"revoked_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// HasScope reports whether the share grants access to the data type
+func (s *Share) HasScope(scope string) bool {
+	for _, granted := range s.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// ReceivesAlerts reports whether the caregiver is sent the owner's glucose alerts
+func (s *Share) ReceivesAlerts() bool {
+	return s.Status == ShareStatusAccepted && s.HasScope(ShareScopeAlerts)
+}
+
+type ShareStore interface {
+	GetShareByID(id int32) (*Share, error)
+	GetShareByTokenHash(tokenHash string) (*Share, error)
+	GetSharesByOwnerID(ownerID int32) ([]*Share, error)
+	GetSharesByCaregiverID(caregiverID int32) ([]*Share, error)
+	GetAcceptedShare(ownerID int32, caregiverID int32) (*Share, error)
+	CreateShare(ctx context.Context, share *Share, tokenHash string) (int32, error)
+	AcceptShare(ctx context.Context, id int32, caregiverID int32) (bool, error)
+	UpdateShareAccess(ctx context.Context, id int32, access string, scopes []string) error
+	RevokeShare(ctx context.Context, id int32) (bool, error)
+}
+
+type CreateSharePayload struct {
+	Email  string   `json:"email" validate:"required,email,max=100"`
+	Access string   `json:"access" validate:"required,oneof=read alerts"`
+	Scopes []string `json:"scopes" validate:"omitempty,max=8,dive,required"`
+}
+
+type UpdateSharePayload struct {
+	Access string   `json:"access" validate:"required,oneof=read alerts"`
+	Scopes []string `json:"scopes" validate:"omitempty,max=8,dive,required"`
+}
+
+type AcceptSharePayload struct {
+	Token string `json:"token" validate:"required"`
+}
@@ -0,0 +1,65 @@
+package types
+
+import "time"
+
+// StatsWindows are the reporting periods, in days, supported by the statistics endpoint
+var StatsWindows = []int{1, 7, 14, 30, 90}
+
+// GlucoseThresholds are the boundaries used to bucket readings into ranges
+type GlucoseThresholds struct {
+	VeryLow  float64 `json:"very_low"`
+	Low      float64 `json:"low"`
+	High     float64 `json:"high"`
+	VeryHigh float64 `json:"very_high"`
+}
+
+// GlucoseStats holds the standard CGM metrics for one reporting window.
+// Glucose values are expressed in Unit and all percentages are 0-100.
+type GlucoseStats struct {
+	Days       int               `json:"days"`
+	From       time.Time         `json:"from"`
+	To         time.Time         `json:"to"`
+	Unit       string            `json:"unit"`
+	Thresholds GlucoseThresholds `json:"thresholds"`
+	Readings   int               `json:"readings"`
+
+	Mean                   float64 `json:"mean"`
+	StandardDeviation      float64 `json:"standard_deviation"`
+	CoefficientOfVariation float64 `json:"coefficient_of_variation"`
+	// Glucose management indicator, an estimated A1c in percent
+	GMI float64 `json:"gmi"`
+
+	TimeVeryLow  float64 `json:"time_very_low"`
+	TimeLow      float64 `json:"time_low"`
+	TimeInRange  float64 `json:"time_in_range"`
+	TimeHigh     float64 `json:"time_high"`
+	TimeVeryHigh float64 `json:"time_very_high"`
+}
+
+// AGPPercentiles are the glucose percentiles plotted in an ambulatory glucose profile
+type AGPPercentiles struct {
+	P5  float64 `json:"p5"`
+	P25 float64 `json:"p25"`
+	P50 float64 `json:"p50"`
+	P75 float64 `json:"p75"`
+	P95 float64 `json:"p95"`
+}
+
+// AGPBucket is one time-of-day slot of the ambulatory glucose profile.
+// Percentiles is nil when no readings fall into the slot.
+type AGPBucket struct {
+	// Local start time of the slot, formatted as HH:MM
+	Start       string          `json:"start"`
+	Readings    int             `json:"readings"`
+	Percentiles *AGPPercentiles `json:"percentiles"`
+}
+
+type AGP struct {
+	From          time.Time    `json:"from"`
+	To            time.Time    `json:"to"`
+	Timezone      string       `json:"timezone"`
+	Unit          string       `json:"unit"`
+	BucketMinutes int          `json:"bucket_minutes"`
+	Readings      int          `json:"readings"`
+	Buckets       []*AGPBucket `json:"buckets"`
+}
@@ -0,0 +1,92 @@
+package types
+
+import (
+	"math"
+	"time"
+)
+
+// Timeline event types
+const (
+	TimelineAccountCreated     = "account_created"
+	TimelineSignIn             = "sign_in"
+	TimelineGoal               = "goal"
+	TimelineHealthCondition    = "health_condition"
+	TimelineDietaryRestriction = "dietary_restriction"
+	TimelineGlucoseReading     = "glucose_reading"
+	TimelineMeal               = "meal"
+	TimelineMedicationDose     = "medication_dose"
+	TimelineActivity           = "activity"
+	TimelineLabResult          = "lab_result"
+	TimelineGlucoseAlert       = "glucose_alert"
+	TimelineWeight             = "weight"
+)
+
+// TimelineEvent is one record on the user's timeline. Type tells clients how to
+// read Data, which is the record as its own endpoint returns it. ID is only unique
+// within a type.
+type TimelineEvent struct {
+	Type       string      `json:"type"`
+	ID         int32       `json:"id"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+// TimelinePosition is where an event sits on the timeline. Events are ordered by
+// time, then by type and ID.
+type TimelinePosition struct {
+	OccurredAt time.Time
+	Type       string
+	ID         int32
+}
+
+// TimelineQuery selects the events of a timeline page: those in [Start, End) after
+// the last event of the previous page. Limit is how many events the page needs.
+type TimelineQuery struct {
+	Start time.Time
+	End   time.Time
+	After *TimelinePosition
+	Limit int
+}
+
+// Bound returns where events of the type start on the page. Events at the returned
+// time must also have an ID above the returned ID.
+func (q TimelineQuery) Bound(eventType string) (time.Time, int32) {
+	if q.After == nil || q.After.OccurredAt.Before(q.Start) {
+		return q.Start, 0
+	}
+
+	switch {
+	case eventType < q.After.Type:
+		// Events of the type at the cursor's time came before it
+		return q.After.OccurredAt, math.MaxInt32
+	case eventType > q.After.Type:
+		return q.After.OccurredAt, 0
+	default:
+		return q.After.OccurredAt, q.After.ID
+	}
+}
+
+// Includes reports whether an event belongs on the page, for sources that select
+// their events in memory
+func (q TimelineQuery) Includes(eventType string, occurredAt time.Time, id int32) bool {
+	start, afterID := q.Bound(eventType)
+	if !occurredAt.Before(q.End) {
+		return false
+	}
+	return occurredAt.After(start) || (occurredAt.Equal(start) && id > afterID)
+}
+
+// TimelineSource is implemented by every store whose records belong on the timeline
+type TimelineSource interface {
+	// TimelineTypes lists the event types the source produces
+	TimelineTypes() []string
+	// TimelineEvents returns the user's events the query selects. It returns at least
+	// the first Limit events of each type in time and ID order, and may return more.
+	TimelineEvents(userID int32, query TimelineQuery) ([]*TimelineEvent, error)
+}
+
+// AccountEvent is the data of account_created and sign_in events
+type AccountEvent struct {
+	UserID      int32  `json:"user_id"`
+	Description string `json:"description"`
+}
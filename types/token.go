@@ -0,0 +1,74 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+type RefreshToken struct {
+	ID        int32      `json:"id"`
+	UserID    int32      `json:"user_id"`
+	FamilyID  string     `json:"family_id"`
+	TokenHash string     `json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type RefreshTokenStore interface {
+	CreateRefreshToken(ctx context.Context, token *RefreshToken) error
+	GetRefreshTokenByHash(tokenHash string) (*RefreshToken, error)
+	MarkRefreshTokenUsed(ctx context.Context, id int32) (bool, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	RevokeUserRefreshTokens(ctx context.Context, userID int32) error
+}
+
+type RevokedToken struct {
+	JTI       string    `json:"jti"`
+	UserID    int32     `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type RevocationStore interface {
+	CreateRevokedToken(ctx context.Context, token *RevokedToken) error
+	GetActiveRevokedTokens() ([]*RevokedToken, error)
+	DeleteExpiredRevokedTokens(ctx context.Context) error
+	RevokeUserSessions(ctx context.Context, userID int32, revokedBefore time.Time) error
+	GetUserSessionRevocations() (map[int32]time.Time, error)
+}
+
+type PasswordResetToken struct {
+	ID        int32      `json:"id"`
+	UserID    int32      `json:"user_id"`
+	TokenHash string     `json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type PasswordResetStore interface {
+	CreatePasswordResetToken(ctx context.Context, token *PasswordResetToken) error
+	GetPasswordResetTokenByHash(tokenHash string) (*PasswordResetToken, error)
+	MarkPasswordResetTokenUsed(ctx context.Context, id int32) (bool, error)
+	InvalidateUserPasswordResetTokens(ctx context.Context, userID int32) error
+}
+
+// TokenStore persists the server-side tokens used by the user handler
+type TokenStore interface {
+	RefreshTokenStore
+	PasswordResetStore
+}
+
+type RefreshTokenPayload struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type ForgotPasswordPayload struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type ResetPasswordPayload struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=3,max=20"`
+}
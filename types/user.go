@@ -31,6 +31,8 @@ type UserStore interface {
 	UpdateUserVerification(ctx context.Context, id int32) error
 	DeleteUserByID(ctx context.Context, id int32) error
 	UpdateUserPassword(ctx context.Context, id int32, passwordHash string) error
+	UpdateUsername(ctx context.Context, id int32, username string) error
+	UpdateUserEmail(ctx context.Context, id int32, email string) error
 }
 
 type RegisterUserPayload struct {
@@ -47,8 +49,8 @@ type LoginUserPayload struct {
 }
 
 type UpdateUserInformationPayload struct {
-	Username string `json:"username" validate:"required"`
-	Email    string `json:"email" validate:"required,email"`
+	Username string `json:"username" validate:"omitempty,min=1,max=50"`
+	Email    string `json:"email" validate:"omitempty,email,max=100"`
 }
 
 type ResendVerificationEmailPayload struct {
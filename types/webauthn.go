@@ -0,0 +1,68 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// WebAuthnCredential is a passkey registered by a user
+type WebAuthnCredential struct {
+	ID             int32      `json:"id"`
+	UserID         int32      `json:"user_id"`
+	ExternalID     []byte     `json:"-"`
+	PublicKey      []byte     `json:"-"`
+	Algorithm      int64      `json:"algorithm"`
+	SignCount      uint32     `json:"-"`
+	Transports     []string   `json:"transports"`
+	AAGUID         []byte     `json:"-"`
+	Name           string     `json:"name"`
+	BackupEligible bool       `json:"backup_eligible"`
+	LastUsedAt     *time.Time `json:"last_used_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// WebAuthnChallenge is the challenge of a ceremony in progress. Registration
+// challenges belong to the user adding a passkey; login challenges have no user.
+type WebAuthnChallenge struct {
+	Challenge string
+	Ceremony  string
+	UserID    *int32
+	ExpiresAt time.Time
+}
+
+type WebAuthnStore interface {
+	CreateCredential(ctx context.Context, credential *WebAuthnCredential) error
+	GetCredentialByID(id int32) (*WebAuthnCredential, error)
+	GetCredentialByExternalID(externalID []byte) (*WebAuthnCredential, error)
+	GetCredentialsByUserID(userID int32) ([]*WebAuthnCredential, error)
+	UpdateCredentialUsage(ctx context.Context, id int32, signCount uint32) error
+	DeleteCredential(ctx context.Context, id int32) error
+	CreateChallenge(ctx context.Context, challenge *WebAuthnChallenge) error
+	ConsumeChallenge(ctx context.Context, challenge string) (*WebAuthnChallenge, error)
+}
+
+type PasskeyRegistrationResponse struct {
+	ClientDataJSON    string   `json:"clientDataJSON" validate:"required"`
+	AttestationObject string   `json:"attestationObject" validate:"required"`
+	Transports        []string `json:"transports" validate:"omitempty,max=6"`
+}
+
+type PasskeyRegistrationPayload struct {
+	ID       string                      `json:"id" validate:"required"`
+	Type     string                      `json:"type" validate:"required,eq=public-key"`
+	Response PasskeyRegistrationResponse `json:"response" validate:"required"`
+	Name     string                      `json:"name" validate:"omitempty,max=64"`
+}
+
+type PasskeyAssertionResponse struct {
+	ClientDataJSON    string `json:"clientDataJSON" validate:"required"`
+	AuthenticatorData string `json:"authenticatorData" validate:"required"`
+	Signature         string `json:"signature" validate:"required"`
+	UserHandle        string `json:"userHandle"`
+}
+
+type PasskeyLoginPayload struct {
+	ID       string                   `json:"id" validate:"required"`
+	Type     string                   `json:"type" validate:"required,eq=public-key"`
+	Response PasskeyAssertionResponse `json:"response" validate:"required"`
+}
@@ -2,13 +2,29 @@ package utils
 
 import (
 	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
 
 	"github.com/jayden1905/abundance/types"
 )
 
-var Validate = validator.New()
+var Validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+
+	// Dietary restrictions must come from the curated vocabulary
+	v.RegisterValidation("dietary_restriction", func(fl validator.FieldLevel) bool {
+		return slices.Contains(types.DietaryRestrictionOptions, fl.Field().String())
+	})
+
+	return v
+}
 
 func ValidatePayload(payload interface{}) (map[string]string, error) {
 	err := Validate.Struct(payload)
@@ -39,6 +55,16 @@ func IsSuperUser(userID int32, store types.UserStore) (bool, error) {
 	return false, nil
 }
 
+// HasAnyRole checks whether the user has one of the given roles
+func HasAnyRole(userID int32, store types.UserStore, roles ...string) (bool, error) {
+	role, err := store.GetUserRoleByID(userID)
+	if err != nil {
+		return false, fmt.Errorf("error getting user role by id: %v", err)
+	}
+
+	return slices.Contains(roles, role), nil
+}
+
 func ConvertRoleStringToRoleID(role string) int8 {
 	switch role {
 	case "free_user":
@@ -68,3 +94,94 @@ func ConvertSubscriptionStringToSubscriptionID(subscription string) int8 {
 		return 1
 	}
 }
+
+// ParseTimeRange parses RFC3339 "from" and "to" query values, defaulting to the
+// window ending now when they are omitted
+func ParseTimeRange(fromStr string, toStr string, defaultWindow time.Duration) (time.Time, time.Time, error) {
+	to := time.Now().UTC()
+	if toStr != "" {
+		t, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid 'to' time: %v", err)
+		}
+		to = t
+	}
+
+	from := to.Add(-defaultWindow)
+	if fromStr != "" {
+		f, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid 'from' time: %v", err)
+		}
+		from = f
+	}
+
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("'from' must be before 'to'")
+	}
+
+	return from, to, nil
+}
+
+// LimitBody rejects request bodies larger than limit. The server streams bodies over the
+// default limit instead of reading them, so routes only read larger bodies where this
+// allows them. Requests for which skip returns true are left to a route's own limit.
+func LimitBody(limit int, skip func(c *fiber.Ctx) bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if skip != nil && skip(c) {
+			return c.Next()
+		}
+
+		req := c.Request()
+		contentLength := req.Header.ContentLength()
+		if contentLength > limit {
+			return bodyTooLarge(c)
+		}
+
+		// Chunked bodies have no length up front, so read them up to the limit
+		if contentLength < 0 && req.IsBodyStream() {
+			body, err := io.ReadAll(io.LimitReader(req.BodyStream(), int64(limit)+1))
+			if err != nil {
+				c.Context().SetConnectionClose()
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Error reading request body"})
+			}
+			if len(body) > limit {
+				return bodyTooLarge(c)
+			}
+			req.SetBody(body)
+		}
+
+		// A streamed body the handler does not read to the end would be taken for the
+		// next request on the connection
+		if contentLength > fiber.DefaultBodyLimit {
+			c.Context().SetConnectionClose()
+		}
+
+		return c.Next()
+	}
+}
+
+// bodyTooLarge rejects a request without reading its body, so the connection cannot be reused
+func bodyTooLarge(c *fiber.Ctx) error {
+	c.Context().SetConnectionClose()
+	return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{"error": "Request body is too large"})
+}
+
+// UniqueNames trims the names and drops empty entries and entries that repeat an
+// earlier one in any case
+func UniqueNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	var result []string
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, name)
+	}
+
+	return result
+}